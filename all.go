@@ -0,0 +1,128 @@
+// Copyright (c) 2023–present Bartłomiej Krukowski
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is furnished
+// to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package exporter
+
+import (
+	"fmt"
+	"reflect"
+	"strings"
+)
+
+// ExportAllError reports values that cannot be exported by ExportAll.
+type ExportAllError struct {
+	// Errors has the same length as the exported values, it contains nil for values that have been exported.
+	Errors []error
+}
+
+func (e *ExportAllError) Error() string {
+	parts := make([]string, 0, len(e.Errors))
+
+	for i, err := range e.Errors {
+		if err != nil {
+			parts = append(parts, fmt.Sprintf("[%d]: %s", i, err.Error()))
+		}
+	}
+
+	return fmt.Sprintf("cannot export %d of %d values: %s", len(parts), len(e.Errors), strings.Join(parts, "; "))
+}
+
+// Unwrap returns the errors of values that cannot be exported.
+func (e *ExportAllError) Unwrap() []error {
+	result := make([]error, 0, len(e.Errors))
+
+	for _, err := range e.Errors {
+		if err != nil {
+			result = append(result, err)
+		}
+	}
+
+	return result
+}
+
+// ExportAll exports the given values, and returns their codes in the same order.
+//
+// See Exporter.ExportAll.
+func ExportAll(vs ...any) ([]string, error) {
+	return defaultExporter.ExportAll(vs...)
+}
+
+// ExportAll exports the given values, and returns their codes in the same order.
+// It shares the results of checking which types are supported across all values,
+// so batch generators can export thousands of small values of the same types efficiently.
+//
+// ExportAll exports all values even if some of them cannot be exported.
+// Codes of such values are empty, and the returned error is *ExportAllError.
+func (e *Exporter) ExportAll(vs ...any) ([]string, error) {
+	cfg := e.config
+	cfg.supportedTypes = make(map[reflect.Type]bool)
+	exp := newRootExporter(cfg)
+
+	var (
+		result = make([]string, len(vs))
+		errs   []error
+	)
+
+	for i, v := range vs {
+		code, err := exp.export(v)
+		if err != nil {
+			if errs == nil {
+				errs = make([]error, len(vs))
+			}
+
+			errs[i] = err
+
+			continue
+		}
+
+		result[i] = code
+	}
+
+	if errs != nil {
+		return result, &ExportAllError{Errors: errs}
+	}
+
+	return result, nil
+}
+
+// typeCacheExporter remembers which types are supported by the next exporter, see supportsType.
+// It lets many values share the results, see Exporter.ExportAll.
+type typeCacheExporter struct {
+	types map[reflect.Type]bool
+	next  exporter
+}
+
+func (c *typeCacheExporter) export(v any) (string, error) {
+	return c.next.export(v) //nolint:wrapcheck
+}
+
+func (c *typeCacheExporter) supports(v any) bool {
+	return c.next.supports(v)
+}
+
+func (c *typeCacheExporter) supportsType(t reflect.Type) bool {
+	r, ok := c.types[t]
+	if !ok {
+		r = c.next.supports(reflect.Zero(t).Interface())
+		c.types[t] = r
+	}
+
+	return r
+}
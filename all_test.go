@@ -0,0 +1,80 @@
+// Copyright (c) 2023–present Bartłomiej Krukowski
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is furnished
+// to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package exporter_test
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/gontainer/exporter"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestExportAll(t *testing.T) {
+	t.Parallel()
+
+	t.Run("OK", func(t *testing.T) {
+		t.Parallel()
+
+		codes, err := exporter.ExportAll(1, "a", []StructServer{{Port: 80}}, []StructServer{})
+		require.NoError(t, err)
+		assert.Equal(
+			t,
+			[]string{
+				`int(1)`,
+				`"a"`,
+				`[]exporter_test.StructServer{exporter_test.StructServer{Port: int(80)}}`,
+				`make([]exporter_test.StructServer, 0)`,
+			},
+			codes,
+		)
+	})
+
+	t.Run("No values", func(t *testing.T) {
+		t.Parallel()
+
+		codes, err := exporter.ExportAll()
+		require.NoError(t, err)
+		assert.Empty(t, codes)
+	})
+
+	t.Run("Errors", func(t *testing.T) {
+		t.Parallel()
+
+		e := exporter.New(exporter.WithMaxNodes(2))
+		codes, err := e.ExportAll([]int{1}, make(chan int), []int{1, 2}, true)
+		assert.Equal(t, []string{`[]int{int(1)}`, ``, ``, `true`}, codes)
+		assert.EqualError(
+			t,
+			err,
+			`cannot export 2 of 4 values: [1]: type chan int is not supported; `+
+				`[2]: cannot export ([]int)[1]: the value contains more than 2 nodes, see WithMaxNodes`,
+		)
+
+		var target *exporter.ExportAllError
+
+		require.True(t, errors.As(err, &target))
+		assert.Len(t, target.Errors, 4)
+		assert.NoError(t, target.Errors[0])
+		assert.EqualError(t, target.Errors[1], `type chan int is not supported`)
+	})
+}
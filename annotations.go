@@ -0,0 +1,48 @@
+// Copyright (c) 2023–present Bartłomiej Krukowski
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is furnished
+// to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package exporter
+
+import (
+	"strings"
+)
+
+// WithIndexComments annotates elements of slices, arrays and maps with at least minElements elements
+// by trailing comments with their indices or keys, e.g.:
+//
+//	[]string{"a" /* [0] */, "b" /* [1] */}
+//	map[string]int{"a": int(1) /* ["a"] */}
+//
+// It makes it feasible to locate a particular element in a huge generated fixture.
+// A non-positive value disables annotations, it is the default behavior. The JSON backend ignores this option.
+func WithIndexComments(minElements int) Option {
+	return func(c *config) {
+		c.indexComments = minElements
+	}
+}
+
+func annotate(minElements int, size int) bool {
+	return minElements > 0 && size >= minElements
+}
+
+// commentText returns the given text that can be safely embedded in a block comment.
+func commentText(s string) string {
+	return strings.ReplaceAll(s, "*/", "* /")
+}
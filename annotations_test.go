@@ -0,0 +1,87 @@
+// Copyright (c) 2023–present Bartłomiej Krukowski
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is furnished
+// to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package exporter_test
+
+import (
+	"testing"
+
+	"github.com/gontainer/exporter"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestWithIndexComments(t *testing.T) {
+	t.Parallel()
+
+	scenarios := []struct {
+		name    string
+		input   any
+		output  string
+		json    string
+		options []exporter.Option
+	}{
+		{
+			name:   "Slice",
+			input:  []string{"a", "b"},
+			output: `[]string{"a" /* [0] */, "b" /* [1] */}`,
+			json:   `["a","b"]`,
+		},
+		{
+			name:   "Small slice",
+			input:  []string{"a"},
+			output: `[]string{"a"}`,
+			json:   `["a"]`,
+		},
+		{
+			name:   "Map",
+			input:  map[string]int{"b": 2, "a*/": 1},
+			output: `map[string]int{"a*/": int(1) /* ["a* /"] */, "b": int(2) /* ["b"] */}`,
+			json:   `{"a*/":1,"b":2}`,
+		},
+		{
+			name:   "Nested",
+			input:  [][]int{{1, 2}, {3}},
+			output: `[][]int{[]int{int(1) /* [0] */, int(2) /* [1] */} /* [0] */, []int{int(3)} /* [1] */}`,
+			json:   `[[1,2],[3]]`,
+		},
+		{
+			name:  "Chunks",
+			input: []int{1, 2, 3},
+			output: `func() []int { v := make([]int, 0, 3); v = append(v, int(1) /* [0] */, int(2) /* [1] */); ` +
+				`v = append(v, int(3) /* [2] */); return v }()`,
+			json:    `[1,2,3]`,
+			options: []exporter.Option{exporter.WithLiteralLimits(2, 0)},
+		},
+	}
+
+	for _, s := range scenarios {
+		s := s
+
+		t.Run(s.name, func(t *testing.T) {
+			t.Parallel()
+
+			opts := append([]exporter.Option{exporter.WithIndexComments(2)}, s.options...)
+			assert.Equal(t, s.output, exporter.New(opts...).MustExport(s.input))
+
+			opts = append(opts, exporter.WithBackend(exporter.JSONBackend()))
+			assert.Equal(t, s.json, exporter.New(opts...).MustExport(s.input))
+		})
+	}
+}
@@ -0,0 +1,83 @@
+// Copyright (c) 2023–present Bartłomiej Krukowski
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is furnished
+// to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package exporter
+
+import (
+	"go/scanner"
+	"go/token"
+	"strings"
+)
+
+// WithAnyAlias makes the GO backend write the alias any instead of the empty interface, e.g.:
+//
+//	[]any{int(1), "a"}
+//
+// It applies to Exporter.Export, Exporter.ExportPretty, Exporter.ExportAST, and to files generated by ExportFile,
+// that require GO 1.18 or newer then.
+func WithAnyAlias() Option {
+	return func(c *config) {
+		c.anyAlias = true
+	}
+}
+
+// replaceEmptyInterfaces replaces the empty interfaces in the given GO code by the alias any.
+// String literals and comments are left untouched.
+func replaceEmptyInterfaces(code string) string {
+	type scanned struct {
+		offset int
+		tok    token.Token
+	}
+
+	fset := token.NewFileSet()
+
+	var s scanner.Scanner
+	s.Init(fset.AddFile("", -1, len(code)), []byte(code), nil, scanner.ScanComments)
+
+	var (
+		buf    strings.Builder
+		last   int
+		window []scanned
+	)
+
+	for {
+		pos, tok, _ := s.Scan()
+		if tok == token.EOF {
+			break
+		}
+
+		window = append(window, scanned{offset: fset.Position(pos).Offset, tok: tok})
+		if len(window) > 3 { //nolint:gomnd
+			window = window[1:]
+		}
+
+		if len(window) == 3 && window[0].tok == token.INTERFACE && window[1].tok == token.LBRACE &&
+			window[2].tok == token.RBRACE {
+			buf.WriteString(code[last:window[0].offset])
+			buf.WriteString("any")
+			last = window[2].offset + 1
+			window = nil
+		}
+	}
+
+	buf.WriteString(code[last:])
+
+	return buf.String()
+}
@@ -0,0 +1,88 @@
+// Copyright (c) 2023–present Bartłomiej Krukowski
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is furnished
+// to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package exporter_test
+
+import (
+	"bytes"
+	"go/printer"
+	"go/token"
+	"io/ioutil" //nolint:staticcheck
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/gontainer/exporter"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestWithAnyAlias(t *testing.T) {
+	t.Parallel()
+
+	e := exporter.New(exporter.WithAnyAlias())
+
+	t.Run("Export", func(t *testing.T) {
+		t.Parallel()
+
+		assert.Equal(
+			t,
+			`map[string]any{"interface{}": []interface { Do() }{nil}}`,
+			e.MustExport(map[string]any{"interface{}": []interface{ Do() }{nil}}),
+		)
+		assert.Equal(t, `make([]interface{}, 0)`, exporter.MustExport([]any{}))
+	})
+
+	t.Run("ExportPretty", func(t *testing.T) {
+		t.Parallel()
+
+		code, err := e.ExportPretty([]any{1})
+		require.NoError(t, err)
+		assert.Equal(t, "[]any{\n\tint(1),\n}", code)
+	})
+
+	t.Run("ExportAST", func(t *testing.T) {
+		t.Parallel()
+
+		expr, err := e.ExportAST([]any{1})
+		require.NoError(t, err)
+
+		buf := bytes.NewBuffer(nil)
+		require.NoError(t, printer.Fprint(buf, token.NewFileSet(), expr))
+		assert.Equal(t, "[]any{int(1)}", buf.String())
+	})
+
+	t.Run("ExportFile", func(t *testing.T) {
+		t.Parallel()
+
+		code, err := exporter.New(exporter.WithAnyAlias(), exporter.WithDeclaration(exporter.DeclarationFunc)).
+			ExportFile("fixtures", "Values", []any{1})
+		require.NoError(t, err)
+		assert.Contains(t, string(code), "func Values() []any {\n\treturn []any{int(1)}\n}\n")
+
+		dir, err := ioutil.TempDir("", "exporter") //nolint:staticcheck
+		require.NoError(t, err)
+		defer os.RemoveAll(dir)
+
+		path := filepath.Join(dir, "values.go")
+		require.NoError(t, ioutil.WriteFile(path, code, 0o600)) //nolint:staticcheck
+		assert.NoError(t, exporter.VerifyGenerated(path))
+	})
+}
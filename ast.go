@@ -0,0 +1,62 @@
+// Copyright (c) 2023–present Bartłomiej Krukowski
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is furnished
+// to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package exporter
+
+import (
+	"errors"
+	"fmt"
+	"go/ast"
+	"go/parser"
+)
+
+// ExportAST exports the given value to a tree of go/ast nodes, e.g. *ast.CompositeLit,
+// that can be embedded into files built by go/ast and printed by go/printer:
+//
+//	expr, err := exporter.ExportAST([]int{1, 2})
+//	// &ast.CompositeLit{Type: &ast.ArrayType{...}, Elts: []ast.Expr{&ast.CallExpr{...}, &ast.CallExpr{...}}}
+//
+// The nodes do not have valid positions, and comments, e.g. WithIndexComments, are dropped.
+//
+// See Exporter.ExportAST.
+func ExportAST(v any) (ast.Expr, error) {
+	return defaultExporter.ExportAST(v)
+}
+
+// ExportAST exports the given value to a tree of go/ast nodes, see the function ExportAST.
+func (e *Exporter) ExportAST(v any) (ast.Expr, error) {
+	if _, ok := e.config.backend.(goBackend); !ok {
+		return nil, errors.New("ExportAST requires the GO backend") //nolint:goerr113
+	}
+
+	code, err := e.exportCode(v)
+	if err != nil {
+		return nil, err
+	}
+
+	expr, err := parser.ParseExpr(code)
+	if err != nil {
+		return nil, fmt.Errorf("cannot parse exported code: %w", err)
+	}
+
+	clearPositions(expr)
+
+	return expr, nil
+}
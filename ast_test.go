@@ -0,0 +1,84 @@
+// Copyright (c) 2023–present Bartłomiej Krukowski
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is furnished
+// to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package exporter_test
+
+import (
+	"bytes"
+	"go/ast"
+	"go/printer"
+	"go/token"
+	"testing"
+
+	"github.com/gontainer/exporter"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestExportAST(t *testing.T) {
+	t.Parallel()
+
+	t.Run("Composite literal", func(t *testing.T) {
+		t.Parallel()
+
+		expr, err := exporter.ExportAST(map[string][]int{"a": {1, 2}})
+		require.NoError(t, err)
+
+		lit, ok := expr.(*ast.CompositeLit)
+		require.True(t, ok)
+		require.Len(t, lit.Elts, 1)
+		assert.IsType(t, &ast.KeyValueExpr{}, lit.Elts[0]) //nolint:exhaustruct
+
+		ast.Inspect(expr, func(n ast.Node) bool {
+			if n != nil {
+				assert.Equal(t, token.NoPos, n.Pos())
+			}
+
+			return true
+		})
+
+		buf := bytes.NewBuffer(nil)
+		require.NoError(t, printer.Fprint(buf, token.NewFileSet(), expr))
+		assert.Equal(t, `map[string][]int{"a": []int{int(1), int(2)}}`, buf.String())
+	})
+
+	t.Run("Options", func(t *testing.T) {
+		t.Parallel()
+
+		expr, err := exporter.New(exporter.WithIndent("\t"), exporter.WithGofmt(true)).ExportAST([]string{"a"})
+		require.NoError(t, err)
+
+		buf := bytes.NewBuffer(nil)
+		require.NoError(t, printer.Fprint(buf, token.NewFileSet(), expr))
+		assert.Equal(t, `[]string{"a"}`, buf.String())
+	})
+
+	t.Run("Errors", func(t *testing.T) {
+		t.Parallel()
+
+		expr, err := exporter.ExportAST(make(chan int))
+		assert.EqualError(t, err, "type chan int is not supported")
+		assert.Nil(t, expr)
+
+		expr, err = exporter.New(exporter.WithBackend(exporter.JSONBackend())).ExportAST(5)
+		assert.EqualError(t, err, "ExportAST requires the GO backend")
+		assert.Nil(t, expr)
+	})
+}
@@ -0,0 +1,98 @@
+// Copyright (c) 2023–present Bartłomiej Krukowski
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is furnished
+// to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package exporter
+
+import (
+	"fmt"
+	"reflect"
+)
+
+// WithAtomicValues enables exporting values of the type atomic.Value.
+// The type of the stored value is not known statically, so atomic.Value is not exported by default.
+func WithAtomicValues() Option {
+	return func(c *config) {
+		c.atomicValues = true
+	}
+}
+
+//nolint:gochecknoglobals
+var atomicTypes = map[string]struct{}{
+	"Bool":    {},
+	"Int32":   {},
+	"Int64":   {},
+	"Uint32":  {},
+	"Uint64":  {},
+	"Uintptr": {},
+	"Value":   {},
+}
+
+func isAtomic(t reflect.Type) bool {
+	if t == nil || t.Kind() != reflect.Struct || t.PkgPath() != "sync/atomic" {
+		return false
+	}
+
+	_, ok := atomicTypes[t.Name()]
+
+	return ok
+}
+
+// atomicExporter exports types from the package sync/atomic, e.g. atomic.Int64 and atomic.Bool.
+// It reads the stored value and generates the code that stores it again, e.g.:
+//
+//	func() (v atomic.Int64) { v.Store(int64(5)); return }()
+//
+// Atomic types must not be copied, so the function returns the named result by the naked return,
+// that is accepted by the check "copylocks" of "go vet".
+type atomicExporter struct {
+	exporter exporter
+	backend  Backend
+	values   bool
+}
+
+func (a atomicExporter) export(v any) (string, error) {
+	val := reflect.ValueOf(v)
+	t := val.Type()
+
+	if t.Name() == "Value" && !a.values && !val.IsZero() {
+		return "", fmt.Errorf("type %s is not supported, see WithAtomicValues", typeName(t)) //nolint:goerr113
+	}
+
+	// methods of atomic types have pointer receivers
+	ptr := reflect.New(t)
+	ptr.Elem().Set(val)
+	stored := ptr.MethodByName("Load").Call(nil)[0]
+
+	// atomic.Value.Load returns nil, when nothing has been stored
+	if stored.Kind() == reflect.Interface && stored.IsNil() {
+		return a.backend.renderAtomic(t, a.backend.renderNil(), true), nil
+	}
+
+	code, err := a.exporter.export(stored.Interface())
+	if err != nil {
+		return "", fmt.Errorf("cannot export value stored in %s: %w", typeName(t), err)
+	}
+
+	return a.backend.renderAtomic(t, code, val.IsZero()), nil
+}
+
+func (atomicExporter) supports(v any) bool {
+	return isAtomic(reflect.TypeOf(v))
+}
@@ -0,0 +1,179 @@
+// Copyright (c) 2023–present Bartłomiej Krukowski
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is furnished
+// to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+//go:build go1.19
+// +build go1.19
+
+package exporter_test
+
+import (
+	"reflect"
+	"sync/atomic"
+	"testing"
+
+	"github.com/gontainer/exporter"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type atomicStats struct {
+	Hits    atomic.Int64
+	Enabled atomic.Bool
+	Name    string
+}
+
+// valueOf copies the value the given pointer points to without triggering "copylocks" warnings.
+func valueOf(ptr any) any {
+	return reflect.ValueOf(ptr).Elem().Interface()
+}
+
+//nolint:testifylint
+func TestExport_atomic(t *testing.T) {
+	t.Parallel()
+
+	i32 := new(atomic.Int32)
+	i32.Store(-5)
+
+	u64 := new(atomic.Uint64)
+	u64.Store(5)
+
+	b := new(atomic.Bool)
+	b.Store(true)
+
+	stats := new(atomicStats)
+	stats.Hits.Store(100)
+	stats.Name = "stats"
+
+	v := new(atomic.Value)
+	v.Store([]string{"hello"})
+
+	//nolint:exhaustruct
+	scenarios := []struct {
+		name    string
+		input   any
+		output  string
+		json    string
+		error   string
+		options []exporter.Option
+	}{
+		{
+			name:   "Zero value",
+			input:  valueOf(new(atomic.Int64)),
+			output: `atomic.Int64{}`,
+			json:   `0`,
+		},
+		{
+			name:   "atomic.Int32",
+			input:  valueOf(i32),
+			output: `func() (v atomic.Int32) { v.Store(int32(-5)); return }()`,
+			json:   `-5`,
+		},
+		{
+			name:   "atomic.Uint64",
+			input:  valueOf(u64),
+			output: `func() (v atomic.Uint64) { v.Store(uint64(5)); return }()`,
+			json:   `5`,
+		},
+		{
+			name:   "atomic.Bool",
+			input:  valueOf(b),
+			output: `func() (v atomic.Bool) { v.Store(true); return }()`,
+			json:   `true`,
+		},
+		{
+			name:   "Pointer",
+			input:  i32,
+			output: `func() *atomic.Int32 { v := new(atomic.Int32); v.Store(int32(-5)); return v }()`,
+			json:   `-5`,
+		},
+		{
+			name:   "Pointer to zero value",
+			input:  new(atomic.Int64),
+			output: `&atomic.Int64{}`,
+			json:   `0`,
+		},
+		{
+			name:  "Struct",
+			input: valueOf(stats),
+			output: `exporter_test.atomicStats{Hits: func() (v atomic.Int64) { v.Store(int64(100)); return }(), ` +
+				`Name: "stats"}`,
+			json: `{"Hits":100,"Name":"stats"}`,
+		},
+		{
+			name:   "Empty atomic.Value",
+			input:  valueOf(new(atomic.Value)),
+			output: `atomic.Value{}`,
+			json:   `null`,
+		},
+		{
+			name:    "atomic.Value",
+			input:   valueOf(v),
+			output:  `func() (v atomic.Value) { v.Store([]string{"hello"}); return }()`,
+			json:    `["hello"]`,
+			options: []exporter.Option{exporter.WithAtomicValues()},
+		},
+		{
+			name:  "atomic.Value disabled",
+			input: valueOf(v),
+			error: `type atomic.Value is not supported, see WithAtomicValues`,
+		},
+	}
+
+	t.Run("go vet", func(t *testing.T) {
+		t.Parallel()
+
+		counters := new(struct {
+			Hits    atomic.Int64
+			Enabled atomic.Bool
+		})
+		counters.Hits.Store(100)
+
+		for _, d := range []exporter.Declaration{exporter.DeclarationVar, exporter.DeclarationFunc} {
+			code, err := exporter.New(exporter.WithDeclaration(d)).ExportFile("fixtures", "Counters", valueOf(counters))
+			require.NoError(t, err)
+			assert.Contains(t, string(code), "Hits: func() (v atomic.Int64) { v.Store(int64(100)); return }()")
+			vetFile(t, code)
+		}
+	})
+
+	for _, s := range scenarios {
+		s := s
+
+		t.Run(s.name, func(t *testing.T) {
+			t.Parallel()
+
+			output, err := exporter.New(s.options...).Export(s.input)
+			if s.error != "" {
+				assert.EqualError(t, err, s.error)
+				assert.Empty(t, output)
+
+				return
+			}
+
+			require.NoError(t, err)
+			assert.Equal(t, s.output, output)
+
+			opts := append([]exporter.Option{exporter.WithBackend(exporter.JSONBackend())}, s.options...)
+			output, err = exporter.New(opts...).Export(s.input)
+			require.NoError(t, err)
+			assert.Equal(t, s.json, output)
+		})
+	}
+}
@@ -0,0 +1,341 @@
+// Copyright (c) 2023–present Bartłomiej Krukowski
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is furnished
+// to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package exporter
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"strings"
+)
+
+// Backend renders exported values in a target language. See GoBackend, JSONBackend and CUEBackend.
+//
+// All backends share the same traversal of the input value, they differ in the way they render its nodes.
+// Methods of Backend are not exported, so it cannot be implemented outside this package.
+type Backend interface {
+	renderNil() string
+	renderNumber(t reflect.Type, literal string) (string, error)
+	renderString(v string) string
+	renderBytes(v []byte) string
+	renderNilSlice(t reflect.Type) string
+	renderEmptySlice(t reflect.Type) string
+	renderSequence(t reflect.Type, elements []string) string
+	renderChunkedSequence(t reflect.Type, chunks [][]string) string
+	renderStruct(t reflect.Type, fields []string, values []string) string
+	renderNilMap(t reflect.Type) string
+	renderMap(t reflect.Type, keys []string, values []string) (string, error)
+	renderChunkedMap(t reflect.Type, keys [][]string, values [][]string) (string, error)
+	renderConversion(t reflect.Type, value string) string
+	renderAtomic(t reflect.Type, value string, zero bool) string
+	renderAnnotation(value string, annotation string) string
+	renderNilPointer(t reflect.Type) string
+	renderPointer(t reflect.Type, value string) string
+	// exporters returns additional exporters specific to the given backend,
+	// the given exporter must be used to export nested values.
+	exporters(cfg config, nested exporter) []exporter
+}
+
+// GoBackend renders values as a GO code. This is the default backend.
+func GoBackend() Backend { //nolint:ireturn
+	return goBackend{}
+}
+
+// JSONBackend renders values as a JSON. Numbers are rendered without their types,
+// byte slices are rendered as strings, structs and maps are rendered as objects.
+// Like encoding/json, it renames fields by their "json" tags, and it skips fields tagged as "-".
+// Values of time.Time are rendered as strings in the format time.RFC3339Nano,
+// and errors created by errors.New, fmt.Errorf and errors.Join, and sentinel errors, as their messages.
+func JSONBackend() Backend { //nolint:ireturn
+	return jsonBackend{}
+}
+
+type goBackend struct {
+	quote QuoteStyle
+}
+
+func (goBackend) renderNil() string {
+	return "nil"
+}
+
+func (goBackend) renderNumber(t reflect.Type, literal string) (string, error) {
+	return fmt.Sprintf("%s(%s)", t.Kind().String(), literal), nil
+}
+
+func (b goBackend) renderString(v string) string {
+	return quote(v, b.quote)
+}
+
+func (b goBackend) renderBytes(v []byte) string {
+	return fmt.Sprintf("[]byte(%s)", b.renderString(string(v)))
+}
+
+func (goBackend) renderNilSlice(t reflect.Type) string {
+	return fmt.Sprintf("(%s)(nil)", typeName(t))
+}
+
+func (goBackend) renderEmptySlice(t reflect.Type) string {
+	return fmt.Sprintf("make(%s, 0)", typeName(t))
+}
+
+func (goBackend) renderSequence(t reflect.Type, elements []string) string {
+	return typeName(t) + "{" + strings.Join(elements, ", ") + "}"
+}
+
+func (goBackend) renderChunkedSequence(t reflect.Type, chunks [][]string) string {
+	var (
+		buf  strings.Builder
+		name = typeName(t)
+		n    = 0
+	)
+
+	for _, c := range chunks {
+		n += len(c)
+	}
+
+	if t.Kind() == reflect.Array {
+		buf.WriteString(fmt.Sprintf("func() %s { var v %s; ", name, name))
+
+		offset := 0
+		for _, c := range chunks {
+			buf.WriteString(fmt.Sprintf("copy(v[%d:], []%s{%s}); ", offset, typeName(t.Elem()), strings.Join(c, ", ")))
+			offset += len(c)
+		}
+	} else {
+		buf.WriteString(fmt.Sprintf("func() %s { v := make(%s, 0, %d); ", name, name, n))
+
+		for _, c := range chunks {
+			buf.WriteString(fmt.Sprintf("v = append(v, %s); ", strings.Join(c, ", ")))
+		}
+	}
+
+	buf.WriteString("return v }()")
+
+	return buf.String()
+}
+
+func (goBackend) renderStruct(t reflect.Type, fields []string, values []string) string {
+	parts := make([]string, len(fields))
+	for i, f := range fields {
+		parts[i] = f + ": " + values[i]
+	}
+
+	return typeName(t) + "{" + strings.Join(parts, ", ") + "}"
+}
+
+func (goBackend) renderNilMap(t reflect.Type) string {
+	return fmt.Sprintf("(%s)(nil)", typeName(t))
+}
+
+func (goBackend) renderMap(t reflect.Type, keys []string, values []string) (string, error) {
+	parts := make([]string, len(keys))
+	for i, k := range keys {
+		parts[i] = k + ": " + values[i]
+	}
+
+	return typeName(t) + "{" + strings.Join(parts, ", ") + "}", nil
+}
+
+func (b goBackend) renderChunkedMap(t reflect.Type, keys [][]string, values [][]string) (string, error) {
+	var (
+		buf  strings.Builder
+		name = typeName(t)
+		n    = 0
+	)
+
+	for _, c := range keys {
+		n += len(c)
+	}
+
+	buf.WriteString(fmt.Sprintf("func() %s { v := make(%s, %d); ", name, name, n))
+
+	for i := range keys {
+		chunk, err := b.renderMap(t, keys[i], values[i])
+		if err != nil {
+			return "", err
+		}
+
+		buf.WriteString(fmt.Sprintf("for k, e := range %s { v[k] = e }; ", chunk))
+	}
+
+	buf.WriteString("return v }()")
+
+	return buf.String(), nil
+}
+
+func (goBackend) renderConversion(t reflect.Type, value string) string {
+	return fmt.Sprintf("%s(%s)", typeName(t), value)
+}
+
+func (goBackend) renderNilPointer(t reflect.Type) string {
+	return fmt.Sprintf("(%s)(nil)", typeName(t))
+}
+
+func (goBackend) renderPointer(t reflect.Type, value string) string {
+	return renderGoPointer(t, value)
+}
+
+// goAtomicPrefix and goAtomicSuffix surround values stored in atomic types, the prefix contains the type.
+// The naked return does not copy the value, so "go vet" does not report copying the lock.
+const (
+	goAtomicPrefix = "func() (v %s) { v.Store("
+	goAtomicSuffix = "); return }()"
+)
+
+func (goBackend) renderAtomic(t reflect.Type, value string, zero bool) string {
+	if zero {
+		return typeName(t) + "{}"
+	}
+
+	return fmt.Sprintf(goAtomicPrefix, typeName(t)) + value + goAtomicSuffix
+}
+
+func (goBackend) renderAnnotation(value string, annotation string) string {
+	return value + " /* " + commentText(annotation) + " */"
+}
+
+func (b goBackend) exporters(cfg config, nested exporter) []exporter {
+	return []exporter{
+		&timeExporter{location: cfg.timeLocation},
+		&errorExporter{exporter: nested, sentinels: cfg.sentinels},
+		&fileModeExporter{},
+		&structTagExporter{},
+		&netExporter{backend: b},
+		&htmlTemplateExporter{backend: b},
+		&orderedMap{exporter: nested},
+		&listExporter{exporter: nested, integralFloats: cfg.integralFloats},
+		&ringExporter{exporter: nested, integralFloats: cfg.integralFloats},
+		&readerExporter{exporter: nested},
+	}
+}
+
+type jsonBackend struct{}
+
+func (jsonBackend) renderNil() string {
+	return "null"
+}
+
+func (jsonBackend) renderNumber(t reflect.Type, literal string) (string, error) {
+	if !json.Valid([]byte(literal)) {
+		return "", fmt.Errorf("%s(%s) cannot be represented in JSON", t.Kind().String(), literal) //nolint:goerr113
+	}
+
+	return literal, nil
+}
+
+func (jsonBackend) renderString(v string) string {
+	var buf bytes.Buffer
+
+	enc := json.NewEncoder(&buf)
+	enc.SetEscapeHTML(false)
+	_ = enc.Encode(v) // encoding a string cannot fail
+
+	return strings.TrimSuffix(buf.String(), "\n")
+}
+
+func (b jsonBackend) renderBytes(v []byte) string {
+	return b.renderString(string(v))
+}
+
+func (jsonBackend) renderNilSlice(reflect.Type) string {
+	return "null"
+}
+
+func (jsonBackend) renderEmptySlice(reflect.Type) string {
+	return "[]"
+}
+
+func (jsonBackend) renderSequence(_ reflect.Type, elements []string) string {
+	return "[" + strings.Join(elements, ",") + "]"
+}
+
+func (b jsonBackend) renderChunkedSequence(t reflect.Type, chunks [][]string) string {
+	elements := make([]string, 0)
+	for _, c := range chunks {
+		elements = append(elements, c...)
+	}
+
+	return b.renderSequence(t, elements)
+}
+
+func (b jsonBackend) renderStruct(_ reflect.Type, fields []string, values []string) string {
+	parts := make([]string, len(fields))
+	for i, f := range fields {
+		parts[i] = b.renderString(f) + ":" + values[i]
+	}
+
+	return "{" + strings.Join(parts, ",") + "}"
+}
+
+func (jsonBackend) renderNilMap(reflect.Type) string {
+	return "null"
+}
+
+func (jsonBackend) renderMap(_ reflect.Type, keys []string, values []string) (string, error) {
+	parts := make([]string, len(keys))
+
+	for i, k := range keys {
+		switch {
+		case strings.HasPrefix(k, `"`):
+		case k[0] == '-' || (k[0] >= '0' && k[0] <= '9'):
+			// numbers are quoted the same way encoding/json does
+			k = `"` + k + `"`
+		default:
+			return "", fmt.Errorf("key %s cannot be represented in JSON", k) //nolint:goerr113
+		}
+
+		parts[i] = k + ":" + values[i]
+	}
+
+	return "{" + strings.Join(parts, ",") + "}", nil
+}
+
+func (b jsonBackend) renderChunkedMap(t reflect.Type, keys [][]string, values [][]string) (string, error) {
+	return b.renderMap(t, flatten(keys), flatten(values))
+}
+
+func (jsonBackend) renderNilPointer(reflect.Type) string {
+	return "null"
+}
+
+func (jsonBackend) renderPointer(_ reflect.Type, value string) string {
+	return value
+}
+
+func (jsonBackend) renderConversion(_ reflect.Type, value string) string {
+	return value
+}
+
+func (jsonBackend) renderAtomic(_ reflect.Type, value string, _ bool) string {
+	return value
+}
+
+func (jsonBackend) renderAnnotation(value string, _ string) string {
+	return value
+}
+
+func (b jsonBackend) exporters(cfg config, nested exporter) []exporter {
+	return []exporter{
+		&jsonTimeExporter{backend: b, location: cfg.timeLocation},
+		&jsonErrorExporter{backend: b, errors: errorExporter{exporter: nested, sentinels: cfg.sentinels}},
+	}
+}
@@ -0,0 +1,153 @@
+// Copyright (c) 2023–present Bartłomiej Krukowski
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is furnished
+// to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package exporter_test
+
+import (
+	"container/list"
+	"errors"
+	"fmt"
+	"io"
+	"math"
+	"testing"
+	"time"
+
+	"github.com/gontainer/exporter"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type jsonBackendUser struct {
+	Name     string `json:"name"`
+	Email    string `json:"email,omitempty"`
+	Password string `json:"-"`
+	Age      int    `json:",omitempty"`
+	Dash     bool   `json:"-,"`
+}
+
+//nolint:testifylint
+func TestJSONBackend(t *testing.T) {
+	t.Parallel()
+
+	//nolint:exhaustruct
+	scenarios := []struct {
+		name   string
+		input  any
+		output string
+		error  string
+	}{
+		{
+			name:   "nil",
+			input:  nil,
+			output: "null",
+		},
+		{
+			name:   "bool",
+			input:  true,
+			output: "true",
+		},
+		{
+			name:   "int",
+			input:  int8(-5),
+			output: "-5",
+		},
+		{
+			name:   "float",
+			input:  float32(3.14),
+			output: "3.14",
+		},
+		{
+			name:  "NaN",
+			input: math.NaN(),
+			error: "float64(NaN) cannot be represented in JSON",
+		},
+		{
+			name:   "string",
+			input:  "<hello> \"world\" 你好",
+			output: `"<hello> \"world\" 你好"`,
+		},
+		{
+			name:   "bytes",
+			input:  []byte("hello"),
+			output: `"hello"`,
+		},
+		{
+			name:   "slice",
+			input:  []any{1, "2", nil, []int(nil), []int{}, [2]bool{true}},
+			output: `[1,"2",null,null,[],[true,false]]`,
+		},
+		{
+			name: "json tags",
+			input: jsonBackendUser{
+				Name:     "Mary",
+				Email:    "mary@example.com",
+				Password: "secret",
+				Age:      30,
+				Dash:     true,
+			},
+			output: `{"name":"Mary","email":"mary@example.com","Age":30,"-":true}`,
+		},
+		{
+			name:   "time.Time",
+			input:  []any{time.Date(2023, time.March, 1, 12, 30, 0, 5, time.FixedZone("CET", 3600))},
+			output: `["2023-03-01T12:30:00.000000005+01:00"]`,
+		},
+		{
+			name:   "Errors",
+			input:  []error{io.EOF, errors.New("invalid"), fmt.Errorf("read: %w", io.EOF), nil},
+			output: `["EOF","invalid","read: EOF",null]`,
+		},
+		{
+			name:  "Unsupported value",
+			input: []any{list.New()},
+			error: "cannot export ([]interface{})[0]: cannot export (list.List).root: unexported field is not zero",
+		},
+	}
+
+	e := exporter.New(exporter.WithBackend(exporter.JSONBackend()))
+
+	for _, s := range scenarios {
+		s := s
+
+		t.Run(s.name, func(t *testing.T) {
+			t.Parallel()
+
+			output, err := e.Export(s.input)
+			if s.error != "" {
+				assert.EqualError(t, err, s.error)
+				assert.Empty(t, output)
+
+				return
+			}
+
+			require.NoError(t, err)
+			assert.Equal(t, s.output, output)
+		})
+	}
+}
+
+func TestGoBackend(t *testing.T) {
+	t.Parallel()
+
+	input := []any{1, "2", nil, []int(nil), []int{}, [2]bool{true}, list.New()}
+	output := exporter.New(exporter.WithBackend(exporter.GoBackend())).MustExport(input)
+
+	assert.Equal(t, exporter.MustExport(input), output)
+}
@@ -0,0 +1,131 @@
+// Copyright (c) 2023–present Bartłomiej Krukowski
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is furnished
+// to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package exporter
+
+// BuiltIn identifies a built-in exporter, see WithoutBuiltIns.
+type BuiltIn int
+
+const (
+	// BuiltInBool exports booleans.
+	BuiltInBool BuiltIn = iota
+	// BuiltInNil exports nil.
+	BuiltInNil
+	// BuiltInInt exports integers.
+	BuiltInInt
+	// BuiltInFloat exports floats.
+	BuiltInFloat
+	// BuiltInString exports strings.
+	BuiltInString
+	// BuiltInBytes exports byte slices as conversions of strings, see WithByteLists.
+	BuiltInBytes
+	// BuiltInSequence exports slices and arrays.
+	BuiltInSequence
+	// BuiltInMap exports maps.
+	BuiltInMap
+	// BuiltInStruct exports structs.
+	BuiltInStruct
+	// BuiltInAtomic exports types from the package sync/atomic.
+	BuiltInAtomic
+	// BuiltInTime exports time.Time.
+	BuiltInTime
+	// BuiltInError exports errors.
+	BuiltInError
+	// BuiltInContainer exports types from the package container and ordered maps.
+	BuiltInContainer
+	// BuiltInNet exports types from the packages net and net/netip.
+	BuiltInNet
+	// BuiltInFileMode exports os.FileMode.
+	BuiltInFileMode
+	// BuiltInStructTag exports reflect.StructTag.
+	BuiltInStructTag
+	// BuiltInHTMLTemplate exports string types from the package html/template.
+	BuiltInHTMLTemplate
+)
+
+// WithoutBuiltIns disables the given built-in exporters. Values supported by them are passed to the following
+// exporters, e.g. byte slices are exported as lists of bytes without BuiltInBytes,
+// otherwise the exporter fails, e.g. with the error "type float64 is not supported" without BuiltInFloat.
+// It lets strict environments make sure that particular types are never exported by accident.
+func WithoutBuiltIns(b ...BuiltIn) Option {
+	return func(c *config) {
+		for _, x := range b {
+			c.disabledBuiltIns |= 1 << uint(x)
+		}
+	}
+}
+
+func (c config) builtIn(b BuiltIn) bool {
+	return c.disabledBuiltIns&(1<<uint(b)) == 0
+}
+
+// builtInOf returns the identifier of the given built-in exporter.
+func builtInOf(e exporter) (BuiltIn, bool) {
+	switch e.(type) {
+	case *boolExporter:
+		return BuiltInBool, true
+	case *nilExporter:
+		return BuiltInNil, true
+	case *stringExporter:
+		return BuiltInString, true
+	case *bytesExporter:
+		return BuiltInBytes, true
+	case *multiArray:
+		return BuiltInSequence, true
+	case *mapExporter:
+		return BuiltInMap, true
+	case *structExporter:
+		return BuiltInStruct, true
+	case *atomicExporter:
+		return BuiltInAtomic, true
+	case *timeExporter:
+		return BuiltInTime, true
+	case *errorExporter:
+		return BuiltInError, true
+	case *orderedMap, *listExporter, *ringExporter:
+		return BuiltInContainer, true
+	case *netExporter:
+		return BuiltInNet, true
+	case *fileModeExporter:
+		return BuiltInFileMode, true
+	case *structTagExporter:
+		return BuiltInStructTag, true
+	case *htmlTemplateExporter:
+		return BuiltInHTMLTemplate, true
+	}
+
+	return 0, false
+}
+
+// withoutBuiltIns removes the disabled built-in exporters from the given ones, see WithoutBuiltIns.
+// Integers and floats are handled by numberExporter itself.
+func withoutBuiltIns(cfg config, exporters []exporter) []exporter {
+	result := make([]exporter, 0, len(exporters))
+
+	for _, e := range exporters {
+		if b, ok := builtInOf(e); ok && !cfg.builtIn(b) {
+			continue
+		}
+
+		result = append(result, e)
+	}
+
+	return result
+}
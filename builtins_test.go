@@ -0,0 +1,118 @@
+// Copyright (c) 2023–present Bartłomiej Krukowski
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is furnished
+// to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package exporter_test
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/gontainer/exporter"
+	"github.com/stretchr/testify/assert"
+)
+
+//nolint:testifylint
+func TestWithoutBuiltIns(t *testing.T) {
+	t.Parallel()
+
+	//nolint:exhaustruct
+	scenarios := []struct {
+		name     string
+		builtIns []exporter.BuiltIn
+		input    any
+		output   string
+		error    string
+	}{
+		{
+			name:     "Floats",
+			builtIns: []exporter.BuiltIn{exporter.BuiltInFloat},
+			input:    []any{1, 1.5},
+			error:    `cannot export ([]interface{})[1]: type float64 is not supported`,
+		},
+		{
+			name:     "Integers",
+			builtIns: []exporter.BuiltIn{exporter.BuiltInInt},
+			input:    []any{1.5, uint8(1)},
+			error:    `cannot export ([]interface{})[1]: type uint8 is not supported`,
+		},
+		{
+			name:     "Other numbers",
+			builtIns: []exporter.BuiltIn{exporter.BuiltInInt},
+			input:    []float32{1.5},
+			output:   `[]float32{float32(1.5)}`,
+		},
+		{
+			name:     "Bytes",
+			builtIns: []exporter.BuiltIn{exporter.BuiltInBytes},
+			input:    []byte("a"),
+			output:   `[]uint8{uint8(97)}`,
+		},
+		{
+			name:     "Time",
+			builtIns: []exporter.BuiltIn{exporter.BuiltInTime},
+			input:    time.Unix(0, 0).UTC(),
+			error:    `cannot export (time.Time).ext: unexported field is not zero`,
+		},
+		{
+			name:     "Zero time",
+			builtIns: []exporter.BuiltIn{exporter.BuiltInTime},
+			input:    time.Time{},
+			output:   `time.Time{}`,
+		},
+		{
+			name:     "Zero time without structs",
+			builtIns: []exporter.BuiltIn{exporter.BuiltInTime, exporter.BuiltInStruct},
+			input:    time.Time{},
+			error:    `type time.Time is not supported`,
+		},
+		{
+			name:     "Errors",
+			builtIns: []exporter.BuiltIn{exporter.BuiltInError},
+			input:    errors.New("error"),
+			error:    `cannot export (errors.errorString).s: unexported field is not zero`,
+		},
+		{
+			name:     "Maps",
+			builtIns: []exporter.BuiltIn{exporter.BuiltInMap},
+			input:    []any{map[string]int{}},
+			error:    `cannot export ([]interface{})[0]: type map[string]int is not supported`,
+		},
+	}
+
+	for _, s := range scenarios {
+		s := s
+
+		t.Run(s.name, func(t *testing.T) {
+			t.Parallel()
+
+			output, err := exporter.New(exporter.WithoutBuiltIns(s.builtIns...)).Export(s.input)
+			if s.error != "" {
+				assert.EqualError(t, err, s.error)
+				assert.Empty(t, output)
+
+				return
+			}
+
+			assert.NoError(t, err)
+			assert.Equal(t, s.output, output)
+		})
+	}
+}
@@ -0,0 +1,128 @@
+// Copyright (c) 2023–present Bartłomiej Krukowski
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is furnished
+// to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package exporter
+
+import (
+	"reflect"
+	"sync"
+)
+
+type cacheKey struct {
+	t   reflect.Type
+	ptr uintptr
+	len int
+}
+
+type cacheEntry struct {
+	// value keeps the exported value alive, so its address cannot be reused by another value
+	value  any
+	result string
+}
+
+// cache stores results of exporting values identified by their addresses.
+type cache struct {
+	mu      sync.Mutex
+	entries map[cacheKey]cacheEntry
+}
+
+func newCache() *cache {
+	return &cache{
+		mu:      sync.Mutex{},
+		entries: make(map[cacheKey]cacheEntry),
+	}
+}
+
+func (c *cache) get(k cacheKey) (string, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	e, ok := c.entries[k]
+
+	return e.result, ok
+}
+
+func (c *cache) set(k cacheKey, v any, result string) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.entries[k] = cacheEntry{value: v, result: result}
+}
+
+func (c *cache) reset() {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.entries = make(map[cacheKey]cacheEntry)
+}
+
+// cacheExporter reuses results of exporting slices, maps and pointers that have been already exported.
+type cacheExporter struct {
+	cache *cache
+	next  exporter
+}
+
+func newCacheExporter(c *cache, next exporter) *cacheExporter {
+	return &cacheExporter{cache: c, next: next}
+}
+
+func (c cacheExporter) export(v any) (string, error) {
+	k, ok := newCacheKey(v)
+	if !ok {
+		return c.next.export(v) //nolint:wrapcheck
+	}
+
+	if r, ok := c.cache.get(k); ok {
+		return r, nil
+	}
+
+	r, err := c.next.export(v)
+	if err != nil {
+		return "", err //nolint:wrapcheck
+	}
+
+	c.cache.set(k, v, r)
+
+	return r, nil
+}
+
+func (c cacheExporter) supports(v any) bool {
+	return c.next.supports(v)
+}
+
+func newCacheKey(v any) (cacheKey, bool) {
+	val := reflect.ValueOf(v)
+
+	switch val.Kind() { //nolint:exhaustive
+	case reflect.Slice, reflect.Map, reflect.Ptr:
+		if val.IsNil() {
+			return cacheKey{}, false //nolint:exhaustruct
+		}
+
+		k := cacheKey{t: val.Type(), ptr: val.Pointer(), len: 0}
+		if val.Kind() != reflect.Ptr {
+			k.len = val.Len()
+		}
+
+		return k, true
+	}
+
+	return cacheKey{}, false //nolint:exhaustruct
+}
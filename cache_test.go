@@ -0,0 +1,84 @@
+// Copyright (c) 2023–present Bartłomiej Krukowski
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is furnished
+// to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package exporter_test
+
+import (
+	"testing"
+
+	"github.com/gontainer/exporter"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestWithCache(t *testing.T) {
+	t.Parallel()
+
+	t.Run("Cached", func(t *testing.T) {
+		t.Parallel()
+
+		e := exporter.New(exporter.WithCache())
+		numbers := []int{1, 2, 3}
+
+		assert.Equal(t, "[][]int{[]int{int(1), int(2), int(3)}}", e.MustExport([][]int{numbers}))
+
+		// modifications are not visible, the cached result is used for the nested slice
+		numbers[0] = 100
+		assert.Equal(t, "[]interface{}{[]int{int(1), int(2), int(3)}}", e.MustExport([]any{numbers}))
+
+		e.ResetCache()
+		assert.Equal(t, "[]int{int(100), int(2), int(3)}", e.MustExport(numbers))
+	})
+
+	t.Run("Different lengths", func(t *testing.T) {
+		t.Parallel()
+
+		e := exporter.New(exporter.WithCache())
+		numbers := []int{1, 2, 3}
+
+		assert.Equal(t, "[]int{int(1), int(2), int(3)}", e.MustExport(numbers))
+		assert.Equal(t, "[]int{int(1), int(2)}", e.MustExport(numbers[:2]))
+	})
+
+	t.Run("Errors are not cached", func(t *testing.T) {
+		t.Parallel()
+
+		e := exporter.New(exporter.WithCache())
+		values := []any{make(chan int)}
+
+		_, err := e.Export(values)
+		assert.EqualError(t, err, "cannot export ([]interface{})[0]: type chan int is not supported")
+
+		values[0] = 5
+		assert.Equal(t, "[]interface{}{int(5)}", e.MustExport(values))
+	})
+
+	t.Run("Without cache", func(t *testing.T) {
+		t.Parallel()
+
+		e := exporter.New()
+		numbers := []int{1}
+
+		assert.Equal(t, "[]int{int(1)}", e.MustExport(numbers))
+
+		numbers[0] = 2
+		e.ResetCache()
+		assert.Equal(t, "[]int{int(2)}", e.MustExport(numbers))
+	})
+}
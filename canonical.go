@@ -0,0 +1,214 @@
+// Copyright (c) 2023–present Bartłomiej Krukowski
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is furnished
+// to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package exporter
+
+import (
+	"fmt"
+	"math"
+	"reflect"
+	"runtime"
+)
+
+// NaNPolicy defines how Canonicalize normalizes NaNs, see WithNaNPolicy.
+type NaNPolicy int
+
+const (
+	// NaNCanonical replaces all NaNs by math.NaN(), so they have the same bits. It is the default value.
+	NaNCanonical NaNPolicy = iota
+	// NaNZero replaces all NaNs by zeros.
+	NaNZero
+)
+
+// WithNaNPolicy sets how Canonicalize normalizes NaNs.
+func WithNaNPolicy(p NaNPolicy) Option {
+	return func(c *config) {
+		c.nanPolicy = p
+	}
+}
+
+// Canonicalize returns a canonical copy of the given value, see Exporter.Canonicalize.
+func Canonicalize(v any) any {
+	return defaultExporter.Canonicalize(v)
+}
+
+// Canonicalize returns a deep copy of the given value, in which equal values have equal representations.
+// Negative zeros are replaced by zeros, and NaNs are normalized according to WithNaNPolicy.
+// Slices, arrays, maps, structs and interfaces are copied, other values, e.g. pointers, are shared with the original.
+// Keys of maps are not changed, since NaN keys are distinct and normalized keys might collide.
+//
+// Maps are unordered in GO, Export sorts their keys, so no further normalization is needed.
+// Export(Canonicalize(v)) is byte-stable for values supported by the built-in exporters, see VerifyStable.
+func (e *Exporter) Canonicalize(v any) any {
+	if v == nil {
+		return nil
+	}
+
+	c := canonicalizer{policy: e.config.nanPolicy, copies: make(map[cacheKey]reflect.Value)}
+
+	return c.canonicalize(reflect.ValueOf(v)).Interface()
+}
+
+// VerifyStable verifies that the given value is exported to the same code each time, see Exporter.VerifyStable.
+func VerifyStable(v any, runs int) error {
+	return defaultExporter.VerifyStable(v, runs)
+}
+
+// VerifyStable exports the canonical copy of the given value the given number of times,
+// and returns an error if the results differ. Each run canonicalizes the value again and runs the garbage collector,
+// so results that depend on addresses or the order of iteration over maps are likely to differ.
+// Values lower than 2 mean 2.
+//
+// It is meant for contract tests of generators that rely on reproducible builds, e.g.:
+//
+//	if err := exporter.VerifyStable(fixtures, 10); err != nil {
+//		t.Fatal(err)
+//	}
+func (e *Exporter) VerifyStable(v any, runs int) error {
+	first, err := e.Export(e.Canonicalize(v))
+	if err != nil {
+		return err
+	}
+
+	if runs < 2 { //nolint:gomnd
+		runs = 2
+	}
+
+	for i := 2; i <= runs; i++ {
+		runtime.GC()
+
+		code, err := e.Export(e.Canonicalize(v))
+		if err != nil {
+			return fmt.Errorf("nondeterministic output: export %d failed: %w", i, err)
+		}
+
+		if code != first {
+			return fmt.Errorf( //nolint:goerr113
+				"nondeterministic output: export %d differs from export 1 at the byte %d",
+				i,
+				diffOffset(first, code),
+			)
+		}
+	}
+
+	return nil
+}
+
+type canonicalizer struct {
+	policy NaNPolicy
+	// copies contains copies of slices and maps, so cycles and shared values are preserved
+	copies map[cacheKey]reflect.Value
+}
+
+func (c canonicalizer) canonicalize(v reflect.Value) reflect.Value {
+	t := v.Type()
+
+	//nolint:exhaustive
+	switch v.Kind() {
+	case reflect.Float32, reflect.Float64:
+		r := reflect.New(t).Elem()
+		r.SetFloat(c.float(v.Float()))
+
+		return r
+	case reflect.Complex64, reflect.Complex128:
+		r := reflect.New(t).Elem()
+		r.SetComplex(complex(c.float(real(v.Complex())), c.float(imag(v.Complex()))))
+
+		return r
+	case reflect.Interface:
+		r := reflect.New(t).Elem()
+		if !v.IsNil() {
+			r.Set(c.canonicalize(v.Elem()))
+		}
+
+		return r
+	case reflect.Array:
+		r := reflect.New(t).Elem()
+		for i := 0; i < v.Len(); i++ {
+			r.Index(i).Set(c.canonicalize(v.Index(i)))
+		}
+
+		return r
+	case reflect.Slice:
+		if v.IsNil() {
+			return v
+		}
+
+		k := cacheKey{t: t, ptr: v.Pointer(), len: v.Len()}
+		if r, ok := c.copies[k]; ok {
+			return r
+		}
+
+		r := reflect.MakeSlice(t, v.Len(), v.Len())
+		c.copies[k] = r
+
+		for i := 0; i < v.Len(); i++ {
+			r.Index(i).Set(c.canonicalize(v.Index(i)))
+		}
+
+		return r
+	case reflect.Map:
+		if v.IsNil() {
+			return v
+		}
+
+		k := cacheKey{t: t, ptr: v.Pointer(), len: 0}
+		if r, ok := c.copies[k]; ok {
+			return r
+		}
+
+		r := reflect.MakeMapWithSize(t, v.Len())
+		c.copies[k] = r
+
+		iter := v.MapRange()
+		for iter.Next() {
+			r.SetMapIndex(iter.Key(), c.canonicalize(iter.Value()))
+		}
+
+		return r
+	case reflect.Struct:
+		r := reflect.New(t).Elem()
+		r.Set(v)
+
+		for i := 0; i < v.NumField(); i++ {
+			if f := r.Field(i); f.CanSet() {
+				f.Set(c.canonicalize(v.Field(i)))
+			}
+		}
+
+		return r
+	}
+
+	return v
+}
+
+func (c canonicalizer) float(f float64) float64 {
+	switch {
+	case math.IsNaN(f) && c.policy == NaNZero:
+		return 0
+	case math.IsNaN(f):
+		return math.NaN()
+	case f == 0:
+		// replaces -0
+		return 0
+	}
+
+	return f
+}
@@ -0,0 +1,111 @@
+// Copyright (c) 2023–present Bartłomiej Krukowski
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is furnished
+// to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package exporter_test
+
+import (
+	"math"
+	"testing"
+
+	"github.com/gontainer/exporter"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type canonicalPoint struct {
+	X, Y  float64
+	Tags  []string
+	scale float64
+}
+
+func TestCanonicalize(t *testing.T) {
+	t.Parallel()
+
+	t.Run("Floats", func(t *testing.T) {
+		t.Parallel()
+
+		nan := math.Float64frombits(math.Float64bits(math.NaN()) + 1)
+		input := []any{math.Copysign(0, -1), nan, float32(1.5), complex(math.Copysign(0, -1), 1)}
+
+		output := exporter.Canonicalize(input).([]any) //nolint:forcetypeassert
+		assert.Equal(t, math.Float64bits(0), math.Float64bits(output[0].(float64)))
+		assert.Equal(t, math.Float64bits(math.NaN()), math.Float64bits(output[1].(float64)))
+		assert.Equal(t, float32(1.5), output[2])
+		assert.Equal(t, complex(0, 1), output[3])
+		assert.Equal(t, math.Float64bits(math.Copysign(0, -1)), math.Float64bits(input[0].(float64)))
+
+		output = exporter.New(exporter.WithNaNPolicy(exporter.NaNZero)).Canonicalize(input).([]any) //nolint:forcetypeassert
+		assert.Equal(t, float64(0), output[1])
+	})
+
+	t.Run("Containers", func(t *testing.T) {
+		t.Parallel()
+
+		tags := []string{"a"}
+		input := map[string]canonicalPoint{"p": {X: math.Copysign(0, -1), Y: 2, Tags: tags, scale: math.Copysign(0, -1)}}
+
+		output := exporter.Canonicalize(input).(map[string]canonicalPoint) //nolint:forcetypeassert
+		assert.Equal(t, canonicalPoint{X: 0, Y: 2, Tags: []string{"a"}, scale: math.Copysign(0, -1)}, output["p"])
+		assert.False(t, math.Signbit(output["p"].X))
+		// unexported fields are copied as they are
+		assert.True(t, math.Signbit(output["p"].scale))
+
+		// the result is a deep copy
+		output["p"].Tags[0] = "b"
+		assert.Equal(t, "a", tags[0])
+	})
+
+	t.Run("Cycles", func(t *testing.T) {
+		t.Parallel()
+
+		input := []any{nil, 1.5}
+		input[0] = input
+
+		output := exporter.Canonicalize(input).([]any) //nolint:forcetypeassert
+		assert.Equal(t, 1.5, output[0].([]any)[1])
+		assert.Equal(t, 1.5, output[0].([]any)[0].([]any)[1])
+	})
+
+	t.Run("nil", func(t *testing.T) {
+		t.Parallel()
+
+		assert.Nil(t, exporter.Canonicalize(nil))
+		assert.Equal(t, []int(nil), exporter.Canonicalize([]int(nil)))
+	})
+}
+
+func TestVerifyStable(t *testing.T) {
+	t.Parallel()
+
+	input := map[float64][]any{1.5: {math.Copysign(0, -1), "a"}, -1: {map[string]int{"b": 2, "a": 1}}}
+	require.NoError(t, exporter.VerifyStable(input, 10))
+	assert.Equal(
+		t,
+		`map[float64][]interface{}{float64(-1): []interface{}{map[string]int{"a": int(1), "b": int(2)}}, `+
+			`float64(1.5): []interface{}{float64(0), "a"}}`,
+		exporter.MustExport(exporter.Canonicalize(input)),
+	)
+
+	assert.EqualError(
+		t,
+		exporter.VerifyStable([]any{make(chan int)}, 2),
+		"cannot export ([]interface{})[0]: type chan int is not supported",
+	)
+}
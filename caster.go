@@ -0,0 +1,314 @@
+// Copyright (c) 2023–present Bartłomiej Krukowski
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is furnished
+// to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package exporter
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"math/big"
+	"reflect"
+)
+
+//nolint:gochecknoglobals
+var (
+	// ErrOutOfRange is returned when a number exceeds the range of the target type, see RangeError.
+	ErrOutOfRange = errors.New("value out of range")
+	// ErrPrecisionLoss is returned when a number cannot be represented exactly by the target type, see RangeError.
+	ErrPrecisionLoss = errors.New("precision loss")
+)
+
+// WithLenientCasting makes Exporter.CastToString accept values of any type.
+// Values of types that are not supported otherwise are formatted using fmt.Sprintf("%v", v).
+//
+// The conversion is lossy, the result cannot be converted back to the original value.
+// Use it only when any string is better than an error, e.g. for logging.
+func WithLenientCasting() Option {
+	return func(c *config) {
+		c.lenientCasting = true
+	}
+}
+
+// WithUnderlyingCasting makes Exporter.CastToString cast values of defined types of booleans, strings and numbers
+// like values of their underlying types, e.g.:
+//
+//	type Port int
+//
+//	exporter.New(exporter.WithUnderlyingCasting()).CastToString(Port(80)) // "80"
+//
+// Without this option, values of defined types are not supported, unless WithLenientCasting is used.
+func WithUnderlyingCasting() Option {
+	return func(c *config) {
+		c.castUnderlying = true
+	}
+}
+
+// CastToString casts input value to a string, see the function CastToString and WithLenientCasting.
+func (e *Exporter) CastToString(i any) (string, error) {
+	if r, ok := i.(string); ok {
+		return r, nil
+	}
+
+	return e.caster.export(i) //nolint:wrapcheck
+}
+
+// MustCastToString casts input value to a string.
+//
+// See Exporter.CastToString.
+func (e *Exporter) MustCastToString(i any) string {
+	r, err := e.CastToString(i)
+	if err != nil {
+		e.panicWith("cast", i, err)
+	}
+
+	return r
+}
+
+func newStringCaster(cfg config) exporter { //nolint:ireturn
+	chain := newChainExporter(
+		&boolExporter{},
+		&nilExporter{backend: goBackend{}},
+		&numberExporter{
+			explicitType:   false,
+			backend:        goBackend{},
+			floatPrecision: -1,
+			hexLargeUints:  false,
+			minimal:        false,
+			noInts:         false,
+			noFloats:       false,
+			names:          nil,
+			ratios:         0,
+		},
+	)
+
+	if cfg.castUnderlying {
+		chain.exporters = append(chain.exporters, underlyingCaster{next: chain})
+	}
+
+	if cfg.lenientCasting {
+		chain.exporters = append(chain.exporters, sprintfExporter{})
+	}
+
+	return chain
+}
+
+// underlyingCaster casts values of defined types like values of their underlying types, see WithUnderlyingCasting.
+type underlyingCaster struct {
+	next exporter
+}
+
+func (u underlyingCaster) export(v any) (string, error) {
+	val := reflect.ValueOf(v)
+	basic := val.Convert(basicType(val.Kind())).Interface()
+
+	if s, ok := basic.(string); ok {
+		return s, nil
+	}
+
+	return u.next.export(basic) //nolint:wrapcheck
+}
+
+func (underlyingCaster) supports(v any) bool {
+	return definedExporter{}.supports(v) //nolint:exhaustruct
+}
+
+// sprintfExporter is the lossy fallback of the string caster, see WithLenientCasting.
+type sprintfExporter struct{}
+
+func (sprintfExporter) export(v any) (string, error) {
+	return fmt.Sprintf("%v", v), nil
+}
+
+func (sprintfExporter) supports(any) bool {
+	return true
+}
+
+// RangeError is returned by numeric casters when the given value cannot be converted
+// to the target type without changing it. It wraps either ErrOutOfRange or ErrPrecisionLoss.
+type RangeError struct {
+	Value any
+	Type  reflect.Type
+	Err   error
+}
+
+func (e *RangeError) Error() string {
+	return fmt.Sprintf("cannot cast %T(%v) to %s: %s", e.Value, e.Value, typeName(e.Type), e.Err.Error())
+}
+
+func (e *RangeError) Unwrap() error {
+	return e.Err
+}
+
+// CastToInt casts the given number to int, see CastNumber.
+func CastToInt(v any) (int, error) {
+	r, err := CastNumber(v, reflect.TypeOf(int(0)))
+	if err != nil {
+		return 0, err
+	}
+
+	return r.(int), nil //nolint:forcetypeassert
+}
+
+// CastToInt64 casts the given number to int64, see CastNumber.
+func CastToInt64(v any) (int64, error) {
+	r, err := CastNumber(v, reflect.TypeOf(int64(0)))
+	if err != nil {
+		return 0, err
+	}
+
+	return r.(int64), nil //nolint:forcetypeassert
+}
+
+// CastToFloat32 casts the given number to float32, see CastNumber.
+func CastToFloat32(v any) (float32, error) {
+	r, err := CastNumber(v, reflect.TypeOf(float32(0)))
+	if err != nil {
+		return 0, err
+	}
+
+	return r.(float32), nil //nolint:forcetypeassert
+}
+
+// CastToFloat64 casts the given number to float64, see CastNumber.
+func CastToFloat64(v any) (float64, error) {
+	r, err := CastNumber(v, reflect.TypeOf(float64(0)))
+	if err != nil {
+		return 0, err
+	}
+
+	return r.(float64), nil //nolint:forcetypeassert
+}
+
+// CastNumber converts the given integer or float to the given numeric type.
+// Unlike GO conversions, it never truncates values silently. It returns *RangeError when the value
+// exceeds the range of the target type, e.g. int64(300) to int8, or when it cannot be represented exactly,
+// e.g. float64(0.1) to float32 or float64(1.5) to int. NaN and infinities can be converted to floats only.
+func CastNumber(v any, t reflect.Type) (any, error) {
+	val := reflect.ValueOf(v)
+	if !isNumber(val) {
+		return nil, fmt.Errorf("type %T is not a number", v) //nolint:goerr113
+	}
+
+	if !isNumber(reflect.Zero(t)) {
+		return nil, fmt.Errorf("type %s is not a number", typeName(t)) //nolint:goerr113
+	}
+
+	result := reflect.New(t).Elem()
+	isFloat := t.Kind() == reflect.Float32 || t.Kind() == reflect.Float64
+
+	if f, ok := nonFinite(val); ok {
+		if !isFloat {
+			return nil, &RangeError{Value: v, Type: t, Err: ErrOutOfRange}
+		}
+
+		result.SetFloat(f)
+
+		return result.Interface(), nil
+	}
+
+	x := exactNumber(val)
+
+	if isFloat {
+		var (
+			f   float64
+			acc big.Accuracy
+		)
+
+		if t.Kind() == reflect.Float32 {
+			var f32 float32
+			f32, acc = x.Float32()
+			f = float64(f32)
+		} else {
+			f, acc = x.Float64()
+		}
+
+		switch {
+		case math.IsInf(f, 0):
+			return nil, &RangeError{Value: v, Type: t, Err: ErrOutOfRange}
+		case acc != big.Exact:
+			return nil, &RangeError{Value: v, Type: t, Err: ErrPrecisionLoss}
+		}
+
+		result.SetFloat(f)
+
+		return result.Interface(), nil
+	}
+
+	if !x.IsInt() {
+		return nil, &RangeError{Value: v, Type: t, Err: ErrPrecisionLoss}
+	}
+
+	i, _ := x.Int(nil)
+
+	//nolint:exhaustive
+	switch t.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		if !i.IsInt64() || result.OverflowInt(i.Int64()) {
+			return nil, &RangeError{Value: v, Type: t, Err: ErrOutOfRange}
+		}
+
+		result.SetInt(i.Int64())
+	default:
+		if !i.IsUint64() || result.OverflowUint(i.Uint64()) {
+			return nil, &RangeError{Value: v, Type: t, Err: ErrOutOfRange}
+		}
+
+		result.SetUint(i.Uint64())
+	}
+
+	return result.Interface(), nil
+}
+
+func isNumber(v reflect.Value) bool {
+	//nolint:exhaustive
+	switch v.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr,
+		reflect.Float32, reflect.Float64:
+		return true
+	}
+
+	return false
+}
+
+// nonFinite returns the given value if it is NaN or an infinity.
+func nonFinite(v reflect.Value) (float64, bool) {
+	if v.Kind() != reflect.Float32 && v.Kind() != reflect.Float64 {
+		return 0, false
+	}
+
+	f := v.Float()
+
+	return f, math.IsNaN(f) || math.IsInf(f, 0)
+}
+
+// exactNumber returns the exact value of the given finite number.
+func exactNumber(v reflect.Value) *big.Float {
+	//nolint:exhaustive
+	switch v.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return new(big.Float).SetInt64(v.Int())
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		return new(big.Float).SetUint64(v.Uint())
+	}
+
+	return new(big.Float).SetFloat64(v.Float())
+}
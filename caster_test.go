@@ -0,0 +1,270 @@
+// Copyright (c) 2023–present Bartłomiej Krukowski
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is furnished
+// to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package exporter_test
+
+import (
+	"errors"
+	"math"
+	"reflect"
+	"testing"
+
+	"github.com/gontainer/exporter"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestExporter_CastToString(t *testing.T) {
+	t.Parallel()
+
+	t.Run("Strict", func(t *testing.T) {
+		t.Parallel()
+
+		e := exporter.New()
+
+		_, err := e.CastToString([]int{1, 2})
+		require.EqualError(t, err, "type []int is not supported")
+		assert.Equal(t, "5", e.MustCastToString(uint(5)))
+		assert.PanicsWithValue(t, "cannot cast []int to string: type []int is not supported", func() {
+			e.MustCastToString([]int{1, 2})
+		})
+	})
+
+	t.Run("Lenient", func(t *testing.T) {
+		t.Parallel()
+
+		e := exporter.New(exporter.WithLenientCasting())
+
+		scenarios := []struct {
+			input  any
+			output string
+		}{
+			{input: "hello", output: "hello"},
+			{input: nil, output: "nil"},
+			{input: 1.5, output: "1.5"},
+			{input: []int{1, 2}, output: "[1 2]"},
+			{input: struct{ A string }{A: "a"}, output: "{a}"},
+			{input: map[string]int{"b": 2, "a": 1}, output: "map[a:1 b:2]"},
+		}
+
+		for _, s := range scenarios {
+			output, err := e.CastToString(s.input)
+			require.NoError(t, err)
+			assert.Equal(t, s.output, output)
+		}
+	})
+
+	t.Run("Underlying types", func(t *testing.T) {
+		t.Parallel()
+
+		type (
+			port   int
+			ratio  float32
+			status string
+			flag   bool
+		)
+
+		_, err := exporter.CastToString(port(80))
+		require.EqualError(t, err, "type exporter_test.port is not supported")
+
+		e := exporter.New(exporter.WithUnderlyingCasting())
+
+		scenarios := []struct {
+			input  any
+			output string
+		}{
+			{input: port(80), output: "80"},
+			{input: ratio(0.5), output: "0.5"},
+			{input: status("active"), output: "active"},
+			{input: flag(true), output: "true"},
+		}
+
+		for _, s := range scenarios {
+			output, err := e.CastToString(s.input)
+			require.NoError(t, err)
+			assert.Equal(t, s.output, output)
+		}
+
+		_, err = e.CastToString([]port{80})
+		require.EqualError(t, err, "type []exporter_test.port is not supported")
+	})
+}
+
+func TestCastNumber(t *testing.T) {
+	t.Parallel()
+
+	type celsius float64
+
+	//nolint:exhaustruct
+	scenarios := []struct {
+		name   string
+		input  any
+		target reflect.Type
+		output any
+		error  string
+	}{
+		{
+			name:   "Integer to float",
+			input:  int64(1) << 53,
+			target: reflect.TypeOf(float64(0)),
+			output: float64(1 << 53),
+		},
+		{
+			name:   "Integer to float with precision loss",
+			input:  int64(1)<<53 + 1,
+			target: reflect.TypeOf(float64(0)),
+			error:  "cannot cast int64(9007199254740993) to float64: precision loss",
+		},
+		{
+			name:   "Float64 to float32",
+			input:  0.5,
+			target: reflect.TypeOf(float32(0)),
+			output: float32(0.5),
+		},
+		{
+			name:   "Float64 to float32 with precision loss",
+			input:  0.1,
+			target: reflect.TypeOf(float32(0)),
+			error:  "cannot cast float64(0.1) to float32: precision loss",
+		},
+		{
+			name:   "Float64 to float32 out of range",
+			input:  1e300,
+			target: reflect.TypeOf(float32(0)),
+			error:  "cannot cast float64(1e+300) to float32: value out of range",
+		},
+		{
+			name:   "Integral float to int",
+			input:  float32(-3),
+			target: reflect.TypeOf(int(0)),
+			output: -3,
+		},
+		{
+			name:   "Fraction to int",
+			input:  1.5,
+			target: reflect.TypeOf(int(0)),
+			error:  "cannot cast float64(1.5) to int: precision loss",
+		},
+		{
+			name:   "Int64 to int8",
+			input:  int64(300),
+			target: reflect.TypeOf(int8(0)),
+			error:  "cannot cast int64(300) to int8: value out of range",
+		},
+		{
+			name:   "Negative to uint",
+			input:  -1,
+			target: reflect.TypeOf(uint(0)),
+			error:  "cannot cast int(-1) to uint: value out of range",
+		},
+		{
+			name:   "Large uint64 to int64",
+			input:  uint64(math.MaxUint64),
+			target: reflect.TypeOf(int64(0)),
+			error:  "cannot cast uint64(18446744073709551615) to int64: value out of range",
+		},
+		{
+			name:   "Large float to uint64",
+			input:  float64(math.MaxUint64),
+			target: reflect.TypeOf(uint64(0)),
+			error:  "cannot cast float64(1.8446744073709552e+19) to uint64: value out of range",
+		},
+		{
+			name:   "Infinity",
+			input:  math.Inf(1),
+			target: reflect.TypeOf(float32(0)),
+			output: float32(math.Inf(1)),
+		},
+		{
+			name:   "Infinity to int",
+			input:  math.Inf(-1),
+			target: reflect.TypeOf(int(0)),
+			error:  "cannot cast float64(-Inf) to int: value out of range",
+		},
+		{
+			name:   "Named types",
+			input:  celsius(21.5),
+			target: reflect.TypeOf(float32(0)),
+			output: float32(21.5),
+		},
+		{
+			name:   "Not a number",
+			input:  "5",
+			target: reflect.TypeOf(int(0)),
+			error:  "type string is not a number",
+		},
+		{
+			name:   "Invalid target",
+			input:  5,
+			target: reflect.TypeOf(""),
+			error:  "type string is not a number",
+		},
+	}
+
+	for _, s := range scenarios {
+		s := s
+
+		t.Run(s.name, func(t *testing.T) {
+			t.Parallel()
+
+			output, err := exporter.CastNumber(s.input, s.target)
+			if s.error != "" {
+				assert.EqualError(t, err, s.error)
+				assert.Nil(t, output)
+
+				return
+			}
+
+			require.NoError(t, err)
+			assert.Equal(t, s.output, output)
+		})
+	}
+}
+
+func TestCastToInt(t *testing.T) {
+	t.Parallel()
+
+	i, err := exporter.CastToInt(float64(7))
+	require.NoError(t, err)
+	assert.Equal(t, 7, i)
+
+	i64, err := exporter.CastToInt64(uint8(7))
+	require.NoError(t, err)
+	assert.Equal(t, int64(7), i64)
+
+	f32, err := exporter.CastToFloat32(0.25)
+	require.NoError(t, err)
+	assert.Equal(t, float32(0.25), f32)
+
+	f64, err := exporter.CastToFloat64(float32(0.1))
+	require.NoError(t, err)
+	assert.Equal(t, float64(float32(0.1)), f64)
+
+	_, err = exporter.CastToFloat32(0.1)
+
+	var rangeErr *exporter.RangeError
+
+	require.True(t, errors.As(err, &rangeErr))
+	assert.Equal(t, 0.1, rangeErr.Value)
+	assert.True(t, errors.Is(err, exporter.ErrPrecisionLoss))
+
+	_, err = exporter.CastToInt(uint64(math.MaxUint64))
+	assert.True(t, errors.Is(err, exporter.ErrOutOfRange))
+}
@@ -0,0 +1,78 @@
+// Copyright (c) 2023–present Bartłomiej Krukowski
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is furnished
+// to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package exporter
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"io/ioutil"
+)
+
+// ChecksumPrefix starts the second line of files generated by ExportFile, it is followed by the checksum
+// of the rest of the file, see VerifyGenerated.
+const ChecksumPrefix = "// Checksum: sha256:"
+
+// VerifyGenerated verifies whether the given file generated by ExportFile has not been modified since then.
+// Call it before regenerating the file to avoid silently overwriting manual changes.
+func VerifyGenerated(path string) error {
+	src, err := ioutil.ReadFile(path) //nolint:staticcheck
+	if err != nil {
+		return err //nolint:wrapcheck
+	}
+
+	// the first line is the header, the second one contains the checksum
+	header := bytes.IndexByte(src, '\n') + 1
+	end := bytes.IndexByte(src[header:], '\n')
+
+	if header == 0 || end < 0 || !bytes.HasPrefix(src[header:], []byte(ChecksumPrefix)) {
+		return fmt.Errorf("file %s does not contain a checksum", path) //nolint:goerr113
+	}
+
+	line := bytes.TrimRight(src[header:header+end], "\r")
+	expected := string(line[len(ChecksumPrefix):])
+
+	if checksum(src[header+end+1:]) != expected {
+		return fmt.Errorf("file %s has been modified after it was generated", path) //nolint:goerr113
+	}
+
+	return nil
+}
+
+// addChecksum inserts the checksum of the given generated file after its first line.
+func addChecksum(src []byte, eol string) []byte {
+	header := bytes.IndexByte(src, '\n') + 1
+	body := src[header:]
+
+	result := make([]byte, 0, len(src)+len(ChecksumPrefix)+sha256.Size*2+len(eol))
+	result = append(result, src[:header]...)
+	result = append(result, ChecksumPrefix+checksum(body)+eol...)
+	result = append(result, body...)
+
+	return result
+}
+
+func checksum(b []byte) string {
+	sum := sha256.Sum256(b)
+
+	return hex.EncodeToString(sum[:])
+}
@@ -0,0 +1,72 @@
+// Copyright (c) 2023–present Bartłomiej Krukowski
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is furnished
+// to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package exporter_test
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"regexp"
+	"testing"
+
+	"github.com/gontainer/exporter"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+//nolint:gochecknoglobals
+var checksumLine = regexp.MustCompile(`\A(.*\n)// Checksum: sha256:[0-9a-f]{64}\r?\n`)
+
+// withoutChecksum removes the line with the checksum from the given generated file.
+func withoutChecksum(t *testing.T, code []byte) string {
+	t.Helper()
+
+	require.Regexp(t, checksumLine, string(code))
+
+	return checksumLine.ReplaceAllString(string(code), "$1")
+}
+
+func TestVerifyGenerated(t *testing.T) {
+	t.Parallel()
+
+	dir, err := ioutil.TempDir("", "exporter") //nolint:staticcheck
+	require.NoError(t, err)
+	defer os.RemoveAll(dir)
+
+	for _, opts := range [][]exporter.Option{nil, {exporter.WithLineEnding(exporter.LineEndingCRLF)}} {
+		code, err := exporter.New(opts...).ExportFile("fixtures", "Numbers", []int{1, 2})
+		require.NoError(t, err)
+
+		path := filepath.Join(dir, "numbers.go")
+		require.NoError(t, ioutil.WriteFile(path, code, 0o600)) //nolint:staticcheck
+		require.NoError(t, exporter.VerifyGenerated(path))
+
+		code = append(code, []byte("\nvar Manual = 5\n")...)
+		require.NoError(t, ioutil.WriteFile(path, code, 0o600)) //nolint:staticcheck
+		assert.EqualError(t, exporter.VerifyGenerated(path), "file "+path+" has been modified after it was generated")
+	}
+
+	path := filepath.Join(dir, "manual.go")
+	require.NoError(t, ioutil.WriteFile(path, []byte("package fixtures\n"), 0o600)) //nolint:staticcheck
+	assert.EqualError(t, exporter.VerifyGenerated(path), "file "+path+" does not contain a checksum")
+
+	assert.Error(t, exporter.VerifyGenerated(filepath.Join(dir, "missing.go")))
+}
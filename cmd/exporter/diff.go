@@ -0,0 +1,390 @@
+// Copyright (c) 2023–present Bartłomiej Krukowski
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is furnished
+// to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package main
+
+import (
+	"encoding/json"
+	"errors"
+	"flag"
+	"fmt"
+	"go/ast"
+	"go/parser"
+	"go/token"
+	"io"
+	"io/ioutil" //nolint:staticcheck
+	"strings"
+
+	"github.com/gontainer/exporter"
+)
+
+// diffContext is the number of unchanged lines around changes in the printed diff.
+const diffContext = 3
+
+func diffCommand(args []string, stdout io.Writer, stderr io.Writer) int {
+	flags := flag.NewFlagSet("diff", flag.ContinueOnError)
+	flags.SetOutput(stderr)
+	options := newOptionFlags(flags)
+	pkg := flags.String("package", "", "the name of the package, defaults to the package of the generated file")
+	varName := flags.String("var-name", "", "the name of the variable, defaults to the one declared by the generated file")
+
+	if err := flags.Parse(args); err != nil {
+		return exitError
+	}
+
+	if flags.NArg() != 2 { //nolint:gomnd
+		_, _ = io.WriteString(stderr, usage)
+
+		return exitError
+	}
+
+	diff, err := diffFile(flags.Arg(0), flags.Arg(1), *pkg, *varName, options())
+	if err != nil {
+		_, _ = fmt.Fprintf(stderr, "exporter diff: %s\n", err.Error())
+
+		return exitError
+	}
+
+	if diff == "" {
+		return exitOK
+	}
+
+	_, _ = io.WriteString(stdout, diff)
+
+	return exitDiff
+}
+
+// diffFile regenerates the given GO file from the given JSON file using the given options,
+// and returns the unified diff between the current and the regenerated content.
+// Empty names of the package and the variable are read from the GO file.
+func diffFile(goFile string, jsonFile string, pkg string, varName string, opts []exporter.Option) (string, error) {
+	current, err := ioutil.ReadFile(goFile) //nolint:staticcheck
+	if err != nil {
+		return "", fmt.Errorf("cannot read %s: %w", goFile, err)
+	}
+
+	generated, err := parseGenerated(current)
+	if err != nil {
+		return "", fmt.Errorf("%s: %w", goFile, err)
+	}
+
+	if pkg == "" {
+		pkg = generated.pkg
+	}
+
+	if varName == "" {
+		varName = generated.name
+	}
+
+	data, err := ioutil.ReadFile(jsonFile) //nolint:staticcheck
+	if err != nil {
+		return "", fmt.Errorf("cannot read %s: %w", jsonFile, err)
+	}
+
+	var v interface{}
+	if err := json.Unmarshal(data, &v); err != nil {
+		return "", fmt.Errorf("cannot decode %s: %w", jsonFile, err)
+	}
+
+	// files generated by older versions of the module keep their format
+	e := exporter.New(append(
+		opts,
+		exporter.WithFormatVersion(exporter.GeneratedFormatVersion(current)),
+		exporter.WithDeclaration(generated.declaration),
+		exporter.WithCopyAccessors(generated.copySuffix),
+	)...)
+
+	regenerated, err := e.ExportFile(pkg, varName, v)
+	if err != nil {
+		return "", fmt.Errorf("cannot regenerate %s: %w", goFile, err)
+	}
+
+	return unifiedDiff(goFile, string(current), string(regenerated)), nil
+}
+
+// generatedFile describes the declaration of a file generated by ExportFile.
+type generatedFile struct {
+	pkg         string
+	name        string
+	declaration exporter.Declaration
+	copySuffix  string
+}
+
+// parseGenerated returns the name of the package and the declaration of the exported value in the given file,
+// i.e. the first variable, or the last function without parameters, see exporter.WithDeclaration.
+func parseGenerated(src []byte) (generatedFile, error) {
+	file, err := parser.ParseFile(token.NewFileSet(), "", src, 0)
+	if err != nil {
+		return generatedFile{}, fmt.Errorf("cannot parse: %w", err) //nolint:exhaustruct
+	}
+
+	result := generatedFile{pkg: file.Name.Name, name: "", declaration: exporter.DeclarationVar, copySuffix: ""}
+
+	for _, d := range file.Decls {
+		if g, ok := d.(*ast.GenDecl); ok && g.Tok == token.VAR {
+			if len(g.Specs) == 0 {
+				return generatedFile{}, errors.New("empty variable declaration") //nolint:goerr113,exhaustruct
+			}
+
+			spec, ok := g.Specs[0].(*ast.ValueSpec)
+			if !ok || len(spec.Names) == 0 {
+				return generatedFile{}, errors.New("invalid variable declaration") //nolint:goerr113,exhaustruct
+			}
+
+			result.name = spec.Names[0].Name
+
+			// var Users = UsersCopy(), see exporter.WithCopyAccessors
+			if len(spec.Values) > 0 {
+				if call, ok := spec.Values[0].(*ast.CallExpr); ok && len(call.Args) == 0 {
+					if fn, ok := call.Fun.(*ast.Ident); ok && strings.HasPrefix(fn.Name, result.name) {
+						result.copySuffix = fn.Name[len(result.name):]
+					}
+				}
+			}
+
+			return result, nil
+		}
+	}
+
+	// functions of split maps precede the declared function, typed accessors have parameters
+	for _, d := range file.Decls {
+		if f, ok := d.(*ast.FuncDecl); ok && f.Recv == nil && f.Type.Params.NumFields() == 0 && f.Type.Results != nil {
+			result.name = f.Name.Name
+			result.declaration = exporter.DeclarationFunc
+		}
+	}
+
+	if result.name == "" {
+		return generatedFile{}, errors.New("no variable or function declared") //nolint:goerr113,exhaustruct
+	}
+
+	return result, nil
+}
+
+type diffOp struct {
+	kind byte // ' ', '-' or '+'
+	line string
+}
+
+// unifiedDiff returns the unified diff between the given texts, or an empty string if they are equal.
+func unifiedDiff(name string, a string, b string) string {
+	if a == b {
+		return ""
+	}
+
+	ops := diffLines(splitLines(a), splitLines(b))
+
+	buf := strings.Builder{}
+	buf.WriteString("--- " + name + "\n")
+	buf.WriteString("+++ " + name + " (regenerated)\n")
+
+	for start := 0; start < len(ops); {
+		first := start
+		for first < len(ops) && ops[first].kind == ' ' {
+			first++
+		}
+
+		if first == len(ops) {
+			break
+		}
+
+		// extend the hunk while the gaps between changes are not longer than the context on both sides
+		last, equal := first, 0
+		for i := first; i < len(ops) && equal <= 2*diffContext; i++ {
+			if ops[i].kind == ' ' {
+				equal++
+
+				continue
+			}
+
+			last, equal = i, 0
+		}
+
+		from, to := maxInt(first-diffContext, start), minInt(last+diffContext+1, len(ops))
+		writeHunk(&buf, ops, from, to)
+		start = to
+	}
+
+	return buf.String()
+}
+
+func writeHunk(buf *strings.Builder, ops []diffOp, from int, to int) {
+	aLine, bLine := 1, 1
+
+	for _, o := range ops[:from] {
+		if o.kind != '+' {
+			aLine++
+		}
+
+		if o.kind != '-' {
+			bLine++
+		}
+	}
+
+	aLen, bLen := 0, 0
+
+	for _, o := range ops[from:to] {
+		if o.kind != '+' {
+			aLen++
+		}
+
+		if o.kind != '-' {
+			bLen++
+		}
+	}
+
+	// empty ranges refer to the line before them
+	if aLen == 0 {
+		aLine--
+	}
+
+	if bLen == 0 {
+		bLine--
+	}
+
+	buf.WriteString(fmt.Sprintf("@@ -%d,%d +%d,%d @@\n", aLine, aLen, bLine, bLen))
+
+	for _, o := range ops[from:to] {
+		buf.WriteString(string(o.kind) + o.line + "\n")
+	}
+}
+
+func splitLines(s string) []string {
+	return strings.Split(strings.TrimSuffix(s, "\n"), "\n")
+}
+
+// diffLines returns the shortest edit script that transforms a into b, see myersDiff.
+// Common prefixes and suffixes are skipped, so regenerated fixtures with few changes are compared quickly.
+func diffLines(a []string, b []string) []diffOp {
+	prefix := 0
+	for prefix < len(a) && prefix < len(b) && a[prefix] == b[prefix] {
+		prefix++
+	}
+
+	suffix := 0
+	for suffix < len(a)-prefix && suffix < len(b)-prefix && a[len(a)-1-suffix] == b[len(b)-1-suffix] {
+		suffix++
+	}
+
+	ops := make([]diffOp, 0, len(a)+len(b))
+	for _, l := range a[:prefix] {
+		ops = append(ops, diffOp{kind: ' ', line: l})
+	}
+
+	ops = append(ops, myersDiff(a[prefix:len(a)-suffix], b[prefix:len(b)-suffix])...)
+
+	for _, l := range a[len(a)-suffix:] {
+		ops = append(ops, diffOp{kind: ' ', line: l})
+	}
+
+	return ops
+}
+
+// myersDiff returns the shortest edit script that transforms a into b, it implements the algorithm
+// described in "An O(ND) Difference Algorithm and Its Variations" by Eugene W. Myers.
+// It takes O((N+M)·D) time and O(D²) memory, where D is the number of changed lines.
+func myersDiff(a []string, b []string) []diffOp {
+	n, m := len(a), len(b)
+	offset := n + m
+	// v[offset+k] is the furthest x reached on the diagonal k = x - y
+	v := make([]int, 2*offset+2) //nolint:gomnd
+	// trace[d][d+k] is the furthest x reached on the diagonal k with d changes
+	trace := make([][]int, 0)
+
+	for d, found := 0, false; d <= n+m && !found; d++ {
+		for k := -d; k <= d; k += 2 {
+			x := v[offset+k+1] // an insertion, i.e. a move down
+			if k != -d && (k == d || v[offset+k-1] >= v[offset+k+1]) {
+				x = v[offset+k-1] + 1 // a deletion, i.e. a move right
+			}
+
+			y := x - k
+			for x < n && y < m && a[x] == b[y] {
+				x, y = x+1, y+1
+			}
+
+			v[offset+k] = x
+
+			if x >= n && y >= m {
+				found = true
+
+				break
+			}
+		}
+
+		trace = append(trace, append([]int(nil), v[offset-d:offset+d+1]...))
+	}
+
+	ops := make([]diffOp, 0, n+m)
+	x, y := n, m
+
+	for d := len(trace) - 1; d > 0; d-- {
+		prev := func(k int) int { return trace[d-1][d-1+k] }
+		k := x - y
+
+		prevK := k + 1
+		if k != -d && (k == d || prev(k-1) >= prev(k+1)) {
+			prevK = k - 1
+		}
+
+		prevX := prev(prevK)
+		prevY := prevX - prevK
+
+		for x > prevX && y > prevY {
+			ops = append(ops, diffOp{kind: ' ', line: a[x-1]})
+			x, y = x-1, y-1
+		}
+
+		if x == prevX {
+			ops = append(ops, diffOp{kind: '+', line: b[y-1]})
+			y--
+		} else {
+			ops = append(ops, diffOp{kind: '-', line: a[x-1]})
+			x--
+		}
+	}
+
+	for x > 0 && y > 0 {
+		ops = append(ops, diffOp{kind: ' ', line: a[x-1]})
+		x, y = x-1, y-1
+	}
+
+	for i, j := 0, len(ops)-1; i < j; i, j = i+1, j-1 {
+		ops[i], ops[j] = ops[j], ops[i]
+	}
+
+	return ops
+}
+
+func maxInt(a int, b int) int {
+	if a > b {
+		return a
+	}
+
+	return b
+}
+
+func minInt(a int, b int) int {
+	if a < b {
+		return a
+	}
+
+	return b
+}
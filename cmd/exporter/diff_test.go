@@ -0,0 +1,102 @@
+// Copyright (c) 2023–present Bartłomiej Krukowski
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is furnished
+// to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package main
+
+import (
+	"math/rand"
+	"strconv"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDiffLines(t *testing.T) {
+	t.Parallel()
+
+	// lcsLength returns the length of the longest common subsequence, the shortest edit script consists of
+	// len(a)+len(b)-2*lcsLength(a, b) changes
+	lcsLength := func(a []string, b []string) int {
+		lcs := make([][]int, len(a)+1)
+		for i := range lcs {
+			lcs[i] = make([]int, len(b)+1)
+		}
+
+		for i := len(a) - 1; i >= 0; i-- {
+			for j := len(b) - 1; j >= 0; j-- {
+				if a[i] == b[j] {
+					lcs[i][j] = lcs[i+1][j+1] + 1
+				} else {
+					lcs[i][j] = maxInt(lcs[i+1][j], lcs[i][j+1])
+				}
+			}
+		}
+
+		return lcs[0][0]
+	}
+
+	randomLines := func(r *rand.Rand) []string {
+		lines := make([]string, r.Intn(20))
+		for i := range lines {
+			lines[i] = strconv.Itoa(r.Intn(4))
+		}
+
+		return lines
+	}
+
+	r := rand.New(rand.NewSource(1)) //nolint:gosec
+
+	for i := 0; i < 500; i++ {
+		a, b := randomLines(r), randomLines(r)
+		ops := diffLines(a, b)
+
+		var (
+			gotA, gotB []string
+			changes    int
+		)
+
+		for _, o := range ops {
+			if o.kind != '+' {
+				gotA = append(gotA, o.line)
+			}
+
+			if o.kind != '-' {
+				gotB = append(gotB, o.line)
+			}
+
+			if o.kind != ' ' {
+				changes++
+			}
+		}
+
+		assert.Equal(t, len(a), len(gotA), "%q %q", a, b)
+		assert.Equal(t, len(b), len(gotB), "%q %q", a, b)
+
+		if len(a) > 0 {
+			assert.Equal(t, a, gotA)
+		}
+
+		if len(b) > 0 {
+			assert.Equal(t, b, gotB)
+		}
+
+		assert.Equal(t, len(a)+len(b)-2*lcsLength(a, b), changes, "%q %q", a, b)
+	}
+}
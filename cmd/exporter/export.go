@@ -0,0 +1,157 @@
+// Copyright (c) 2023–present Bartłomiej Krukowski
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is furnished
+// to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package main
+
+import (
+	"encoding/json"
+	"flag"
+	"fmt"
+	"io"
+	"io/ioutil" //nolint:staticcheck
+
+	"github.com/gontainer/exporter"
+	"gopkg.in/yaml.v3"
+)
+
+func exportCommand(args []string, stdin io.Reader, stdout io.Writer, stderr io.Writer) int {
+	flags := flag.NewFlagSet("export", flag.ContinueOnError)
+	flags.SetOutput(stderr)
+	options := newOptionFlags(flags)
+	pkg := flags.String("package", "main", "the name of the package of the generated file, requires -var-name")
+	varName := flags.String("var-name", "", "write a complete GO file that declares the variable of the given name")
+	format := flags.String("format", "json", "the format of the data, json or yaml")
+	yamlBools := flags.Bool("yaml-bools", false, "convert booleans of YAML 1.1, e.g. yes and off, requires -format yaml")
+
+	if err := flags.Parse(args); err != nil {
+		return exitError
+	}
+
+	if flags.NArg() > 1 || (*format != "json" && *format != "yaml") || (*yamlBools && *format != "yaml") {
+		_, _ = io.WriteString(stderr, usage)
+
+		return exitError
+	}
+
+	input := flags.Arg(0)
+	if input == "" {
+		input = "-"
+	}
+
+	decode := decodeJSON
+	if *format == "yaml" {
+		decode = func(data []byte) (interface{}, error) {
+			return decodeYAML(data, exporter.YAMLCoercion{Bools: *yamlBools})
+		}
+	}
+
+	code, err := exportData(input, stdin, decode, *pkg, *varName, options())
+	if err != nil {
+		_, _ = fmt.Fprintf(stderr, "exporter export: %s\n", err.Error())
+
+		return exitError
+	}
+
+	_, _ = io.WriteString(stdout, code)
+
+	return exitOK
+}
+
+// newOptionFlags defines the flags that configure the exporter, they are shared by the commands export and diff.
+// The returned function returns the options according to the parsed flags.
+func newOptionFlags(flags *flag.FlagSet) func() []exporter.Option {
+	useAny := flags.Bool("any", false, "write any instead of interface{}")
+	indent := flags.String("indent", "", "render composite literals across multiple lines indented with the given string")
+
+	return func() []exporter.Option {
+		opts := []exporter.Option{exporter.WithIndent(*indent)}
+		if *useAny {
+			opts = append(opts, exporter.WithAnyAlias())
+		}
+
+		return opts
+	}
+}
+
+// exportData exports the value decoded from the given file, or the given reader for "-",
+// to a GO literal, or to a complete GO file when the name of the variable is given.
+func exportData(
+	input string,
+	stdin io.Reader,
+	decode func([]byte) (interface{}, error),
+	pkg string,
+	varName string,
+	opts []exporter.Option,
+) (string, error) {
+	var (
+		data []byte
+		err  error
+	)
+
+	if input == "-" {
+		data, err = ioutil.ReadAll(stdin) //nolint:staticcheck
+	} else {
+		data, err = ioutil.ReadFile(input) //nolint:staticcheck
+	}
+
+	if err != nil {
+		return "", fmt.Errorf("cannot read %s: %w", input, err)
+	}
+
+	v, err := decode(data)
+	if err != nil {
+		return "", fmt.Errorf("cannot decode %s: %w", input, err)
+	}
+
+	e := exporter.New(opts...)
+
+	if varName != "" {
+		code, err := e.ExportFile(pkg, varName, v)
+		if err != nil {
+			return "", fmt.Errorf("cannot export %s: %w", input, err)
+		}
+
+		return string(code), nil
+	}
+
+	code, err := e.Export(v)
+	if err != nil {
+		return "", fmt.Errorf("cannot export %s: %w", input, err)
+	}
+
+	return code + "\n", nil
+}
+
+func decodeJSON(data []byte) (interface{}, error) {
+	var v interface{}
+	err := json.Unmarshal(data, &v)
+
+	return v, err //nolint:wrapcheck
+}
+
+// decodeYAML decodes the given YAML document, and converts its scalars, see exporter.CoerceYAML.
+func decodeYAML(data []byte, coercion exporter.YAMLCoercion) (interface{}, error) {
+	var v interface{}
+	if err := yaml.Unmarshal(data, &v); err != nil {
+		return nil, err //nolint:wrapcheck
+	}
+
+	return exporter.CoerceYAML(v, coercion), nil
+}
@@ -0,0 +1,133 @@
+// Copyright (c) 2023–present Bartłomiej Krukowski
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is furnished
+// to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package main
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRun_export(t *testing.T) {
+	t.Parallel()
+
+	dir := writeFiles(t, map[string]string{
+		"users.json":   `[{"name": "Mary", "tags": {}}]`,
+		"invalid.json": `[`,
+	})
+	defer os.RemoveAll(dir)
+
+	scenarios := []struct {
+		name   string
+		args   []string
+		stdin  string
+		output string
+	}{
+		{
+			name:   "File",
+			args:   []string{filepath.Join(dir, "users.json")},
+			output: `[]interface{}{map[string]interface{}{"name": "Mary", "tags": map[string]interface{}{}}}` + "\n",
+		},
+		{
+			name:   "Standard input",
+			stdin:  `{"a": [1, true]}`,
+			output: `map[string]interface{}{"a": []interface{}{float64(1), true}}` + "\n",
+		},
+		{
+			name:   "Any",
+			args:   []string{"--any", "-"},
+			stdin:  `{"interface{}": []}`,
+			output: `map[string]any{"interface{}": make([]any, 0)}` + "\n",
+		},
+		{
+			name:   "Indentation",
+			args:   []string{"--indent", "  "},
+			stdin:  `[1, 2]`,
+			output: "[]interface{}{\n  float64(1),\n  float64(2),\n}\n",
+		},
+		{
+			name:   "YAML",
+			args:   []string{"-format", "yaml"},
+			stdin:  "debug: yes\nports: [80, 443]\n",
+			output: `map[string]interface{}{"debug": "yes", "ports": []interface{}{int(80), int(443)}}` + "\n",
+		},
+		{
+			name:   "YAML booleans",
+			args:   []string{"-format", "yaml", "-yaml-bools"},
+			stdin:  "debug: yes\n",
+			output: `map[string]interface{}{"debug": true}` + "\n",
+		},
+		{
+			name:  "YAML file",
+			args:  []string{"-format", "yaml", "-package", "fixtures", "-var-name", "Config", "-yaml-bools"},
+			stdin: "debug: on\n",
+			output: "// Code generated by github.com/gontainer/exporter. DO NOT EDIT.\n" +
+				"// Checksum: sha256:25b2cfd986375a7a7bbf23f9f27a8f585381574ea83b164776366a63b517f99c\n" +
+				"// Format: 2\n\n" +
+				"package fixtures\n\nvar Config = map[string]interface{}{\"debug\": true}\n",
+		},
+		{
+			name:  "Complete file",
+			args:  []string{"--package", "fixtures", "--var-name", "Numbers", "--any"},
+			stdin: `[1]`,
+			output: "// Code generated by github.com/gontainer/exporter. DO NOT EDIT.\n" +
+				"// Checksum: sha256:e27ef6012932de5351da8d7d4c1ba187c4f471700d96200d5cfda0d0673a6daa\n" +
+				"// Format: 2\n\n" +
+				"package fixtures\n\nvar Numbers = []any{float64(1)}\n",
+		},
+	}
+
+	for _, s := range scenarios {
+		s := s
+
+		t.Run(s.name, func(t *testing.T) {
+			stdout, stderr := bytes.NewBuffer(nil), bytes.NewBuffer(nil)
+			code := run(append([]string{"export"}, s.args...), strings.NewReader(s.stdin), stdout, stderr)
+			assert.Equal(t, exitOK, code)
+			assert.Empty(t, stderr.String())
+			assert.Equal(t, s.output, stdout.String())
+		})
+	}
+
+	t.Run("Errors", func(t *testing.T) {
+		scenarios := map[string][]string{
+			"exporter export: cannot decode " + filepath.Join(dir, "invalid.json"): {filepath.Join(dir, "invalid.json")},
+			"exporter export: cannot read " + filepath.Join(dir, "missing.json"):   {filepath.Join(dir, "missing.json")},
+			`exporter export: cannot export -: "my-pkg" is not a valid identifier`: {
+				"-package", "my-pkg", "-var-name", "Numbers", "-",
+			},
+			"Usage:":                 {"a.json", "b.json"},
+			"exporter export [-any]": {"-format", "xml", "-"},
+			"-yaml-bools":            {"-yaml-bools", "-"},
+		}
+
+		for expected, args := range scenarios {
+			stdout, stderr := bytes.NewBuffer(nil), bytes.NewBuffer(nil)
+			assert.Equal(t, exitError, run(append([]string{"export"}, args...), strings.NewReader("[]"), stdout, stderr))
+			assert.Empty(t, stdout.String())
+			assert.Contains(t, stderr.String(), expected)
+		}
+	})
+}
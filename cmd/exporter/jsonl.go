@@ -0,0 +1,303 @@
+// Copyright (c) 2023–present Bartłomiej Krukowski
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is furnished
+// to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package main
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"flag"
+	"fmt"
+	"go/token"
+	"hash"
+	"io"
+	"os"
+	"strings"
+
+	"github.com/gontainer/exporter"
+)
+
+func jsonlCommand(args []string, stdin io.Reader, stdout io.Writer, stderr io.Writer) int {
+	flags := flag.NewFlagSet("jsonl", flag.ContinueOnError)
+	flags.SetOutput(stderr)
+	output := flags.String("o", "", "write the GO file to the given path instead of the standard output")
+	shardSize := flags.Int(
+		"shard-size",
+		0,
+		"split records into files named <output>_<i>.go of at most the given number of records, requires -o",
+	)
+
+	if err := flags.Parse(args); err != nil {
+		return exitError
+	}
+
+	if flags.NArg() != 3 || *shardSize < 0 || (*shardSize > 0 && *output == "") { //nolint:gomnd
+		_, _ = io.WriteString(stderr, usage)
+
+		return exitError
+	}
+
+	pkg, varName, input := flags.Arg(0), flags.Arg(1), flags.Arg(2)
+
+	if err := convertJSONL(pkg, varName, input, *output, *shardSize, stdin, stdout); err != nil {
+		_, _ = fmt.Fprintf(stderr, "exporter jsonl: %s\n", err.Error())
+
+		return exitError
+	}
+
+	return exitOK
+}
+
+// convertJSONL reads the newline-delimited JSON records from the given file, or the given reader for "-",
+// and writes the GO file that declares them as elements of a slice.
+// The records are decoded and written one by one, so the input is never loaded as a whole.
+// On failure, the file being written is removed, because it would not compile.
+func convertJSONL(
+	pkg string,
+	varName string,
+	input string,
+	output string,
+	shardSize int,
+	stdin io.Reader,
+	stdout io.Writer,
+) error {
+	if !token.IsIdentifier(pkg) || !token.IsIdentifier(varName) {
+		return fmt.Errorf("invalid package name %q or variable name %q", pkg, varName) //nolint:goerr113
+	}
+
+	r := stdin
+
+	if input != "-" {
+		f, err := os.Open(input)
+		if err != nil {
+			return fmt.Errorf("cannot read %s: %w", input, err)
+		}
+		defer f.Close()
+
+		r = f
+	}
+
+	shards := shardWriter{pkg: pkg, varName: varName, output: output, size: shardSize, stdout: stdout}
+
+	if err := writeRecords(json.NewDecoder(r), input, &shards); err != nil {
+		shards.abort()
+
+		return err
+	}
+
+	return nil
+}
+
+func writeRecords(decoder *json.Decoder, input string, shards *shardWriter) error {
+	for i := 0; ; i++ {
+		var v interface{}
+		if err := decoder.Decode(&v); err != nil {
+			if errors.Is(err, io.EOF) {
+				break
+			}
+
+			return fmt.Errorf("cannot decode the record %d of %s: %w", i, input, err)
+		}
+
+		code, err := exporter.Export(v)
+		if err != nil {
+			return fmt.Errorf("cannot export the record %d of %s: %w", i, input, err)
+		}
+
+		if err := shards.write(code); err != nil {
+			return err
+		}
+	}
+
+	return shards.close()
+}
+
+// shardWriter writes exported records as elements of slices declared in GO files.
+// When the size is positive, every file contains at most size records, and the files are named after the output,
+// e.g. events_0.go, events_1.go, and they declare the variables events0, events1, etc.
+// Every file contains the checksum verified by exporter.VerifyGenerated. Since the checksum precedes the records,
+// files are written with a placeholder overwritten at the end, and the standard output is buffered.
+type shardWriter struct {
+	pkg     string
+	varName string
+	output  string
+	size    int
+	stdout  io.Writer
+
+	file    *os.File
+	buf     *bytes.Buffer
+	sum     hash.Hash
+	w       io.Writer
+	shard   int
+	records int
+}
+
+func (s *shardWriter) write(code string) error {
+	if s.w == nil {
+		if err := s.begin(); err != nil {
+			return err
+		}
+	}
+
+	if s.records == 0 {
+		if _, err := io.WriteString(s.w, "\n"); err != nil {
+			return fmt.Errorf("cannot write %s: %w", s.name(), err)
+		}
+	}
+
+	s.records++
+
+	if _, err := io.WriteString(s.w, "\t"+code+",\n"); err != nil {
+		return fmt.Errorf("cannot write %s: %w", s.name(), err)
+	}
+
+	// full shards are closed right away, so a failure does not affect them
+	if s.size > 0 && s.records == s.size {
+		if err := s.end(); err != nil {
+			return err
+		}
+
+		s.shard++
+	}
+
+	return nil
+}
+
+func (s *shardWriter) close() error {
+	if s.w == nil {
+		// empty input produces an empty slice
+		if s.shard > 0 {
+			return nil
+		}
+
+		if err := s.begin(); err != nil {
+			return err
+		}
+	}
+
+	return s.end()
+}
+
+func (s *shardWriter) name() string {
+	switch {
+	case s.output == "":
+		return "the standard output"
+	case s.size == 0:
+		return s.output
+	}
+
+	return fmt.Sprintf("%s_%d.go", strings.TrimSuffix(s.output, ".go"), s.shard)
+}
+
+func (s *shardWriter) begin() error {
+	s.sum, s.records = sha256.New(), 0
+
+	if s.output == "" {
+		s.buf = bytes.NewBuffer(nil)
+		s.w = io.MultiWriter(s.buf, s.sum)
+	} else {
+		f, err := os.Create(s.name())
+		if err != nil {
+			return fmt.Errorf("cannot create %s: %w", s.name(), err)
+		}
+
+		s.file, s.w = f, io.MultiWriter(f, s.sum)
+
+		placeholder := exporter.GeneratedHeader + "\n" + checksumLine(strings.Repeat("0", sha256.Size*2))
+		if _, err := io.WriteString(f, placeholder); err != nil {
+			return fmt.Errorf("cannot write %s: %w", s.name(), err)
+		}
+	}
+
+	varName := s.varName
+	if s.size > 0 {
+		varName = fmt.Sprintf("%s%d", varName, s.shard)
+	}
+
+	header := fmt.Sprintf(
+		"%s%d\n\npackage %s\n\nvar %s = []interface{}{",
+		exporter.FormatVersionPrefix,
+		exporter.LatestFormatVersion,
+		s.pkg,
+		varName,
+	)
+	if _, err := io.WriteString(s.w, header); err != nil {
+		return fmt.Errorf("cannot write %s: %w", s.name(), err)
+	}
+
+	return nil
+}
+
+func (s *shardWriter) end() error {
+	if _, err := io.WriteString(s.w, "}\n"); err != nil {
+		return fmt.Errorf("cannot write %s: %w", s.name(), err)
+	}
+
+	s.w = nil
+	line := checksumLine(hex.EncodeToString(s.sum.Sum(nil)))
+
+	if s.file == nil {
+		if _, err := io.WriteString(s.stdout, exporter.GeneratedHeader+"\n"+line); err != nil {
+			return fmt.Errorf("cannot write %s: %w", s.name(), err)
+		}
+
+		if _, err := s.buf.WriteTo(s.stdout); err != nil {
+			return fmt.Errorf("cannot write %s: %w", s.name(), err)
+		}
+
+		s.buf = nil
+
+		return nil
+	}
+
+	// the checksum line has the same length as the placeholder that follows the header line
+	if _, err := s.file.WriteAt([]byte(line), int64(len(exporter.GeneratedHeader)+1)); err != nil {
+		return fmt.Errorf("cannot write %s: %w", s.name(), err)
+	}
+
+	f := s.file
+	s.file = nil
+
+	if err := f.Close(); err != nil {
+		return fmt.Errorf("cannot write %s: %w", s.name(), err)
+	}
+
+	return nil
+}
+
+// abort removes the file being written.
+func (s *shardWriter) abort() {
+	s.w, s.buf = nil, nil
+
+	if s.file == nil {
+		return
+	}
+
+	_ = s.file.Close()
+	_ = os.Remove(s.name())
+	s.file = nil
+}
+
+func checksumLine(sum string) string {
+	return exporter.ChecksumPrefix + sum + "\n"
+}
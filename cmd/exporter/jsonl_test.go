@@ -0,0 +1,145 @@
+// Copyright (c) 2023–present Bartłomiej Krukowski
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is furnished
+// to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package main
+
+import (
+	"bytes"
+	"io/ioutil" //nolint:staticcheck
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/gontainer/exporter"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRun_jsonl(t *testing.T) {
+	t.Parallel()
+
+	dir := writeFiles(t, map[string]string{
+		"events.jsonl":  "{\"id\": 1}\n{\"id\": 2, \"tags\": [\"a\"]}\n\n{\"id\": 3}\n",
+		"empty.jsonl":   "",
+		"invalid.jsonl": "{\"id\": 1}\n{\"id\":\n",
+	})
+	defer os.RemoveAll(dir)
+
+	t.Run("Standard output", func(t *testing.T) {
+		stdout, stderr := bytes.NewBuffer(nil), bytes.NewBuffer(nil)
+		code := run([]string{"jsonl", "fixtures", "events", filepath.Join(dir, "events.jsonl")}, nil, stdout, stderr)
+		assert.Equal(t, exitOK, code)
+		assert.Empty(t, stderr.String())
+		assert.Equal(
+			t,
+			`// Code generated by github.com/gontainer/exporter. DO NOT EDIT.
+// Checksum: sha256:2adfe6567cbc9b540199c485f65557789d956a397e4b306c6854faf930a08269
+// Format: 2
+
+package fixtures
+
+var events = []interface{}{
+	map[string]interface{}{"id": float64(1)},
+	map[string]interface{}{"id": float64(2), "tags": []interface{}{"a"}},
+	map[string]interface{}{"id": float64(3)},
+}
+`,
+			stdout.String(),
+		)
+	})
+
+	t.Run("Empty input", func(t *testing.T) {
+		stdout, stderr := bytes.NewBuffer(nil), bytes.NewBuffer(nil)
+		code := run([]string{"jsonl", "fixtures", "events", filepath.Join(dir, "empty.jsonl")}, nil, stdout, stderr)
+		assert.Equal(t, exitOK, code)
+		assert.Empty(t, stderr.String())
+		assert.Contains(t, stdout.String(), "\nvar events = []interface{}{}\n")
+	})
+
+	t.Run("Shards", func(t *testing.T) {
+		output := filepath.Join(dir, "shards.go")
+		stdout, stderr := bytes.NewBuffer(nil), bytes.NewBuffer(nil)
+		code := run(
+			[]string{"jsonl", "-o", output, "-shard-size", "2", "fixtures", "events", filepath.Join(dir, "events.jsonl")},
+			nil,
+			stdout,
+			stderr,
+		)
+		assert.Equal(t, exitOK, code)
+		assert.Empty(t, stdout.String())
+		assert.Empty(t, stderr.String())
+
+		first, err := ioutil.ReadFile(filepath.Join(dir, "shards_0.go")) //nolint:staticcheck
+		require.NoError(t, err)
+		assert.Contains(
+			t,
+			string(first),
+			"var events0 = []interface{}{\n"+
+				"\tmap[string]interface{}{\"id\": float64(1)},\n"+
+				"\tmap[string]interface{}{\"id\": float64(2), \"tags\": []interface{}{\"a\"}},\n}\n",
+		)
+
+		second, err := ioutil.ReadFile(filepath.Join(dir, "shards_1.go")) //nolint:staticcheck
+		require.NoError(t, err)
+		assert.Contains(t, string(second), "var events1 = []interface{}{\n\tmap[string]interface{}{\"id\": float64(3)},\n}\n")
+
+		assert.NoFileExists(t, filepath.Join(dir, "shards_2.go"))
+
+		for _, name := range []string{"shards_0.go", "shards_1.go"} {
+			assert.NoError(t, exporter.VerifyGenerated(filepath.Join(dir, name)))
+		}
+	})
+
+	t.Run("Removed shard", func(t *testing.T) {
+		output := filepath.Join(dir, "invalid.go")
+		stdout, stderr := bytes.NewBuffer(nil), bytes.NewBuffer(nil)
+		code := run(
+			[]string{"jsonl", "-o", output, "-shard-size", "1", "fixtures", "events", filepath.Join(dir, "invalid.jsonl")},
+			nil,
+			stdout,
+			stderr,
+		)
+		assert.Equal(t, exitError, code)
+		assert.Contains(t, stderr.String(), "cannot decode the record 1 of")
+		assert.NoError(t, exporter.VerifyGenerated(filepath.Join(dir, "invalid_0.go")))
+		assert.NoFileExists(t, filepath.Join(dir, "invalid_1.go"))
+	})
+
+	t.Run("Errors", func(t *testing.T) {
+		scenarios := map[string][]string{
+			"exporter jsonl: cannot decode the record 1 of " + filepath.Join(dir, "invalid.jsonl"): {
+				"fixtures", "events", filepath.Join(dir, "invalid.jsonl"),
+			},
+			"exporter jsonl: cannot read " + filepath.Join(dir, "missing.jsonl"): {
+				"fixtures", "events", filepath.Join(dir, "missing.jsonl"),
+			},
+			`exporter jsonl: invalid package name "my-fixtures" or variable name "events"`: {
+				"my-fixtures", "events", filepath.Join(dir, "events.jsonl"),
+			},
+			"Usage:": {"-shard-size", "2", "fixtures", "events", filepath.Join(dir, "events.jsonl")},
+		}
+
+		for expected, args := range scenarios {
+			stdout, stderr := bytes.NewBuffer(nil), bytes.NewBuffer(nil)
+			assert.Equal(t, exitError, run(append([]string{"jsonl"}, args...), nil, stdout, stderr))
+			assert.Contains(t, stderr.String(), expected)
+		}
+	})
+}
@@ -0,0 +1,92 @@
+// Copyright (c) 2023–present Bartłomiej Krukowski
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is furnished
+// to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+// Command exporter works with GO files generated by github.com/gontainer/exporter.
+//
+// Usage:
+//
+//	exporter export [-any] [-indent <string>] [-format json|yaml] [-yaml-bools]
+//		[-package <name>] [-var-name <name>] [<data>]
+//	exporter diff [-any] [-indent <string>] [-package <name>] [-var-name <name>] <generated.go> <data.json>
+//	exporter jsonl [-o <output.go>] [-shard-size <n>] <package> <variable> <data.jsonl>
+//
+// The command export prints the GO literal of the given JSON data, or YAML data given the flag -format yaml,
+// it reads the standard input when the data file is omitted or "-". The flag -yaml-bools converts booleans
+// of YAML 1.1, see exporter.YAMLCoercion. The flag -var-name makes it print a complete GO file instead,
+// e.g. for go:generate:
+//
+//	//go:generate sh -c "exporter export -package fixtures -var-name Users users.json > users.go"
+//
+// The command diff regenerates the given file from the given JSON data in memory,
+// and prints the unified diff between them. It accepts the flags of the command export, that must match
+// the ones the file was generated with. The names of the package and the variable default to the ones
+// declared by the file, functions declared instead of variables are recognized too.
+// It exits with the status 1 when the file differs, so CI can detect fixtures that have drifted
+// from their source of truth.
+//
+// The command jsonl converts newline-delimited JSON records to a GO file that declares a slice
+// with one element per record. It reads the standard input when the data file is "-".
+// The records are processed one by one, so large event logs are not loaded into memory.
+// The flag -shard-size splits the records into many files, see -h for details.
+package main
+
+import (
+	"fmt"
+	"io"
+	"os"
+)
+
+const (
+	exitOK    = 0
+	exitDiff  = 1
+	exitError = 2
+)
+
+const usage = `Usage:
+	exporter export [-any] [-indent <string>] [-format json|yaml] [-yaml-bools]
+		[-package <name>] [-var-name <name>] [<data>]
+	exporter diff [-any] [-indent <string>] [-package <name>] [-var-name <name>] <generated.go> <data.json>
+	exporter jsonl [-o <output.go>] [-shard-size <n>] <package> <variable> <data.jsonl>
+`
+
+func main() {
+	os.Exit(run(os.Args[1:], os.Stdin, os.Stdout, os.Stderr))
+}
+
+func run(args []string, stdin io.Reader, stdout io.Writer, stderr io.Writer) int {
+	if len(args) == 0 {
+		_, _ = io.WriteString(stderr, usage)
+
+		return exitError
+	}
+
+	switch args[0] {
+	case "export":
+		return exportCommand(args[1:], stdin, stdout, stderr)
+	case "diff":
+		return diffCommand(args[1:], stdout, stderr)
+	case "jsonl":
+		return jsonlCommand(args[1:], stdin, stdout, stderr)
+	default:
+		_, _ = fmt.Fprintf(stderr, "unknown command %q\n%s", args[0], usage)
+
+		return exitError
+	}
+}
@@ -0,0 +1,253 @@
+// Copyright (c) 2023–present Bartłomiej Krukowski
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is furnished
+// to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package main
+
+import (
+	"bytes"
+	"io/ioutil" //nolint:staticcheck
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/gontainer/exporter"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func writeFiles(t *testing.T, files map[string]string) string {
+	t.Helper()
+
+	dir, err := ioutil.TempDir("", "exporter") //nolint:staticcheck
+	require.NoError(t, err)
+
+	for name, content := range files {
+		require.NoError(t, ioutil.WriteFile(filepath.Join(dir, name), []byte(content), 0o600)) //nolint:staticcheck
+	}
+
+	return dir
+}
+
+func TestRun_diff(t *testing.T) {
+	t.Parallel()
+
+	generated, err := exporter.ExportFile("fixtures", "Users", []interface{}{
+		map[string]interface{}{"name": "Mary", "age": float64(30)},
+	})
+	require.NoError(t, err)
+
+	t.Run("No drift", func(t *testing.T) {
+		t.Parallel()
+
+		dir := writeFiles(t, map[string]string{
+			"users.go":   string(generated),
+			"users.json": `[{"name": "Mary", "age": 30}]`,
+		})
+		defer os.RemoveAll(dir)
+
+		stdout, stderr := bytes.NewBuffer(nil), bytes.NewBuffer(nil)
+		code := run([]string{"diff", filepath.Join(dir, "users.go"), filepath.Join(dir, "users.json")}, nil, stdout, stderr)
+		assert.Equal(t, exitOK, code)
+		assert.Empty(t, stdout.String())
+		assert.Empty(t, stderr.String())
+	})
+
+	t.Run("Older format", func(t *testing.T) {
+		t.Parallel()
+
+		old, err := exporter.New(exporter.WithFormatVersion(exporter.FormatVersion1)).
+			ExportFile("fixtures", "Users", []interface{}{"Mary"})
+		require.NoError(t, err)
+
+		dir := writeFiles(t, map[string]string{
+			"users.go":   string(old),
+			"users.json": `["Mary"]`,
+		})
+		defer os.RemoveAll(dir)
+
+		stdout, stderr := bytes.NewBuffer(nil), bytes.NewBuffer(nil)
+		code := run([]string{"diff", filepath.Join(dir, "users.go"), filepath.Join(dir, "users.json")}, nil, stdout, stderr)
+		assert.Equal(t, exitOK, code)
+		assert.Empty(t, stdout.String())
+		assert.Empty(t, stderr.String())
+	})
+
+	t.Run("Options", func(t *testing.T) {
+		t.Parallel()
+
+		scenarios := map[string]struct {
+			options []exporter.Option
+			flags   []string
+		}{
+			"Indent and any": {
+				options: []exporter.Option{exporter.WithIndent("  "), exporter.WithAnyAlias()},
+				flags:   []string{"-indent", "  ", "-any"},
+			},
+			"Functions": {
+				options: []exporter.Option{exporter.WithDeclaration(exporter.DeclarationFunc)},
+			},
+			"Copy accessors": {
+				options: []exporter.Option{exporter.WithCopyAccessors("Copy")},
+			},
+		}
+
+		for name, s := range scenarios {
+			generated, err := exporter.New(s.options...).ExportFile("fixtures", "Users", []interface{}{"Mary"})
+			require.NoError(t, err)
+
+			dir := writeFiles(t, map[string]string{"users.go": string(generated), "users.json": `["Mary"]`})
+			defer os.RemoveAll(dir)
+
+			stdout, stderr := bytes.NewBuffer(nil), bytes.NewBuffer(nil)
+			args := append([]string{"diff"}, s.flags...)
+			args = append(args, filepath.Join(dir, "users.go"), filepath.Join(dir, "users.json"))
+			assert.Equal(t, exitOK, run(args, nil, stdout, stderr), name)
+			assert.Empty(t, stdout.String(), name)
+			assert.Empty(t, stderr.String(), name)
+		}
+	})
+
+	t.Run("Package and variable", func(t *testing.T) {
+		t.Parallel()
+
+		dir := writeFiles(t, map[string]string{
+			"users.go":   string(generated),
+			"users.json": `[{"name": "Mary", "age": 30}]`,
+		})
+		defer os.RemoveAll(dir)
+
+		goFile := filepath.Join(dir, "users.go")
+		stdout, stderr := bytes.NewBuffer(nil), bytes.NewBuffer(nil)
+		args := []string{"diff", "-package", "users", "-var-name", "All", goFile, filepath.Join(dir, "users.json")}
+		assert.Equal(t, exitDiff, run(args, nil, stdout, stderr))
+		assert.Contains(t, stdout.String(), "-package fixtures\n+package users\n")
+		assert.Contains(t, stdout.String(), "+var All = ")
+		assert.Empty(t, stderr.String())
+	})
+
+	t.Run("Drift", func(t *testing.T) {
+		t.Parallel()
+
+		dir := writeFiles(t, map[string]string{
+			"users.go":   string(generated),
+			"users.json": `[{"name": "Mary", "age": 31}]`,
+		})
+		defer os.RemoveAll(dir)
+
+		goFile := filepath.Join(dir, "users.go")
+		stdout, stderr := bytes.NewBuffer(nil), bytes.NewBuffer(nil)
+		code := run([]string{"diff", goFile, filepath.Join(dir, "users.json")}, nil, stdout, stderr)
+		assert.Equal(t, exitDiff, code)
+		assert.Empty(t, stderr.String())
+		assert.Regexp(
+			t,
+			`^--- `+goFile+`
+\+\+\+ `+goFile+` \(regenerated\)
+@@ -1,7 \+1,7 @@
+ // Code generated by github.com/gontainer/exporter. DO NOT EDIT.
+-// Checksum: sha256:[0-9a-f]+
+\+// Checksum: sha256:[0-9a-f]+
+ // Format: 2
+ 
+ package fixtures
+ 
+-var Users = \[\]interface{}{map\[string\]interface{}{"age": float64\(30\), "name": "Mary"}}
+\+var Users = \[\]interface{}{map\[string\]interface{}{"age": float64\(31\), "name": "Mary"}}
+$`,
+			stdout.String(),
+		)
+	})
+
+	t.Run("Errors", func(t *testing.T) {
+		t.Parallel()
+
+		dir := writeFiles(t, map[string]string{
+			"empty.go":     "package fixtures\n",
+			"emptyvar.go":  "package fixtures\n\nvar ()\n",
+			"invalid.json": `[`,
+		})
+		defer os.RemoveAll(dir)
+
+		scenarios := map[string][]string{
+			"exporter diff: " + filepath.Join(dir, "empty.go") + ": no variable or function declared\n": {
+				filepath.Join(dir, "empty.go"),
+				filepath.Join(dir, "invalid.json"),
+			},
+			"exporter diff: " + filepath.Join(dir, "emptyvar.go") + ": empty variable declaration\n": {
+				filepath.Join(dir, "emptyvar.go"),
+				filepath.Join(dir, "invalid.json"),
+			},
+			"exporter diff: cannot read " + filepath.Join(dir, "missing.go"): {
+				filepath.Join(dir, "missing.go"),
+				filepath.Join(dir, "invalid.json"),
+			},
+			"Usage:": {filepath.Join(dir, "empty.go")},
+		}
+
+		for expected, args := range scenarios {
+			stdout, stderr := bytes.NewBuffer(nil), bytes.NewBuffer(nil)
+			assert.Equal(t, exitError, run(append([]string{"diff"}, args...), nil, stdout, stderr))
+			assert.Empty(t, stdout.String())
+			assert.Contains(t, stderr.String(), expected)
+		}
+	})
+}
+
+func TestRun(t *testing.T) {
+	t.Parallel()
+
+	stderr := bytes.NewBuffer(nil)
+	assert.Equal(t, exitError, run(nil, nil, bytes.NewBuffer(nil), stderr))
+	assert.Equal(t, usage, stderr.String())
+
+	stderr.Reset()
+	assert.Equal(t, exitError, run([]string{"merge"}, nil, bytes.NewBuffer(nil), stderr))
+	assert.Equal(t, "unknown command \"merge\"\n"+usage, stderr.String())
+}
+
+func TestUnifiedDiff(t *testing.T) {
+	t.Parallel()
+
+	a := "1\n2\n3\n4\n5\n6\n7\n8\n9\n10\n11\n12\n13\n14\n15\n16\n17\n18\n19\n20\n"
+	b := "1\n2\n3\nfour\n5\n6\n7\n8\n9\n10\n11\n12\n13\n14\n15\n16\n17\n18\n19\n20\n21\n"
+
+	assert.Empty(t, unifiedDiff("a.go", a, a))
+	assert.Equal(
+		t,
+		`--- a.go
++++ a.go (regenerated)
+@@ -1,7 +1,7 @@
+ 1
+ 2
+ 3
+-4
++four
+ 5
+ 6
+ 7
+@@ -18,3 +18,4 @@
+ 18
+ 19
+ 20
++21
+`,
+		unifiedDiff("a.go", a, b),
+	)
+}
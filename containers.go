@@ -0,0 +1,111 @@
+// Copyright (c) 2023–present Bartłomiej Krukowski
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is furnished
+// to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package exporter
+
+import (
+	"container/list"
+	"container/ring"
+	"fmt"
+	"reflect"
+	"strings"
+)
+
+//nolint:gochecknoglobals
+var (
+	listType = reflect.TypeOf((*list.List)(nil))
+	ringType = reflect.TypeOf((*ring.Ring)(nil))
+	anyType  = reflect.TypeOf((*any)(nil)).Elem()
+)
+
+// listExporter exports *list.List as a function literal that pushes the exported elements:
+//
+//	func() *list.List { l := list.New(); l.PushBack(int(1)); return l }()
+type listExporter struct {
+	exporter exporter
+	// integralFloats is the type of integers that replace integral floats, see WithIntegralFloats
+	integralFloats reflect.Type
+}
+
+func (e listExporter) export(v any) (string, error) {
+	l := v.(*list.List) //nolint:forcetypeassert
+	if l == nil {
+		return "(*list.List)(nil)", nil
+	}
+
+	calls := make([]string, 0, l.Len())
+
+	for el := l.Front(); el != nil; el = el.Next() {
+		s, err := e.exporter.export(integralFloat(e.integralFloats, anyType, el.Value))
+		if err != nil {
+			return "", newPathError("*list.List", IndexStep(len(calls)), err)
+		}
+
+		calls = append(calls, fmt.Sprintf("l.PushBack(%s); ", s))
+	}
+
+	return fmt.Sprintf("func() *list.List { l := list.New(); %sreturn l }()", strings.Join(calls, "")), nil
+}
+
+func (listExporter) supports(v any) bool {
+	return reflect.TypeOf(v) == listType
+}
+
+// ringExporter exports *ring.Ring as a function literal that assigns the exported values to a new ring:
+//
+//	func() *ring.Ring { r := ring.New(2); r.Value = int(1); r.Move(1).Value = int(2); return r }()
+//
+// Nil values are not assigned, since they are the default ones.
+type ringExporter struct {
+	exporter exporter
+	// integralFloats is the type of integers that replace integral floats, see WithIntegralFloats
+	integralFloats reflect.Type
+}
+
+func (e ringExporter) export(v any) (string, error) {
+	r := v.(*ring.Ring) //nolint:forcetypeassert
+	if r == nil {
+		return "(*ring.Ring)(nil)", nil
+	}
+
+	n := r.Len()
+	calls := make([]string, 0, n)
+
+	for i := 0; i < n; i++ {
+		s, err := e.exporter.export(integralFloat(e.integralFloats, anyType, r.Move(i).Value))
+		if err != nil {
+			return "", newPathError("*ring.Ring", IndexStep(i), err)
+		}
+
+		switch {
+		case s == "nil":
+		case i == 0:
+			calls = append(calls, fmt.Sprintf("r.Value = %s; ", s))
+		default:
+			calls = append(calls, fmt.Sprintf("r.Move(%d).Value = %s; ", i, s))
+		}
+	}
+
+	return fmt.Sprintf("func() *ring.Ring { r := ring.New(%d); %sreturn r }()", n, strings.Join(calls, "")), nil
+}
+
+func (ringExporter) supports(v any) bool {
+	return reflect.TypeOf(v) == ringType
+}
@@ -0,0 +1,132 @@
+// Copyright (c) 2023–present Bartłomiej Krukowski
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is furnished
+// to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package exporter_test
+
+import (
+	"container/list"
+	"container/ring"
+	"testing"
+
+	"github.com/gontainer/exporter"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+//nolint:testifylint
+func TestExport_containers(t *testing.T) {
+	t.Parallel()
+
+	newList := func(vals ...any) *list.List {
+		l := list.New()
+		for _, v := range vals {
+			l.PushBack(v)
+		}
+
+		return l
+	}
+
+	newRing := func(vals ...any) *ring.Ring {
+		r := ring.New(len(vals))
+		for i, v := range vals {
+			r.Move(i).Value = v
+		}
+
+		return r
+	}
+
+	loop := list.New()
+	loop.PushBack(loop)
+
+	//nolint:exhaustruct
+	scenarios := []struct {
+		name   string
+		input  any
+		output string
+		error  string
+	}{
+		{
+			name:  "List",
+			input: newList(1, "hello", []int{1}),
+			output: `func() *list.List { l := list.New(); ` +
+				`l.PushBack(int(1)); l.PushBack("hello"); l.PushBack([]int{int(1)}); return l }()`,
+		},
+		{
+			name:   "Empty list",
+			input:  list.New(),
+			output: `func() *list.List { l := list.New(); return l }()`,
+		},
+		{
+			name:   "Nil list",
+			input:  (*list.List)(nil),
+			output: `(*list.List)(nil)`,
+		},
+		{
+			name:  "List of lists",
+			input: newList(newList(true)),
+			output: `func() *list.List { l := list.New(); ` +
+				`l.PushBack(func() *list.List { l := list.New(); l.PushBack(true); return l }()); return l }()`,
+		},
+		{
+			name:  "List with unsupported value",
+			input: newList(1, make(chan int)),
+			error: `cannot export (*list.List)[1]: type chan int is not supported`,
+		},
+		{
+			name:  "List contains itself",
+			input: loop,
+			error: `cannot export (*list.List)[0]: unexpected infinite loop`,
+		},
+		{
+			name:   "Ring",
+			input:  newRing(1, nil, 3.14),
+			output: `func() *ring.Ring { r := ring.New(3); r.Value = int(1); r.Move(2).Value = float64(3.14); return r }()`,
+		},
+		{
+			name:   "Nil ring",
+			input:  (*ring.Ring)(nil),
+			output: `(*ring.Ring)(nil)`,
+		},
+		{
+			name:  "Ring with unsupported value",
+			input: newRing(make(chan int)),
+			error: `cannot export (*ring.Ring)[0]: type chan int is not supported`,
+		},
+	}
+
+	for _, s := range scenarios {
+		s := s
+
+		t.Run(s.name, func(t *testing.T) {
+			t.Parallel()
+
+			output, err := exporter.Export(s.input)
+			if s.error != "" {
+				assert.EqualError(t, err, s.error)
+				assert.Empty(t, output)
+
+				return
+			}
+
+			require.NoError(t, err)
+			assert.Equal(t, s.output, output)
+		})
+	}
+}
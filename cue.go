@@ -0,0 +1,251 @@
+// Copyright (c) 2023–present Bartłomiej Krukowski
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is furnished
+// to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package exporter
+
+import (
+	"fmt"
+	"reflect"
+	"strings"
+	"unicode"
+)
+
+// CUEBackend renders values in the CUE configuration language, see https://cuelang.org.
+// Numbers are rendered without their types, but floats always have a fraction or an exponent,
+// so CUE does not treat them as integers. Byte slices are rendered as bytes literals, e.g. 'hello',
+// structs and maps are rendered as structs, e.g.:
+//
+//	{Host: "localhost", Port: 8080, Tags: ["a", "b"]}
+//
+// Labels that are not valid identifiers are quoted. Annotations are not rendered.
+func CUEBackend() Backend { //nolint:ireturn
+	return cueBackend{}
+}
+
+type cueBackend struct{}
+
+func (cueBackend) renderNil() string {
+	return "null"
+}
+
+func (cueBackend) renderNumber(t reflect.Type, literal string) (string, error) {
+	if strings.ContainsAny(literal, "NI") {
+		return "", fmt.Errorf("%s(%s) cannot be represented in CUE", t.Kind().String(), literal) //nolint:goerr113
+	}
+
+	if (t.Kind() == reflect.Float32 || t.Kind() == reflect.Float64) && !strings.ContainsAny(literal, ".eE") {
+		literal += ".0"
+	}
+
+	return literal, nil
+}
+
+func (cueBackend) renderString(v string) string {
+	// the JSON encoding of strings escapes backslashes, so it never produces interpolations
+	return jsonBackend{}.renderString(v)
+}
+
+func (cueBackend) renderBytes(v []byte) string {
+	buf := strings.Builder{}
+	buf.WriteByte('\'')
+
+	for _, b := range v {
+		switch {
+		case b == '\'' || b == '\\':
+			buf.WriteString(`\` + string(b))
+		case b >= 0x20 && b < 0x7f:
+			buf.WriteByte(b)
+		default:
+			buf.WriteString(fmt.Sprintf(`\x%02x`, b))
+		}
+	}
+
+	buf.WriteByte('\'')
+
+	return buf.String()
+}
+
+func (cueBackend) renderNilSlice(reflect.Type) string {
+	return "null"
+}
+
+func (cueBackend) renderEmptySlice(reflect.Type) string {
+	return "[]"
+}
+
+func (cueBackend) renderSequence(_ reflect.Type, elements []string) string {
+	return "[" + strings.Join(elements, ", ") + "]"
+}
+
+func (b cueBackend) renderChunkedSequence(t reflect.Type, chunks [][]string) string {
+	elements := make([]string, 0)
+	for _, c := range chunks {
+		elements = append(elements, c...)
+	}
+
+	return b.renderSequence(t, elements)
+}
+
+func (b cueBackend) renderStruct(_ reflect.Type, fields []string, values []string) string {
+	parts := make([]string, len(fields))
+	for i, f := range fields {
+		parts[i] = b.renderLabel(f) + ": " + values[i]
+	}
+
+	return "{" + strings.Join(parts, ", ") + "}"
+}
+
+// renderLabel quotes the given label, unless it is a valid identifier of a regular field.
+// Identifiers that start with "_" or "#" declare hidden fields and definitions in CUE, so they are quoted too.
+func (b cueBackend) renderLabel(label string) string {
+	for i, r := range label {
+		if r != '_' && r != '$' && !unicode.IsLetter(r) && (i == 0 || !unicode.IsDigit(r)) {
+			return b.renderString(label)
+		}
+	}
+
+	if label == "" || label[0] == '_' {
+		return b.renderString(label)
+	}
+
+	return label
+}
+
+func (cueBackend) renderNilMap(reflect.Type) string {
+	return "null"
+}
+
+func (b cueBackend) renderChunkedMap(t reflect.Type, keys [][]string, values [][]string) (string, error) {
+	return b.renderMap(t, flatten(keys), flatten(values))
+}
+
+func (cueBackend) renderMap(_ reflect.Type, keys []string, values []string) (string, error) {
+	parts := make([]string, len(keys))
+
+	for i, k := range keys {
+		switch {
+		case strings.HasPrefix(k, `"`):
+		case k[0] == '-' || (k[0] >= '0' && k[0] <= '9'):
+			k = `"` + k + `"`
+		default:
+			return "", fmt.Errorf("key %s cannot be represented in CUE", k) //nolint:goerr113
+		}
+
+		parts[i] = k + ": " + values[i]
+	}
+
+	return "{" + strings.Join(parts, ", ") + "}", nil
+}
+
+func (cueBackend) renderNilPointer(reflect.Type) string {
+	return "null"
+}
+
+func (cueBackend) renderPointer(_ reflect.Type, value string) string {
+	return value
+}
+
+func (cueBackend) renderConversion(_ reflect.Type, value string) string {
+	return value
+}
+
+func (cueBackend) renderAtomic(_ reflect.Type, value string, _ bool) string {
+	return value
+}
+
+func (cueBackend) renderAnnotation(value string, _ string) string {
+	return value
+}
+
+func (cueBackend) exporters(config, exporter) []exporter {
+	return nil
+}
+
+// indentCUE breaks the lines of the given CUE value after the opening brackets and the elements of
+// lists and structs, and indents them with the given string.
+func indentCUE(code string, indent string) string {
+	var (
+		buf    strings.Builder
+		depth  int
+		quote  byte
+		escape bool
+	)
+
+	newline := func() {
+		buf.WriteByte('\n')
+		buf.WriteString(strings.Repeat(indent, depth))
+	}
+
+	for i := 0; i < len(code); i++ {
+		c := code[i]
+
+		if quote != 0 {
+			buf.WriteByte(c)
+
+			switch {
+			case escape:
+				escape = false
+			case c == '\\':
+				escape = true
+			case c == quote:
+				quote = 0
+			}
+
+			continue
+		}
+
+		switch c {
+		case '"', '\'':
+			quote = c
+			buf.WriteByte(c)
+		case '{', '[':
+			buf.WriteByte(c)
+
+			if i+1 < len(code) && (code[i+1] == '}' || code[i+1] == ']') {
+				continue
+			}
+
+			depth++
+			newline()
+		case '}', ']':
+			if i > 0 && (code[i-1] == '{' || code[i-1] == '[') {
+				buf.WriteByte(c)
+
+				continue
+			}
+
+			depth--
+			buf.WriteByte(',')
+			newline()
+			buf.WriteByte(c)
+		case ',':
+			buf.WriteByte(c)
+			newline()
+
+			if i+1 < len(code) && code[i+1] == ' ' {
+				i++
+			}
+		default:
+			buf.WriteByte(c)
+		}
+	}
+
+	return buf.String()
+}
@@ -0,0 +1,141 @@
+// Copyright (c) 2023–present Bartłomiej Krukowski
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is furnished
+// to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package exporter_test
+
+import (
+	"math"
+	"testing"
+
+	"github.com/gontainer/exporter"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type cueConfig struct {
+	Server StructServer
+	Ratio  float64
+}
+
+//nolint:testifylint
+func TestCUEBackend(t *testing.T) {
+	t.Parallel()
+
+	//nolint:exhaustruct
+	scenarios := []struct {
+		name   string
+		input  any
+		output string
+		error  string
+	}{
+		{
+			name:   "nil",
+			input:  nil,
+			output: "null",
+		},
+		{
+			name:   "Numbers",
+			input:  []any{int8(-5), 1.5, float32(2), 1e21},
+			output: "[-5, 1.5, 2.0, 1000000000000000000000.0]",
+		},
+		{
+			name:  "NaN",
+			input: math.NaN(),
+			error: "float64(NaN) cannot be represented in CUE",
+		},
+		{
+			name:   "Strings",
+			input:  []string{`\(x)`, "你好\n"},
+			output: `["\\(x)", "你好\n"]`,
+		},
+		{
+			name:   "Bytes",
+			input:  []byte("it's\x00"),
+			output: `'it\'s\x00'`,
+		},
+		{
+			name:   "Nil and empty values",
+			input:  []any{[]int(nil), []int{}, map[string]int(nil)},
+			output: `[null, [], null]`,
+		},
+		{
+			name:   "Structs",
+			input:  cueConfig{Server: StructServer{Host: "localhost", Port: 80}, Ratio: 1},
+			output: `{Server: {Host: "localhost", Port: 80}, Ratio: 1.0}`,
+		},
+		{
+			name:   "Maps",
+			input:  map[any]int{"b": 2, "#a": 1, 3: 3},
+			output: `{"#a": 1, "b": 2, "3": 3}`,
+		},
+		{
+			name:  "Invalid keys",
+			input: map[bool]int{true: 1},
+			error: "key true cannot be represented in CUE",
+		},
+	}
+
+	e := exporter.New(exporter.WithBackend(exporter.CUEBackend()))
+
+	for _, s := range scenarios {
+		s := s
+
+		t.Run(s.name, func(t *testing.T) {
+			t.Parallel()
+
+			output, err := e.Export(s.input)
+			if s.error != "" {
+				assert.EqualError(t, err, s.error)
+				assert.Empty(t, output)
+
+				return
+			}
+
+			require.NoError(t, err)
+			assert.Equal(t, s.output, output)
+		})
+	}
+}
+
+func TestCUEBackend_pretty(t *testing.T) {
+	t.Parallel()
+
+	e := exporter.New(exporter.WithBackend(exporter.CUEBackend()))
+	output, err := e.ExportPretty(map[string]any{
+		"servers": []StructServer{{Host: "a,[b]", Port: 80}},
+		"empty":   []int{},
+		"bytes":   []byte("{'}"),
+	})
+	require.NoError(t, err)
+	assert.Equal(
+		t,
+		`{
+	"bytes": '{\'}',
+	"empty": [],
+	"servers": [
+		{
+			Host: "a,[b]",
+			Port: 80,
+		},
+	],
+}`,
+		output,
+	)
+}
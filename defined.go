@@ -0,0 +1,82 @@
+// Copyright (c) 2023–present Bartłomiej Krukowski
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is furnished
+// to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package exporter
+
+import (
+	"reflect"
+)
+
+// nonIntegerTypes contains the underlying types of defined types supported by definedExporter, see also integerTypes.
+//
+//nolint:gochecknoglobals
+var nonIntegerTypes = map[reflect.Kind]reflect.Type{
+	reflect.Bool:    reflect.TypeOf(false),
+	reflect.String:  reflect.TypeOf(""),
+	reflect.Float32: reflect.TypeOf(float32(0)),
+	reflect.Float64: reflect.TypeOf(float64(0)),
+}
+
+// basicType returns the predeclared type of the given kind, or nil, if the kind is not supported by definedExporter.
+func basicType(k reflect.Kind) reflect.Type {
+	if t, ok := integerTypes[k]; ok {
+		return t
+	}
+
+	return nonIntegerTypes[k]
+}
+
+// definedExporter exports values of defined types of booleans, strings and numbers as conversions
+// of their underlying values, e.g.:
+//
+//	time.Duration(5000000000)
+//	pkg.Status("active")
+//
+// Packages of such types are imported by ExportFile like packages of any other named types.
+// Types that have dedicated exporters take precedence, e.g. os.FileMode.
+type definedExporter struct {
+	exporter exporter
+	backend  Backend
+	names    numberNames
+}
+
+func (d definedExporter) export(v any) (string, error) {
+	val := reflect.ValueOf(v)
+	basic := basicType(val.Kind())
+
+	code, err := d.exporter.export(val.Convert(basic).Interface())
+	if err != nil {
+		return "", err //nolint:wrapcheck
+	}
+
+	// the conversion to the defined type fixes the type of the number
+	code = elideScalar(scalarElision{enabled: true, names: d.names}, basic, code)
+
+	return d.backend.renderConversion(val.Type(), code), nil
+}
+
+func (definedExporter) supports(v any) bool {
+	t := reflect.TypeOf(v)
+	if t == nil || t.PkgPath() == "" {
+		return false
+	}
+
+	return basicType(t.Kind()) != nil
+}
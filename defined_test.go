@@ -0,0 +1,109 @@
+// Copyright (c) 2023–present Bartłomiej Krukowski
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is furnished
+// to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package exporter_test
+
+import (
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/gontainer/exporter"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type (
+	definedStatus string
+	definedRatio  float32
+	definedFlag   bool
+)
+
+//nolint:testifylint
+func TestExport_defined(t *testing.T) {
+	t.Parallel()
+
+	//nolint:exhaustruct
+	scenarios := []struct {
+		name    string
+		input   any
+		output  string
+		json    string
+		options []exporter.Option
+	}{
+		{
+			name:   "String",
+			input:  definedStatus("active"),
+			output: `exporter_test.definedStatus("active")`,
+			json:   `"active"`,
+		},
+		{
+			name:   "Float",
+			input:  []definedRatio{1.5, 2},
+			output: `[]exporter_test.definedRatio{exporter_test.definedRatio(1.5), exporter_test.definedRatio(2)}`,
+			json:   `[1.5,2]`,
+		},
+		{
+			name:   "Bool",
+			input:  map[string]definedFlag{"a": true},
+			output: `map[string]exporter_test.definedFlag{"a": exporter_test.definedFlag(true)}`,
+			json:   `{"a":true}`,
+		},
+		{
+			name:   "Standard library",
+			input:  []any{5 * time.Second, time.March},
+			output: `[]interface{}{time.Duration(5000000000), time.Month(3)}`,
+		},
+		{
+			name:    "Custom names of numbers",
+			input:   definedRatio(0.5),
+			output:  `exporter_test.definedRatio(0.5)`,
+			options: []exporter.Option{exporter.WithNumberTypeNames(map[reflect.Kind]string{reflect.Float32: "f32"})},
+		},
+	}
+
+	for _, s := range scenarios {
+		s := s
+
+		t.Run(s.name, func(t *testing.T) {
+			t.Parallel()
+
+			output, err := exporter.New(s.options...).Export(s.input)
+			require.NoError(t, err)
+			assert.Equal(t, s.output, output)
+
+			if s.json == "" {
+				return
+			}
+
+			output, err = exporter.New(exporter.WithBackend(exporter.JSONBackend())).Export(s.input)
+			require.NoError(t, err)
+			assert.Equal(t, s.json, output)
+		})
+	}
+
+	t.Run("Imports", func(t *testing.T) {
+		t.Parallel()
+
+		code, err := exporter.ExportFile("fixtures", "Timeout", time.Minute)
+		require.NoError(t, err)
+		assert.Contains(t, string(code), "import (\n\t\"time\"\n)\n\nvar Timeout = time.Duration(60000000000)\n")
+	})
+}
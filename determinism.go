@@ -0,0 +1,99 @@
+// Copyright (c) 2023–present Bartłomiej Krukowski
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is furnished
+// to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package exporter
+
+import (
+	"fmt"
+	"runtime"
+)
+
+// DeterminismCheck configures the self-check of exported values, see WithDeterminismCheck.
+type DeterminismCheck struct {
+	// Runs is the number of times each value is exported, values lower than 2 mean 2.
+	Runs int
+	// GC runs the garbage collector before each repeated export, so results that depend on addresses
+	// or finalizers are more likely to differ.
+	GC bool
+}
+
+// WithDeterminismCheck exports each value repeatedly, and fails if the results differ.
+// It detects nondeterministic output, e.g. custom exporters that iterate over maps without sorting their keys,
+// before such output lands in the version control. Iteration over maps in GO is randomized,
+// so subsequent runs are likely to visit entries in a different order.
+//
+// Cached results are not exported again, so the check does not work well with WithCache.
+func WithDeterminismCheck(c DeterminismCheck) Option {
+	return func(cfg *config) {
+		cfg.determinismCheck = &c
+	}
+}
+
+type determinismExporter struct {
+	exporter exporter
+	check    DeterminismCheck
+}
+
+func (d determinismExporter) export(v any) (string, error) {
+	first, err := d.exporter.export(v)
+	if err != nil {
+		return "", err //nolint:wrapcheck
+	}
+
+	runs := d.check.Runs
+	if runs < 2 { //nolint:gomnd
+		runs = 2
+	}
+
+	for i := 2; i <= runs; i++ {
+		if d.check.GC {
+			runtime.GC()
+		}
+
+		code, err := d.exporter.export(v)
+		if err != nil {
+			return "", fmt.Errorf("nondeterministic output: export %d failed: %w", i, err)
+		}
+
+		if code != first {
+			return "", fmt.Errorf( //nolint:goerr113
+				"nondeterministic output: export %d differs from export 1 at the byte %d",
+				i,
+				diffOffset(first, code),
+			)
+		}
+	}
+
+	return first, nil
+}
+
+func (d determinismExporter) supports(v any) bool {
+	return d.exporter.supports(v)
+}
+
+// diffOffset returns the index of the first byte that differs in the given strings.
+func diffOffset(a, b string) int {
+	i := 0
+	for i < len(a) && i < len(b) && a[i] == b[i] {
+		i++
+	}
+
+	return i
+}
@@ -0,0 +1,70 @@
+// Copyright (c) 2023–present Bartłomiej Krukowski
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is furnished
+// to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package exporter_test
+
+import (
+	"testing"
+
+	"github.com/gontainer/exporter"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestWithDeterminismCheck(t *testing.T) {
+	t.Parallel()
+
+	t.Run("Deterministic", func(t *testing.T) {
+		t.Parallel()
+
+		e := exporter.New(exporter.WithDeterminismCheck(exporter.DeterminismCheck{Runs: 5, GC: true}))
+		code, err := e.Export(map[string][]int{"b": {2}, "a": {1}, "c": nil})
+		require.NoError(t, err)
+		assert.Equal(t, `map[string][]int{"a": []int{int(1)}, "b": []int{int(2)}, "c": ([]int)(nil)}`, code)
+	})
+
+	t.Run("Nondeterministic", func(t *testing.T) {
+		t.Parallel()
+
+		calls := 0
+		// the iterator yields different values each time it is called
+		seq := func(yield func(int) bool) {
+			calls++
+			yield(calls)
+		}
+
+		e := exporter.New(
+			exporter.WithIterators(0),
+			exporter.WithDeterminismCheck(exporter.DeterminismCheck{}), //nolint:exhaustruct
+		)
+		code, err := e.Export(seq)
+		assert.EqualError(t, err, `nondeterministic output: export 2 differs from export 1 at the byte 10`)
+		assert.Empty(t, code)
+		assert.Equal(t, 2, calls)
+	})
+
+	t.Run("Error", func(t *testing.T) {
+		t.Parallel()
+
+		e := exporter.New(exporter.WithDeterminismCheck(exporter.DeterminismCheck{Runs: 3})) //nolint:exhaustruct
+		_, err := e.Export(make(chan int))
+		assert.EqualError(t, err, `type chan int is not supported`)
+	})
+}
@@ -19,4 +19,40 @@
 // THE SOFTWARE.
 
 // Package exporter provides a set of functions to export variables to a GO code.
+//
+// # Struct tags
+//
+// The tag "export" controls how a field of a struct is exported:
+//
+//	Password string `export:"-"`                       // the field is omitted
+//	Secret   string `export:"redact"`                  // the value is replaced by RedactedValue
+//	Mode     uint32 `export:"hex"`                     // uint32(0x1ff)
+//	Query    string `export:"raw"`                     // `SELECT *\nFROM users`
+//	Addr     net.IP `export:"template=net.ParseIP(%q)"` // net.ParseIP("127.0.0.1")
+//
+// Directives are separated by commas. The directive "template" must be the last one, since the template
+// may contain commas. In the template, %s is replaced by the exported value, and %q is replaced by the quoted value
+// formatted by fmt.Sprint. Redacted fields of types other than string are omitted.
+// Strings that cannot be represented as raw strings are quoted as usual.
+// The JSON backend ignores all directives but "-" and "redact".
+//
+// # Generated code
+//
+// Exported expressions are self-contained. Values that cannot be expressed by literals, e.g. lists,
+// hardware addresses and atomic values, are built by function literals that are called in place:
+//
+//	func() (v net.HardwareAddr) { v, _ = net.ParseMAC("00:00:5e:00:53:01"); return v }()
+//
+// The exporter does not emit helper functions that expressions depend on, so many exported expressions
+// can be composed into one file without deduplicating anything. Top-level declarations other than the
+// exported variable are emitted by ExportFile and Session only when options request them, and their names
+// are derived from the options:
+//
+//   - functions that return values of split maps, see WithMapSplitting,
+//   - the function that replaces the variable, see DeclarationFunc,
+//   - functions that return copies of the variable, see WithCopyAccessors,
+//   - generic functions that return asserted elements, see WithTypedAccessors,
+//   - constants of repeated strings declared by Session.
+//
+// Session.Declarations returns the names of these declarations, except constants.
 package exporter
@@ -0,0 +1,152 @@
+// Copyright (c) 2023–present Bartłomiej Krukowski
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is furnished
+// to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package exporter
+
+import (
+	"fmt"
+	"go/scanner"
+	"go/token"
+	"io"
+	"os"
+	"strings"
+)
+
+// ANSI escape codes used by Sdump, see WithColors.
+const (
+	colorReset   = "\x1b[0m"
+	colorKeyword = "\x1b[35m"
+	colorString  = "\x1b[32m"
+	colorNumber  = "\x1b[36m"
+	colorConst   = "\x1b[33m"
+	colorComment = "\x1b[90m"
+)
+
+// WithColors makes Exporter.Sdump and Exporter.Dump highlight the syntax using ANSI escape codes.
+func WithColors() Option {
+	return func(c *config) {
+		c.colors = true
+	}
+}
+
+// Sdump exports the given value for debugging purposes, see Exporter.Sdump.
+func Sdump(v any) string {
+	return defaultExporter.Sdump(v)
+}
+
+// Dump prints the result of Sdump to the standard output.
+func Dump(v any) {
+	defaultExporter.Dump(v)
+}
+
+// Fdump writes the result of Sdump to the given writer.
+func Fdump(w io.Writer, v any) {
+	defaultExporter.Fdump(w, v)
+}
+
+// Sdump exports the given value for debugging purposes. Unlike Exporter.Export, it never fails,
+// errors are returned as comments, e.g.:
+//
+//	/* cannot export chan int: type chan int is not supported */
+//
+// See WithColors.
+func (e *Exporter) Sdump(v any) string {
+	code, err := e.Export(v)
+	if err != nil {
+		code = fmt.Sprintf("/* cannot export %T: %s */", v, commentText(err.Error()))
+	}
+
+	if e.config.colors {
+		code = colorize(code)
+	}
+
+	return code
+}
+
+// Dump prints the result of Exporter.Sdump to the standard output.
+func (e *Exporter) Dump(v any) {
+	e.Fdump(os.Stdout, v)
+}
+
+// Fdump writes the result of Exporter.Sdump followed by a new line to the given writer.
+func (e *Exporter) Fdump(w io.Writer, v any) {
+	_, _ = io.WriteString(w, e.Sdump(v)+"\n")
+}
+
+// colorize highlights the syntax of the given code using ANSI escape codes.
+func colorize(code string) string {
+	fset := token.NewFileSet()
+	file := fset.AddFile("", fset.Base(), len(code))
+
+	var s scanner.Scanner
+	s.Init(file, []byte(code), nil, scanner.ScanComments)
+
+	buf := strings.Builder{}
+	last := 0
+
+	for {
+		pos, tok, lit := s.Scan()
+		if tok == token.EOF {
+			break
+		}
+
+		// automatically inserted semicolon
+		if tok == token.SEMICOLON && lit == "\n" {
+			continue
+		}
+
+		text := lit
+		if text == "" {
+			text = tok.String()
+		}
+
+		start := file.Offset(pos)
+		end := start + len(text)
+
+		var color string
+
+		switch {
+		case tok.IsKeyword():
+			color = colorKeyword
+		case tok == token.STRING || tok == token.CHAR:
+			color = colorString
+		case tok == token.INT || tok == token.FLOAT || tok == token.IMAG:
+			color = colorNumber
+		case tok == token.IDENT && (lit == "nil" || lit == "true" || lit == "false"):
+			color = colorConst
+		case tok == token.COMMENT:
+			color = colorComment
+		}
+
+		buf.WriteString(code[last:start])
+
+		if color != "" {
+			buf.WriteString(color + code[start:end] + colorReset)
+		} else {
+			buf.WriteString(code[start:end])
+		}
+
+		last = end
+	}
+
+	buf.WriteString(code[last:])
+
+	return buf.String()
+}
@@ -0,0 +1,70 @@
+// Copyright (c) 2023–present Bartłomiej Krukowski
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is furnished
+// to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package exporter_test
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/gontainer/exporter"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSdump(t *testing.T) {
+	t.Parallel()
+
+	t.Run("OK", func(t *testing.T) {
+		t.Parallel()
+
+		assert.Equal(t, `[]interface{}{nil, int(1), "a"}`, exporter.Sdump([]any{nil, 1, "a"}))
+	})
+
+	t.Run("Error", func(t *testing.T) {
+		t.Parallel()
+
+		assert.Equal(
+			t,
+			`/* cannot export []interface {}: cannot export ([]interface{})[0]: type chan int is not supported */`,
+			exporter.Sdump([]any{make(chan int)}),
+		)
+	})
+
+	t.Run("Colors", func(t *testing.T) {
+		t.Parallel()
+
+		e := exporter.New(exporter.WithColors(), exporter.WithIndexComments(1))
+		assert.Equal(
+			t,
+			"\x1b[35mmap\x1b[0m[string]\x1b[35minterface\x1b[0m{}{"+
+				"\x1b[32m\"a\"\x1b[0m: \x1b[33mnil\x1b[0m \x1b[90m/* [\"a\"] */\x1b[0m}",
+			e.Sdump(map[string]any{"a": nil}),
+		)
+	})
+}
+
+func TestFdump(t *testing.T) {
+	t.Parallel()
+
+	buf := bytes.NewBuffer(nil)
+	exporter.Fdump(buf, true)
+	exporter.New(exporter.WithColors()).Fdump(buf, 1.5)
+	assert.Equal(t, "true\nfloat64(\x1b[36m1.5\x1b[0m)\n", buf.String())
+}
@@ -0,0 +1,147 @@
+// Copyright (c) 2023–present Bartłomiej Krukowski
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is furnished
+// to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package exporter
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+	"sort"
+	"strconv"
+)
+
+// ExportEach exports elements of the given slice, array or map one by one, and passes the results to the callback fn.
+// It lets the caller process huge collections, e.g. shard them across files, without materializing the whole literal.
+// Entries of maps are exported like elements of map literals, e.g. "a": int(1), in the order of their keys,
+// and the index is the position of the entry in that order.
+// ExportEach stops on the first error returned by fn, and returns that error.
+func (e *Exporter) ExportEach(v any, fn func(index int, code string) error) error {
+	val := reflect.ValueOf(v)
+	if val.Kind() == reflect.Map {
+		return e.exportEachEntry(val, fn)
+	}
+
+	if val.Kind() != reflect.Slice && val.Kind() != reflect.Array {
+		return fmt.Errorf("type %T is not a slice, an array or a map", v) //nolint:goerr113
+	}
+
+	for i := 0; i < val.Len(); i++ {
+		code, err := e.Export(val.Index(i).Interface())
+		if err != nil {
+			return newPathError(typeName(val.Type()), IndexStep(i), err)
+		}
+
+		if err := fn(i, code); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// exportEachEntry exports entries of the given map one by one, see ExportEach.
+// Keys are exported first to sort them, values are exported one by one.
+func (e *Exporter) exportEachEntry(val reflect.Value, fn func(index int, code string) error) error {
+	type key struct {
+		val  reflect.Value
+		code string
+	}
+
+	keys := make([]key, 0, val.Len())
+	codes := make(map[string]struct{}, val.Len())
+
+	for _, k := range val.MapKeys() {
+		code, err := e.Export(k.Interface())
+		if err != nil {
+			return fmt.Errorf("cannot export key of (%s): %w", typeName(val.Type()), err)
+		}
+
+		if _, ok := codes[code]; ok || containsNaN(k) {
+			return newPathError(
+				typeName(val.Type()),
+				KeyStep(code),
+				errors.New("key cannot be reproduced in a map literal"), //nolint:goerr113
+			)
+		}
+
+		codes[code] = struct{}{}
+		keys = append(keys, key{val: k, code: code})
+	}
+
+	sort.SliceStable(keys, func(i, j int) bool {
+		return lessKey(keys[i].val, keys[j].val, keys[i].code, keys[j].code)
+	})
+
+	for i, k := range keys {
+		code, err := e.Export(val.MapIndex(k.val).Interface())
+		if err != nil {
+			return newPathError(typeName(val.Type()), KeyStep(k.code), err)
+		}
+
+		if err := fn(i, k.code+": "+code); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// ExportEach exports elements of the given slice, array or map one by one, and passes the results to the callback fn.
+//
+// See Exporter.ExportEach.
+func ExportEach(v any, fn func(index int, code string) error) error {
+	return defaultExporter.ExportEach(v, fn)
+}
+
+// ExportMapEntries exports values of the given map with string keys one by one.
+// The result maps keys of the given map to the exported values, e.g.:
+//
+//	map[string]string{"a": "int(1)", "b": "[]int{int(2)}"}
+//
+// It lets the caller distribute entries across templates or files without splitting the whole literal.
+// Use ExportEach to export entries of maps with other keys.
+func (e *Exporter) ExportMapEntries(m any) (map[string]string, error) {
+	val := reflect.ValueOf(m)
+	if val.Kind() != reflect.Map || val.Type().Key().Kind() != reflect.String {
+		return nil, fmt.Errorf("type %T is not a map with string keys", m) //nolint:goerr113
+	}
+
+	result := make(map[string]string, val.Len())
+	iter := val.MapRange()
+
+	for iter.Next() {
+		code, err := e.Export(iter.Value().Interface())
+		if err != nil {
+			return nil, newPathError(typeName(val.Type()), KeyStep(strconv.Quote(iter.Key().String())), err)
+		}
+
+		result[iter.Key().String()] = code
+	}
+
+	return result, nil
+}
+
+// ExportMapEntries exports values of the given map with string keys one by one.
+//
+// See Exporter.ExportMapEntries.
+func ExportMapEntries(m any) (map[string]string, error) {
+	return defaultExporter.ExportMapEntries(m)
+}
@@ -0,0 +1,154 @@
+// Copyright (c) 2023–present Bartłomiej Krukowski
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is furnished
+// to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package exporter_test
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/gontainer/exporter"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestExportEach(t *testing.T) {
+	t.Parallel()
+
+	t.Run("OK", func(t *testing.T) {
+		t.Parallel()
+
+		for _, input := range []any{[]any{1, "2", []int{3}}, [3]any{1, "2", []int{3}}} {
+			var results []string
+
+			err := exporter.ExportEach(input, func(index int, code string) error {
+				results = append(results, fmt.Sprintf("%d: %s", index, code))
+
+				return nil
+			})
+
+			assert.NoError(t, err)
+			assert.Equal(t, []string{`0: int(1)`, `1: "2"`, `2: []int{int(3)}`}, results)
+		}
+	})
+
+	t.Run("Not a slice", func(t *testing.T) {
+		t.Parallel()
+
+		err := exporter.ExportEach(5, func(int, string) error {
+			return nil
+		})
+		assert.EqualError(t, err, "type int is not a slice, an array or a map")
+	})
+
+	t.Run("Map", func(t *testing.T) {
+		t.Parallel()
+
+		var results []string
+
+		err := exporter.ExportEach(map[int]any{10: "a", 2: []int{3}, 1: nil}, func(index int, code string) error {
+			results = append(results, fmt.Sprintf("%d: %s", index, code))
+
+			return nil
+		})
+
+		assert.NoError(t, err)
+		assert.Equal(t, []string{`0: int(1): nil`, `1: int(2): []int{int(3)}`, `2: int(10): "a"`}, results)
+	})
+
+	t.Run("Unsupported map value", func(t *testing.T) {
+		t.Parallel()
+
+		err := exporter.ExportEach(map[string]any{"a": 1, "b": make(chan int)}, func(int, string) error {
+			return nil
+		})
+		assert.EqualError(t, err, `cannot export (map[string]interface{})["b"]: type chan int is not supported`)
+	})
+
+	t.Run("Colliding map keys", func(t *testing.T) {
+		t.Parallel()
+
+		e := exporter.New(exporter.WithFloatPrecision(1))
+		err := e.ExportEach(map[float64]int{1.01: 1, 1.02: 2}, func(int, string) error {
+			return nil
+		})
+		assert.EqualError(t, err, `cannot export (map[float64]int)[float64(1)]: key cannot be reproduced in a map literal`)
+	})
+
+	t.Run("Unsupported element", func(t *testing.T) {
+		t.Parallel()
+
+		var results []string
+
+		err := exporter.ExportEach([]any{1, make(chan int), 3}, func(_ int, code string) error {
+			results = append(results, code)
+
+			return nil
+		})
+		assert.EqualError(t, err, "cannot export ([]interface{})[1]: type chan int is not supported")
+		assert.Equal(t, []string{"int(1)"}, results)
+	})
+
+	t.Run("Callback error", func(t *testing.T) {
+		t.Parallel()
+
+		stop := errors.New("stop")
+		calls := 0
+
+		err := exporter.ExportEach([]int{1, 2, 3}, func(int, string) error {
+			calls++
+
+			return stop
+		})
+		assert.ErrorIs(t, err, stop)
+		assert.Equal(t, 1, calls)
+	})
+}
+
+func TestExportMapEntries(t *testing.T) {
+	t.Parallel()
+
+	t.Run("OK", func(t *testing.T) {
+		t.Parallel()
+
+		entries, err := exporter.ExportMapEntries(map[string]any{"a": 1, "b": []int{2}, "c": nil})
+		require.NoError(t, err)
+		assert.Equal(t, map[string]string{"a": "int(1)", "b": "[]int{int(2)}", "c": "nil"}, entries)
+
+		entries, err = exporter.ExportMapEntries(map[string]int(nil))
+		require.NoError(t, err)
+		assert.Empty(t, entries)
+	})
+
+	t.Run("Not a map", func(t *testing.T) {
+		t.Parallel()
+
+		_, err := exporter.ExportMapEntries(map[int]string{1: "a"})
+		assert.EqualError(t, err, "type map[int]string is not a map with string keys")
+	})
+
+	t.Run("Unsupported value", func(t *testing.T) {
+		t.Parallel()
+
+		_, err := exporter.ExportMapEntries(map[string]any{"a": make(chan int)})
+		assert.EqualError(t, err, `cannot export (map[string]interface{})["a"]: type chan int is not supported`)
+	})
+}
@@ -0,0 +1,198 @@
+// Copyright (c) 2023–present Bartłomiej Krukowski
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is furnished
+// to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package exporter
+
+import (
+	"go/scanner"
+	"go/token"
+	"reflect"
+	"strconv"
+	"strings"
+)
+
+// WithScalarElision omits conversions of numbers, when the enclosing composite literal fixes their types, e.g.:
+//
+//	[]int{1, 2}                      // instead of []int{int(1), int(2)}
+//	map[string]float64{"pi": 3.14}   // instead of map[string]float64{"pi": float64(3.14)}
+//	[]interface{}{int(1), int8(2)}   // conversions are required to keep the types of values in interfaces
+func WithScalarElision() Option {
+	return func(c *config) {
+		c.scalarElision = true
+	}
+}
+
+// WithMinimalConversions keeps conversions of numbers only where they are required to preserve the types,
+// it implies WithScalarElision. Numbers of the default types of untyped constants are exported without conversions
+// in all contexts, since GO infers their types anyway, e.g.:
+//
+//	[]interface{}{1, 1.5, int32(1), float64(1)} // int, float64, int32, float64
+//
+// Integral floats keep their conversions, since 1 is an untyped integer constant.
+func WithMinimalConversions() Option {
+	return func(c *config) {
+		c.scalarElision = true
+		c.minimalNumbers = true
+	}
+}
+
+// WithCompositeElision omits types of composite literals that are elements, keys or values
+// of enclosing composite literals of the same types, e.g.:
+//
+//	map[Point]Line{{X: 1, Y: 2}: {From: {X: 3, Y: 4}}} // instead of map[Point]Line{Point{X: 1, Y: 2}: Line{...}}
+//	[][]int{{int(1)}, {int(2)}}                         // instead of [][]int{[]int{int(1)}, []int{int(2)}}
+//
+// Fields of structs always keep their types, since GO does not allow to elide them.
+// Elements of slices built from chunks keep their types too, see WithLiteralLimits.
+func WithCompositeElision() Option {
+	return func(c *config) {
+		c.compositeElision = true
+	}
+}
+
+// hasDefaultType reports whether the given literal of a number of the given kind
+// has the same type without a conversion, see WithMinimalConversions.
+func hasDefaultType(k reflect.Kind, literal string) bool {
+	digits := strings.TrimPrefix(literal, "-")
+	if digits == "" || digits[0] < '0' || digits[0] > '9' || !isNumberLiteral(literal) {
+		return false
+	}
+
+	switch k { //nolint:exhaustive
+	case reflect.Int:
+		return !strings.ContainsAny(literal, ".eE")
+	case reflect.Float64:
+		return strings.ContainsAny(literal, ".eE")
+	}
+
+	return false
+}
+
+// scalarElision configures elideScalar, see WithScalarElision.
+type scalarElision struct {
+	enabled bool
+	// names are the names of conversions of numbers, see WithNumberTypeNames
+	names numberNames
+}
+
+// elideScalar removes the conversion from the given exported number stored in a variable of the given static type,
+// see WithScalarElision.
+func elideScalar(e scalarElision, static reflect.Type, code string) string {
+	if !e.enabled || static.PkgPath() != "" || !isNumberKind(static.Kind()) {
+		return code
+	}
+
+	prefix := e.names.name(static.Kind()) + "("
+	if !strings.HasPrefix(code, prefix) || !strings.HasSuffix(code, ")") {
+		return code
+	}
+
+	literal := code[len(prefix) : len(code)-1]
+	if !isNumberLiteral(literal) {
+		return code
+	}
+
+	return literal
+}
+
+func isNumberKind(k reflect.Kind) bool {
+	switch k { //nolint:exhaustive
+	case
+		reflect.Int,
+		reflect.Int8,
+		reflect.Int16,
+		reflect.Int32,
+		reflect.Int64,
+		reflect.Uint,
+		reflect.Uint8,
+		reflect.Uint16,
+		reflect.Uint32,
+		reflect.Uint64,
+		reflect.Uintptr,
+		reflect.Float32,
+		reflect.Float64:
+		return true
+	}
+
+	return false
+}
+
+func isNumberLiteral(s string) bool {
+	if _, err := strconv.ParseInt(s, 0, 64); err == nil {
+		return true
+	}
+
+	if _, err := strconv.ParseUint(s, 0, 64); err == nil {
+		return true
+	}
+
+	_, err := strconv.ParseFloat(s, 64)
+
+	return err == nil
+}
+
+// elideComposite removes the type from the given exported composite literal that is an element of
+// another composite literal of the given static element type, see WithCompositeElision.
+func elideComposite(enabled bool, static reflect.Type, code string) string {
+	if !enabled {
+		return code
+	}
+
+	prefix := typeName(static)
+	if !strings.HasPrefix(code, prefix+"{") || !strings.HasSuffix(code, "}") || !isSingleBlock(code[len(prefix):]) {
+		return code
+	}
+
+	return code[len(prefix):]
+}
+
+// isSingleBlock reports whether the given code consists of a single block in braces, e.g. "{1, 2}",
+// but not "{1}.String()" or "{1}[0]".
+func isSingleBlock(code string) bool {
+	fset := token.NewFileSet()
+	file := fset.AddFile("", fset.Base(), len(code))
+
+	var s scanner.Scanner
+	s.Init(file, []byte(code), nil, 0)
+
+	depth := 0
+
+	for {
+		_, tok, lit := s.Scan()
+
+		switch tok { //nolint:exhaustive
+		case token.LBRACE:
+			depth++
+		case token.RBRACE:
+			depth--
+			if depth == 0 {
+				_, next, lit := s.Scan()
+
+				return next == token.EOF || (next == token.SEMICOLON && lit == "\n")
+			}
+		case token.EOF, token.ILLEGAL:
+			return false
+		case token.SEMICOLON:
+			if lit == "\n" {
+				return false
+			}
+		}
+	}
+}
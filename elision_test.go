@@ -0,0 +1,210 @@
+// Copyright (c) 2023–present Bartłomiej Krukowski
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is furnished
+// to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package exporter_test
+
+import (
+	"testing"
+
+	"github.com/gontainer/exporter"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestWithScalarElision(t *testing.T) {
+	t.Parallel()
+
+	scenarios := []struct {
+		name   string
+		input  any
+		output string
+	}{
+		{
+			name:   "Slice",
+			input:  []int{1, -2},
+			output: `[]int{1, -2}`,
+		},
+		{
+			name:   "Array",
+			input:  [2]float64{1.5, 2},
+			output: `[2]float64{1.5, 2}`,
+		},
+		{
+			name:   "Interfaces",
+			input:  []any{1, int8(2), 1.5},
+			output: `[]interface{}{int(1), int8(2), float64(1.5)}`,
+		},
+		{
+			name:   "Map",
+			input:  map[uint8]float32{1: 0.5},
+			output: `map[uint8]float32{1: 0.5}`,
+		},
+		{
+			name:   "Nested",
+			input:  [][]uint{{1}, {2, 3}},
+			output: `[][]uint{[]uint{1}, []uint{2, 3}}`,
+		},
+		{
+			name:   "Struct",
+			input:  StructServer{Host: "localhost", Port: 80},
+			output: `exporter_test.StructServer{Host: "localhost", Port: 80}`,
+		},
+		{
+			name:   "Top-level values",
+			input:  5,
+			output: `int(5)`,
+		},
+	}
+
+	for _, s := range scenarios {
+		s := s
+
+		t.Run(s.name, func(t *testing.T) {
+			t.Parallel()
+
+			assert.Equal(t, s.output, exporter.New(exporter.WithScalarElision()).MustExport(s.input))
+		})
+	}
+}
+
+func TestWithMinimalConversions(t *testing.T) {
+	t.Parallel()
+
+	scenarios := []struct {
+		name   string
+		input  any
+		output string
+	}{
+		{
+			name:   "Int",
+			input:  -5,
+			output: `-5`,
+		},
+		{
+			name:   "Float64",
+			input:  1.5,
+			output: `1.5`,
+		},
+		{
+			name:   "Integral float64",
+			input:  float64(2),
+			output: `float64(2)`,
+		},
+		{
+			name:   "Negative float64",
+			input:  -0.25,
+			output: `-0.25`,
+		},
+		{
+			name:   "Other types",
+			input:  []any{int32(1), float32(1.5), uint(1), int64(1)},
+			output: `[]interface{}{int32(1), float32(1.5), uint(1), int64(1)}`,
+		},
+		{
+			name:   "Interface",
+			input:  []any{1, 1.5, float64(1)},
+			output: `[]interface{}{1, 1.5, float64(1)}`,
+		},
+		{
+			name:   "Typed context",
+			input:  map[int32][]float64{1: {1, 1.5}},
+			output: `map[int32][]float64{1: []float64{1, 1.5}}`,
+		},
+	}
+
+	e := exporter.New(exporter.WithMinimalConversions())
+
+	for _, s := range scenarios {
+		s := s
+
+		t.Run(s.name, func(t *testing.T) {
+			t.Parallel()
+
+			assert.Equal(t, s.output, e.MustExport(s.input))
+		})
+	}
+
+	t.Run("JSON", func(t *testing.T) {
+		t.Parallel()
+
+		e := exporter.New(exporter.WithMinimalConversions(), exporter.WithBackend(exporter.JSONBackend()))
+		assert.Equal(t, `[1,1.5,2]`, e.MustExport([]any{1, 1.5, float64(2)}))
+	})
+}
+
+type (
+	elisionPoint struct{ X, Y int }
+	elisionLine  struct{ From, To elisionPoint }
+)
+
+func TestWithCompositeElision(t *testing.T) {
+	t.Parallel()
+
+	scenarios := []struct {
+		name    string
+		input   any
+		output  string
+		options []exporter.Option
+	}{
+		{
+			name:  "Maps",
+			input: map[elisionPoint]elisionLine{{X: 1, Y: 2}: {From: elisionPoint{X: 3}}},
+			output: `map[exporter_test.elisionPoint]exporter_test.elisionLine{{X: int(1), Y: int(2)}: ` +
+				`{From: exporter_test.elisionPoint{X: int(3)}}}`,
+		},
+		{
+			name:   "Nested slices",
+			input:  [][]int{{1}, nil, {}},
+			output: `[][]int{{int(1)}, ([]int)(nil), make([]int, 0)}`,
+		},
+		{
+			name:   "Arrays",
+			input:  [1][2]elisionPoint{{{X: 1}}},
+			output: `[1][2]exporter_test.elisionPoint{{{X: int(1)}, {}}}`,
+		},
+		{
+			name:   "Interfaces",
+			input:  []any{[]int{1}, map[string]int{}},
+			output: `[]interface{}{[]int{int(1)}, map[string]int{}}`,
+		},
+		{
+			name:    "Chunked slices",
+			input:   [][]int{{1}, {2}},
+			output:  `func() [][]int { v := make([][]int, 0, 2); v = append(v, []int{1}); v = append(v, []int{2}); return v }()`,
+			options: []exporter.Option{exporter.WithLiteralLimits(1, 0), exporter.WithScalarElision()},
+		},
+		{
+			name:    "Chunked arrays",
+			input:   [2][]int{{1}, {2}},
+			output:  `func() [2][]int { var v [2][]int; copy(v[0:], [][]int{{1}}); copy(v[1:], [][]int{{2}}); return v }()`,
+			options: []exporter.Option{exporter.WithLiteralLimits(1, 0), exporter.WithScalarElision()},
+		},
+	}
+
+	for _, s := range scenarios {
+		s := s
+
+		t.Run(s.name, func(t *testing.T) {
+			t.Parallel()
+
+			e := exporter.New(append([]exporter.Option{exporter.WithCompositeElision()}, s.options...)...)
+			assert.Equal(t, s.output, e.MustExport(s.input))
+		})
+	}
+}
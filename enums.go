@@ -0,0 +1,95 @@
+// Copyright (c) 2023–present Bartłomiej Krukowski
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is furnished
+// to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package exporter
+
+import (
+	"go/token"
+	"reflect"
+	"strings"
+)
+
+// WithEnumKeys makes maps render keys of named integer types as names of the given constants, e.g.:
+//
+//	exporter.WithEnumKeys(map[any]string{
+//		fixtures.StatusActive:  "StatusActive",
+//		fixtures.StatusBlocked: "StatusBlocked",
+//	})
+//
+// exports lookup tables as map[fixtures.Status]string{fixtures.StatusActive: "active", fixtures.Status(7): "unknown"}.
+// Names are qualified by the name of the package of the type, so constants must be declared in the same package
+// as their type. Keys are matched by their types and values, so constants of different types may share values.
+// Keys without names are exported as usual. Values of other types, and names that are not identifiers, are ignored.
+// Only the GO backend renders names, see WithBackend.
+func WithEnumKeys(names map[any]string) Option {
+	return func(c *config) {
+		c.enumKeys = make(enumNames, len(names))
+
+		for v, name := range names {
+			if t := reflect.TypeOf(v); t != nil && isEnumType(t) && token.IsIdentifier(name) {
+				c.enumKeys[v] = name
+			}
+		}
+	}
+}
+
+// enumNames maps values of named integer types to the names of their constants, see WithEnumKeys.
+type enumNames map[any]string
+
+// name returns the qualified name of the constant of the given value.
+func (e enumNames) name(v reflect.Value) (string, bool) {
+	if len(e) == 0 || !v.IsValid() {
+		return "", false
+	}
+
+	if v.Kind() == reflect.Interface {
+		if v.IsNil() {
+			return "", false
+		}
+
+		v = v.Elem()
+	}
+
+	if !isEnumType(v.Type()) {
+		return "", false
+	}
+
+	name, ok := e[v.Interface()]
+	if !ok {
+		return "", false
+	}
+
+	return strings.SplitN(v.Type().String(), ".", 2)[0] + "." + name, true
+}
+
+func isEnumType(t reflect.Type) bool {
+	if t.PkgPath() == "" || t.Name() == "" {
+		return false
+	}
+
+	//nolint:exhaustive
+	switch t.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return true
+	}
+
+	return false
+}
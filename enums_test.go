@@ -0,0 +1,94 @@
+// Copyright (c) 2023–present Bartłomiej Krukowski
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is furnished
+// to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package exporter_test
+
+import (
+	"testing"
+
+	"github.com/gontainer/exporter"
+	"github.com/stretchr/testify/assert"
+)
+
+type enumStatus int
+
+const (
+	statusActive enumStatus = iota + 1
+	statusBlocked
+)
+
+type enumLevel uint8
+
+const (
+	levelLow enumLevel = 1
+)
+
+func TestWithEnumKeys(t *testing.T) {
+	t.Parallel()
+
+	e := exporter.New(exporter.WithEnumKeys(map[any]string{
+		statusActive:  "statusActive",
+		statusBlocked: "statusBlocked",
+		levelLow:      "levelLow",
+		1:             "ignoredInt",
+		enumLevel(2):  "invalid-name",
+	}))
+
+	scenarios := []struct {
+		name   string
+		input  any
+		output string
+	}{
+		{
+			name:  "Lookup table",
+			input: map[enumStatus]string{statusBlocked: "blocked", statusActive: "active", 7: "unknown"},
+			output: `map[exporter_test.enumStatus]string{exporter_test.statusActive: "active", ` +
+				`exporter_test.statusBlocked: "blocked", exporter_test.enumStatus(7): "unknown"}`,
+		},
+		{
+			name:  "Types with the same values",
+			input: map[any]int{statusActive: 1, levelLow: 2},
+			output: `map[interface{}]int{exporter_test.levelLow: int(2), ` +
+				`exporter_test.statusActive: int(1)}`,
+		},
+		{
+			name:   "Ignored names",
+			input:  map[any]int{1: 1, enumLevel(2): 2},
+			output: `map[interface{}]int{exporter_test.enumLevel(2): int(2), int(1): int(1)}`,
+		},
+		{
+			name:   "Values",
+			input:  []enumStatus{statusActive},
+			output: `[]exporter_test.enumStatus{exporter_test.enumStatus(1)}`,
+		},
+	}
+
+	for _, s := range scenarios {
+		s := s
+
+		t.Run(s.name, func(t *testing.T) {
+			t.Parallel()
+
+			output, err := e.Export(s.input)
+			assert.NoError(t, err)
+			assert.Equal(t, s.output, output)
+		})
+	}
+}
@@ -0,0 +1,93 @@
+// Copyright (c) 2023–present Bartłomiej Krukowski
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is furnished
+// to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package exporter
+
+import (
+	"fmt"
+	"sort"
+)
+
+// ExportEqualityTest generates a test file that verifies whether the variable varName generated by ExportFile
+// equals the given value exported again, e.g.:
+//
+//	func TestGenerated_Users(t *testing.T) {
+//		expected := []fixtures.User{...}
+//		if !reflect.DeepEqual(Users, expected) {
+//			t.Errorf("Users does not equal the exported value")
+//		}
+//	}
+//
+// Generate both files at the same time, so the test detects regressions of the exporter,
+// when the fixture is regenerated later.
+//
+// See Exporter.ExportEqualityTest.
+func ExportEqualityTest(pkg string, varName string, v any) ([]byte, error) {
+	return defaultExporter.ExportEqualityTest(pkg, varName, v)
+}
+
+// ExportEqualityTest generates a test file for the variable generated by Exporter.ExportFile,
+// see the function ExportEqualityTest.
+func (e *Exporter) ExportEqualityTest(pkg string, varName string, v any) ([]byte, error) {
+	pkg, varName, err := e.validateFile("ExportEqualityTest", pkg, varName, v)
+	if err != nil {
+		return nil, err
+	}
+
+	code, err := e.Export(v)
+	if err != nil {
+		return nil, err
+	}
+
+	imports, err := findImports(v, e.config, code)
+	if err != nil {
+		return nil, err
+	}
+
+	imports = mergeImports(imports, "reflect", "testing")
+
+	decl := fmt.Sprintf(
+		"func TestGenerated_%s(t *testing.T) {\nexpected := %s\nif !reflect.DeepEqual(%s, expected) {\n"+
+			"t.Errorf(%q)\n}\n}\n",
+		varName,
+		code,
+		e.reference(varName),
+		varName+" does not equal the exported value",
+	)
+
+	return e.renderFile(pkg, imports, decl)
+}
+
+// mergeImports adds the given paths to the given imports, and sorts the result.
+func mergeImports(imports []string, paths ...string) []string {
+	unique := make(map[string]struct{}, len(imports)+len(paths))
+	result := make([]string, 0, len(imports)+len(paths))
+
+	for _, p := range append(imports, paths...) {
+		if _, ok := unique[p]; !ok {
+			unique[p] = struct{}{}
+			result = append(result, p)
+		}
+	}
+
+	sort.Strings(result)
+
+	return result
+}
@@ -0,0 +1,75 @@
+// Copyright (c) 2023–present Bartłomiej Krukowski
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is furnished
+// to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package exporter_test
+
+import (
+	"testing"
+
+	"github.com/gontainer/exporter"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestExportEqualityTest(t *testing.T) {
+	t.Parallel()
+
+	t.Run("OK", func(t *testing.T) {
+		t.Parallel()
+
+		code, err := exporter.ExportEqualityTest("fixtures", "Servers", []StructServer{{Port: 80}})
+		require.NoError(t, err)
+		assert.Equal(
+			t,
+			`// Code generated by github.com/gontainer/exporter. DO NOT EDIT.
+// Format: 2
+
+package fixtures
+
+import (
+	"github.com/gontainer/exporter_test"
+	"reflect"
+	"testing"
+)
+
+func TestGenerated_Servers(t *testing.T) {
+	expected := []exporter_test.StructServer{exporter_test.StructServer{Port: int(80)}}
+	if !reflect.DeepEqual(Servers, expected) {
+		t.Errorf("Servers does not equal the exported value")
+	}
+}
+`,
+			withoutChecksum(t, code),
+		)
+	})
+
+	t.Run("Errors", func(t *testing.T) {
+		t.Parallel()
+
+		_, err := exporter.ExportEqualityTest("fixtures", "my-var", 5)
+		assert.EqualError(t, err, `"my-var" is not a valid identifier`)
+
+		_, err = exporter.New(exporter.WithBackend(exporter.JSONBackend())).ExportEqualityTest("fixtures", "Var", 5)
+		assert.EqualError(t, err, `ExportEqualityTest requires the GO backend`)
+
+		_, err = exporter.ExportEqualityTest("fixtures", "Var", make(chan int))
+		assert.EqualError(t, err, `type chan int is not supported`)
+	})
+}
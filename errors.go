@@ -0,0 +1,219 @@
+// Copyright (c) 2023–present Bartłomiej Krukowski
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is furnished
+// to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package exporter
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"reflect"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// WithSentinelErrors registers sentinel errors declared in the package of the given import path.
+// Keys of the map are qualified names of the errors, e.g.:
+//
+//	exporter.WithSentinelErrors("github.com/me/store", map[string]error{
+//		"store.ErrNotFound": store.ErrNotFound,
+//	})
+//
+// Registered errors are exported by their names, so errors.Is works with the generated code as expected.
+// Sentinel errors from the packages io, os and context are registered by default.
+func WithSentinelErrors(path string, errs map[string]error) Option {
+	return func(c *config) {
+		names := make([]string, 0, len(errs))
+		for name := range errs {
+			names = append(names, name)
+		}
+
+		sort.Strings(names)
+
+		// registered errors take precedence over the ones registered previously
+		sentinels := make([]sentinelError, 0, len(names)+len(c.sentinels))
+		for _, name := range names {
+			sentinels = append(sentinels, sentinelError{name: name, path: path, err: errs[name]})
+		}
+
+		c.sentinels = append(sentinels, c.sentinels...)
+	}
+}
+
+//nolint:gochecknoglobals
+var errorType = reflect.TypeOf((*error)(nil)).Elem()
+
+type sentinelError struct {
+	name string
+	path string
+	err  error
+}
+
+// defaultSentinels contains sentinel errors of the standard library that are exported by their names.
+//
+//nolint:gochecknoglobals
+var defaultSentinels = []sentinelError{
+	{name: "io.EOF", path: "io", err: io.EOF},
+	{name: "io.ErrUnexpectedEOF", path: "io", err: io.ErrUnexpectedEOF},
+	{name: "io.ErrShortWrite", path: "io", err: io.ErrShortWrite},
+	{name: "io.ErrClosedPipe", path: "io", err: io.ErrClosedPipe},
+	{name: "os.ErrNotExist", path: "os", err: os.ErrNotExist},
+	{name: "os.ErrExist", path: "os", err: os.ErrExist},
+	{name: "os.ErrPermission", path: "os", err: os.ErrPermission},
+	{name: "os.ErrClosed", path: "os", err: os.ErrClosed},
+	{name: "context.Canceled", path: "context", err: context.Canceled},
+	{name: "context.DeadlineExceeded", path: "context", err: context.DeadlineExceeded},
+}
+
+// errorExporter exports errors created by errors.New, fmt.Errorf and errors.Join, e.g.:
+//
+//	fmt.Errorf("cannot read config: %w", errors.Join(io.EOF, errors.New("invalid syntax")))
+//
+// The wrap chain is reconstructed, and sentinel errors are exported by their names,
+// so errors.Is and errors.As work with the generated code the same way they work with the exported value.
+// See WithSentinelErrors.
+type errorExporter struct {
+	exporter  exporter
+	sentinels []sentinelError
+}
+
+func (e errorExporter) export(v any) (string, error) {
+	err := v.(error) //nolint:forcetypeassert
+
+	if name, ok := e.sentinel(err); ok {
+		return name, nil
+	}
+
+	switch reflect.TypeOf(err).String() {
+	case "*errors.errorString":
+		return "errors.New(" + strconv.Quote(err.Error()) + ")", nil
+
+	case "*errors.joinError":
+		// errors.Join has been added in GO 1.20, so the type is not referenced directly
+		errs := err.(interface{ Unwrap() []error }).Unwrap() //nolint:errorlint,forcetypeassert
+		args, exportErr := e.exportErrors(errs)
+		if exportErr != nil {
+			return "", exportErr
+		}
+
+		return "errors.Join(" + strings.Join(args, ", ") + ")", nil
+
+	case "*fmt.wrapErrors":
+		return e.exportWrapped(err, err.(interface{ Unwrap() []error }).Unwrap()) //nolint:errorlint,forcetypeassert
+	}
+
+	// *fmt.wrapError
+	return e.exportWrapped(err, []error{errors.Unwrap(err)})
+}
+
+// exportWrapped exports errors created by fmt.Errorf with the verb %w.
+// The format is reconstructed by finding messages of the wrapped errors in the message of the given error.
+func (e errorExporter) exportWrapped(err error, wrapped []error) (string, error) {
+	msg := err.Error()
+	format := ""
+
+	for _, w := range wrapped {
+		if w == nil {
+			return "", fmt.Errorf("cannot reconstruct the format of the error %q", msg) //nolint:goerr113
+		}
+
+		i := strings.Index(msg, w.Error())
+		if i < 0 {
+			return "", fmt.Errorf("cannot reconstruct the format of the error %q", msg) //nolint:goerr113
+		}
+
+		format += strings.ReplaceAll(msg[:i], "%", "%%") + "%w"
+		msg = msg[i+len(w.Error()):]
+	}
+
+	format += strings.ReplaceAll(msg, "%", "%%")
+
+	args, err := e.exportErrors(wrapped)
+	if err != nil {
+		return "", err
+	}
+
+	return "fmt.Errorf(" + strconv.Quote(format) + ", " + strings.Join(args, ", ") + ")", nil
+}
+
+func (e errorExporter) exportErrors(errs []error) ([]string, error) {
+	result := make([]string, len(errs))
+
+	for i, err := range errs {
+		code, exportErr := e.exporter.export(err)
+		if exportErr != nil {
+			return nil, fmt.Errorf("cannot export the wrapped error %q: %w", err.Error(), exportErr)
+		}
+
+		result[i] = code
+	}
+
+	return result, nil
+}
+
+func (e errorExporter) sentinel(err error) (string, bool) {
+	// errors of incomparable types, e.g. slices, cannot be compared by ==
+	if !reflect.TypeOf(err).Comparable() {
+		return "", false
+	}
+
+	for _, s := range e.sentinels {
+		if s.err == err { //nolint:errorlint,goerr113
+			return s.name, true
+		}
+	}
+
+	return "", false
+}
+
+func (e errorExporter) supports(v any) bool {
+	err, ok := v.(error)
+	if !ok {
+		return false
+	}
+
+	if _, ok := e.sentinel(err); ok {
+		return true
+	}
+
+	switch reflect.TypeOf(err).String() {
+	case "*errors.errorString", "*errors.joinError", "*fmt.wrapError", "*fmt.wrapErrors":
+		return !reflect.ValueOf(err).IsNil()
+	}
+
+	return false
+}
+
+// jsonErrorExporter exports errors supported by errorExporter as their messages, see JSONBackend.
+type jsonErrorExporter struct {
+	backend Backend
+	errors  errorExporter
+}
+
+func (e jsonErrorExporter) export(v any) (string, error) {
+	return e.backend.renderString(v.(error).Error()), nil //nolint:forcetypeassert
+}
+
+func (e jsonErrorExporter) supports(v any) bool {
+	return e.errors.supports(v)
+}
@@ -0,0 +1,60 @@
+// Copyright (c) 2023–present Bartłomiej Krukowski
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is furnished
+// to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+//go:build go1.20
+// +build go1.20
+
+package exporter_test
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"testing"
+
+	"github.com/gontainer/exporter"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestExport_errorsGo120(t *testing.T) {
+	t.Parallel()
+
+	t.Run("errors.Join", func(t *testing.T) {
+		t.Parallel()
+
+		err := errors.Join(io.EOF, fmt.Errorf("line %d: %w", 5, errors.New("invalid syntax")))
+		assert.Equal(
+			t,
+			`errors.Join(io.EOF, fmt.Errorf("line 5: %w", errors.New("invalid syntax")))`,
+			exporter.MustExport(err),
+		)
+	})
+
+	t.Run("Multiple %w", func(t *testing.T) {
+		t.Parallel()
+
+		err := fmt.Errorf("%w (%w)", errors.New("broken pipe"), io.ErrClosedPipe)
+		assert.Equal(
+			t,
+			`fmt.Errorf("%w (%w)", errors.New("broken pipe"), io.ErrClosedPipe)`,
+			exporter.MustExport(err),
+		)
+	})
+}
@@ -0,0 +1,162 @@
+// Copyright (c) 2023–present Bartłomiej Krukowski
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is furnished
+// to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package exporter_test
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"testing"
+
+	"github.com/gontainer/exporter"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var errErrorsNotFound = errors.New("not found") //nolint:gochecknoglobals
+
+type errorsCustom struct {
+	Code int
+}
+
+func (e errorsCustom) Error() string {
+	return fmt.Sprintf("code %d", e.Code)
+}
+
+func TestExport_errors(t *testing.T) {
+	t.Parallel()
+
+	//nolint:exhaustruct,goerr113
+	scenarios := []struct {
+		name    string
+		input   any
+		output  string
+		error   string
+		options []exporter.Option
+	}{
+		{
+			name:   "errors.New",
+			input:  errors.New(`invalid "syntax"`),
+			output: `errors.New("invalid \"syntax\"")`,
+		},
+		{
+			name:   "Sentinel",
+			input:  io.EOF,
+			output: `io.EOF`,
+		},
+		{
+			name:   "context.DeadlineExceeded",
+			input:  context.DeadlineExceeded,
+			output: `context.DeadlineExceeded`,
+		},
+		{
+			name:   "Wrapped error",
+			input:  fmt.Errorf("cannot read %d%% of the file: %w", 50, io.ErrUnexpectedEOF),
+			output: `fmt.Errorf("cannot read 50%% of the file: %w", io.ErrUnexpectedEOF)`,
+		},
+		{
+			name:   "Wrapped twice",
+			input:  fmt.Errorf("config: %w", fmt.Errorf("parse: %w", errors.New("100% broken"))),
+			output: `fmt.Errorf("config: %w", fmt.Errorf("parse: %w", errors.New("100% broken")))`,
+		},
+		{
+			name:   "Registered sentinel",
+			input:  fmt.Errorf("user: %w", errErrorsNotFound),
+			output: `fmt.Errorf("user: %w", store.ErrNotFound)`,
+			options: []exporter.Option{
+				exporter.WithSentinelErrors("example.com/store", map[string]error{"store.ErrNotFound": errErrorsNotFound}),
+			},
+		},
+		{
+			name:   "Error field",
+			input:  struct{ Err error }{Err: io.EOF},
+			output: `struct { Err error }{Err: error(io.EOF)}`,
+		},
+		{
+			name:   "Nil error field",
+			input:  struct{ Err error }{Err: nil},
+			output: `struct { Err error }{}`,
+		},
+		{
+			name:   "Slice of errors",
+			input:  []error{io.EOF, errors.New("x")},
+			output: `[]error{io.EOF, errors.New("x")}`,
+		},
+		{
+			name:   "Map of errors",
+			input:  map[string]error{"eof": io.EOF, "none": nil, "x": errors.New("x")},
+			output: `map[string]error{"eof": io.EOF, "none": nil, "x": errors.New("x")}`,
+		},
+		{
+			name:   "Custom error",
+			input:  errorsCustom{Code: 5},
+			output: `exporter_test.errorsCustom{Code: int(5)}`,
+		},
+		{
+			name:   "Wrapped pointer error",
+			input:  fmt.Errorf("request: %w", &errorsCustom{Code: 5}),
+			output: `fmt.Errorf("request: %w", &exporter_test.errorsCustom{Code: int(5)})`,
+		},
+	}
+
+	for _, s := range scenarios {
+		s := s
+
+		t.Run(s.name, func(t *testing.T) {
+			t.Parallel()
+
+			output, err := exporter.New(s.options...).Export(s.input)
+			if s.error != "" {
+				assert.EqualError(t, err, s.error)
+				assert.Empty(t, output)
+
+				return
+			}
+
+			require.NoError(t, err)
+			assert.Equal(t, s.output, output)
+		})
+	}
+}
+
+func TestExportFile_errors(t *testing.T) {
+	t.Parallel()
+
+	src, err := exporter.ExportFile("fixtures", "Err", fmt.Errorf("cannot open: %w", io.EOF))
+	require.NoError(t, err)
+	assert.Equal(
+		t,
+		`// Code generated by github.com/gontainer/exporter. DO NOT EDIT.
+// Format: 2
+
+package fixtures
+
+import (
+	"fmt"
+	"io"
+)
+
+var Err = fmt.Errorf("cannot open: %w", io.EOF)
+`,
+		withoutChecksum(t, src),
+	)
+}
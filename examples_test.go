@@ -22,6 +22,7 @@ package exporter_test
 
 import (
 	"fmt"
+	"time"
 
 	"github.com/gontainer/exporter"
 )
@@ -139,7 +140,93 @@ func ExampleExport_emptyArray2() {
 }
 
 func ExampleExport_err() {
-	_, err := exporter.Export(struct{}{})
+	_, err := exporter.Export(make(chan int))
 	fmt.Println(err)
-	// Output: type struct {} is not supported
+	// Output: type chan int is not supported
+}
+
+func ExampleWithBackend() {
+	e := exporter.New(exporter.WithBackend(exporter.JSONBackend()))
+	s, _ := e.Export([]any{nil, 1.5, "hello world", []int{1, 2}})
+	fmt.Println(s)
+	// Output: [null,1.5,"hello world",[1,2]]
+}
+
+func ExampleExportEach() {
+	_ = exporter.ExportEach([]any{1, "hello world", []int{2, 3}}, func(index int, code string) error {
+		fmt.Printf("%d: %s\n", index, code)
+
+		return nil
+	})
+	// Output:
+	// 0: int(1)
+	// 1: "hello world"
+	// 2: []int{int(2), int(3)}
+}
+
+func ExampleExport_struct() {
+	type Server struct {
+		Host    string
+		Port    int
+		Aliases []string
+	}
+
+	s, _ := exporter.Export(Server{Host: "localhost", Port: 8080})
+	fmt.Println(s)
+	// Output: exporter_test.Server{Host: "localhost", Port: int(8080)}
+}
+
+func ExampleExport_map() {
+	s, _ := exporter.Export(map[string]any{"pi": 3.14, "e": 2.72, "primes": []int{2, 3, 5}})
+	fmt.Println(s)
+	// Output: map[string]interface{}{"e": float64(2.72), "pi": float64(3.14), "primes": []int{int(2), int(3), int(5)}}
+}
+
+func ExampleWithLenientCasting() {
+	e := exporter.New(exporter.WithLenientCasting())
+	s, _ := e.CastToString([]string{"hello", "world"})
+	fmt.Println(s)
+	// Output: [hello world]
+}
+
+func ExampleExportFile() {
+	code, _ := exporter.ExportFile("fixtures", "Primes", []int{2, 3, 5})
+	fmt.Print(string(code))
+	// Output:
+	// // Code generated by github.com/gontainer/exporter. DO NOT EDIT.
+	// // Checksum: sha256:bbaaf3f17f860baba4285cd7ac275505643ff0e086c501e1dcd4a86cf63eea63
+	// // Format: 2
+	//
+	// package fixtures
+	//
+	// var Primes = []int{int(2), int(3), int(5)}
+}
+
+func ExampleExportFile_imports() {
+	code, _ := exporter.ExportFile("fixtures", "Timeouts", map[string]time.Duration{"read": time.Second})
+	fmt.Print(string(code))
+	// Output:
+	// // Code generated by github.com/gontainer/exporter. DO NOT EDIT.
+	// // Checksum: sha256:3f1c54d0ed275af92a06bf1b5ac260ffcf9e13e1fe1ed48c8566b711ad23b1d7
+	// // Format: 2
+	//
+	// package fixtures
+	//
+	// import (
+	// 	"time"
+	// )
+	//
+	// var Timeouts = map[string]time.Duration{"read": time.Duration(1000000000)}
+}
+
+func ExampleExportPretty() {
+	code, _ := exporter.ExportPretty(map[string][]int{"primes": {2, 3}})
+	fmt.Println(code)
+	// Output:
+	// map[string][]int{
+	// 	"primes": []int{
+	// 		int(2),
+	// 		int(3),
+	// 	},
+	// }
 }
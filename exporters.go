@@ -23,37 +23,239 @@ package exporter
 import (
 	"errors"
 	"fmt"
+	"math"
 	"reflect"
 	"strconv"
-	"strings"
 	"unicode/utf8"
 )
 
 //nolint:gochecknoglobals
 var (
-	defaultExporter     = newDefaultExporter()
-	defaultStringCaster = newChainExporter(
-		&boolExporter{},
-		&nilExporter{},
-		&numberExporter{explicitType: false},
-	)
+	defaultExporter = New()
 )
 
-func newDefaultExporter() exporter { //nolint:ireturn
+// Exporter exports values to a code. Use New to create a configured instance,
+// functions Export and MustExport use the default configuration.
+type Exporter struct {
+	config   config
+	exporter exporter
+	caster   exporter
+}
+
+// New creates a new Exporter configured by the given options.
+func New(opts ...Option) *Exporter {
+	cfg := newConfig()
+	for _, o := range opts {
+		o(&cfg)
+	}
+
+	// options can be applied in any order, see WithQuoteStyle
+	if b, ok := cfg.backend.(goBackend); ok {
+		b.quote = cfg.quoteStyle
+		cfg.backend = b
+	}
+
+	return &Exporter{
+		config:   cfg,
+		exporter: newRootExporter(cfg),
+		caster:   newStringCaster(cfg),
+	}
+}
+
+func newRootExporter(cfg config) exporter { //nolint:ireturn
+	exp := newExporter(cfg)
+	if len(cfg.includedPaths) > 0 || len(cfg.excludedPaths) > 0 {
+		filter, err := newPathFilter(cfg.includedPaths, cfg.excludedPaths, exp)
+		exp = pathFilterExporter{exporter: exp, filter: filter, err: err}
+	}
+
+	if cfg.determinismCheck != nil {
+		exp = determinismExporter{exporter: exp, check: *cfg.determinismCheck}
+	}
+
+	if cfg.formatVersion < FormatVersion1 || cfg.formatVersion > LatestFormatVersion {
+		exp = formatVersionExporter{exporter: exp, version: cfg.formatVersion}
+	}
+
+	return exp
+}
+
+// Export exports input value to a code.
+// The code is rendered in a single line unless WithIndent is used.
+func (e *Exporter) Export(i any) (string, error) {
+	code, err := e.exportCode(i)
+	if err != nil {
+		return "", err
+	}
+
+	// the pretty GO code is formatted by gofmt anyway
+	if e.config.indent != "" {
+		return e.pretty(code, e.config.indent)
+	}
+
+	if _, ok := e.config.backend.(goBackend); ok && e.config.gofmt {
+		return formatExpression(code)
+	}
+
+	return code, nil
+}
+
+// exportCode exports the given value to a single line of code, that is not formatted, see WithAnyAlias.
+func (e *Exporter) exportCode(i any) (string, error) {
+	code, err := e.exporter.export(i)
+	if err != nil {
+		return "", err //nolint:wrapcheck
+	}
+
+	if _, ok := e.config.backend.(goBackend); ok && e.config.anyAlias {
+		code = replaceEmptyInterfaces(code)
+	}
+
+	return code, nil
+}
+
+// ResetCache removes all results stored in the cache, see WithCache.
+func (e *Exporter) ResetCache() {
+	if e.config.cache != nil {
+		e.config.cache.reset()
+	}
+}
+
+// MustExport exports input value to a code.
+//
+// See Exporter.Export.
+func (e *Exporter) MustExport(i any) string {
+	r, err := e.Export(i)
+	if err != nil {
+		e.panicWith("export", i, err)
+	}
+
+	return r
+}
+
+func newExporter(cfg config) exporter { //nolint:ireturn
 	return newDisposableExporter(func() exporter {
 		//nolint:exhaustruct // multiArrayExp -> result -> multiArrayExp
-		multiArrayExp := &multiArray{}
+		multiArrayExp := &multiArray{
+			backend:        cfg.backend,
+			maxElements:    cfg.maxElements,
+			warn:           cfg.warn,
+			sparse:         cfg.sparse,
+			annotations:    cfg.indexComments,
+			integralFloats: cfg.integralFloats,
+			elide:          scalarElision{enabled: cfg.scalarElision, names: cfg.numberNames},
+			nils:           cfg.nilStyles,
+			keyed:          cfg.keyedArrays,
+			elideLiterals:  cfg.compositeElision,
+		}
+		//nolint:exhaustruct // mapExp -> result -> mapExp
+		mapExp := &mapExporter{
+			backend:        cfg.backend,
+			sparse:         cfg.sparse,
+			annotations:    cfg.indexComments,
+			integralFloats: cfg.integralFloats,
+			elide:          scalarElision{enabled: cfg.scalarElision, names: cfg.numberNames},
+			nilAsEmpty:     cfg.nilMapsAsEmpty,
+			nils:           cfg.nilStyles,
+			elideLiterals:  cfg.compositeElision,
+			enums:          cfg.enumKeys,
+			maxElements:    cfg.maxElements,
+			warn:           cfg.warn,
+		}
+		//nolint:exhaustruct // structExp -> result -> structExp
+		structExp := &structExporter{
+			backend:        cfg.backend,
+			integralFloats: cfg.integralFloats,
+			filter:         cfg.fieldFilter,
+			elide:          scalarElision{enabled: cfg.scalarElision, names: cfg.numberNames},
+			packagePath:    cfg.packagePath,
+			pseudonyms:     cfg.pseudonyms,
+		}
+		//nolint:exhaustruct // atomicExp -> result -> atomicExp
+		atomicExp := &atomicExporter{backend: cfg.backend, values: cfg.atomicValues}
 
-		result := newAntiLoopExporter(newChainExporter(
+		chain := newChainExporter(
 			&boolExporter{},
-			&nilExporter{},
-			&numberExporter{explicitType: true},
-			&stringExporter{},
-			&bytesExporter{},
+			&nilExporter{backend: cfg.backend},
+			&numberExporter{
+				explicitType:   true,
+				backend:        cfg.backend,
+				floatPrecision: cfg.floatPrecision,
+				hexLargeUints:  cfg.hexLargeUints,
+				minimal:        cfg.minimalNumbers,
+				noInts:         !cfg.builtIn(BuiltInInt),
+				noFloats:       !cfg.builtIn(BuiltInFloat),
+				names:          cfg.numberNames,
+				ratios:         cfg.floatRatios,
+			},
+			&stringExporter{backend: cfg.backend, invisibles: cfg.escapeInvisibles, pseudonyms: cfg.pseudonyms},
+			&bytesExporter{backend: cfg.backend, disabled: cfg.byteLists, invisibles: cfg.escapeInvisibles},
 			multiArrayExp,
-		))
+			mapExp,
+			atomicExp,
+		)
+
+		//nolint:exhaustruct // iteratorExp -> result -> iteratorExp
+		iteratorExp := &iteratorExporter{maxElements: cfg.iteratorLimit}
+		if cfg.iterators {
+			chain.exporters = append(chain.exporters, iteratorExp)
+		}
+
+		var result exporter = chain
+		if cfg.cache != nil {
+			result = newCacheExporter(cfg.cache, result)
+		}
+
+		if cfg.maxNodes > 0 {
+			result = newNodesExporter(cfg.maxNodes, result)
+		}
+
+		if cfg.maxDepth > 0 {
+			result = newDepthExporter(cfg.maxDepth, result)
+		}
+
+		result = newAntiLoopExporter(cfg.visitedSet(), result)
+
+		if cfg.tracing != nil {
+			result = newTraceExporter(cfg.tracing, result)
+		}
+
+		if cfg.supportedTypes != nil {
+			result = &typeCacheExporter{types: cfg.supportedTypes, next: result}
+		}
 
 		multiArrayExp.exporter = result
+		mapExp.exporter = result
+		structExp.exporter = result
+		atomicExp.exporter = result
+		iteratorExp.exporter = result
+		chain.exporters = append(chain.exporters, cfg.backend.exporters(cfg, result)...)
+		// backend-specific exporters precede structExporter, since they may support particular structs
+		chain.exporters = append(
+			chain.exporters,
+			structExp,
+			&pointerExporter{exporter: result, backend: cfg.backend},
+			&definedExporter{exporter: result, backend: cfg.backend, names: cfg.numberNames},
+		)
+		chain.exporters = withoutBuiltIns(cfg, chain.exporters)
+
+		custom := make([]exporter, 0, len(cfg.customExporters)+len(chain.exporters))
+		for _, c := range cfg.customExporters {
+			custom = append(custom, customExporter{custom: c})
+		}
+
+		// custom exporters precede built-in ones, see Exporter.Register
+		chain.exporters = append(custom, chain.exporters...)
+
+		if cfg.timestamps != nil {
+			// timestampExporter precedes all exporters, since it replaces values supported by them
+			tsExp := &timestampExporter{
+				exporter:   newChainExporter(chain.exporters...),
+				backend:    cfg.backend,
+				timestamps: *cfg.timestamps,
+			}
+			chain.exporters = append([]exporter{tsExp}, chain.exporters...)
+		}
 
 		return result
 	})
@@ -61,19 +263,14 @@ func newDefaultExporter() exporter { //nolint:ireturn
 
 // Export exports input value to a GO code.
 func Export(i any) (string, error) {
-	return defaultExporter.export(i) //nolint:wrapcheck
+	return defaultExporter.Export(i)
 }
 
 // MustExport exports input value to a GO code.
 //
 // See Export.
 func MustExport(i any) string {
-	r, err := Export(i)
-	if err != nil {
-		panic(fmt.Sprintf("cannot export %T to string: %s", i, err.Error()))
-	}
-
-	return r
+	return defaultExporter.MustExport(i)
 }
 
 // CastToString casts input value to a string. This function supports booleans, strings, numeric values and nil-values:
@@ -82,23 +279,14 @@ func MustExport(i any) string {
 //   - any string input results in the output that equals the input
 //   - any nil input returns a "nil" string
 func CastToString(i any) (string, error) {
-	if r, ok := i.(string); ok {
-		return r, nil
-	}
-
-	return defaultStringCaster.export(i)
+	return defaultExporter.CastToString(i)
 }
 
 // MustCastToString casts input value to a string.
 //
 // See CastToString.
 func MustCastToString(i any) string {
-	r, err := CastToString(i)
-	if err != nil {
-		panic(fmt.Sprintf("cannot cast %T to string: %s", i, err.Error()))
-	}
-
-	return r
+	return defaultExporter.MustCastToString(i)
 }
 
 //nolint:inamedparam
@@ -131,11 +319,11 @@ func newStack() *stack {
 	return &r
 }
 
-func (s *stack) pop() {
+func (s *stack) Pop() {
 	*s = (*s)[:len(*s)-1]
 }
 
-func (s *stack) push(v any) error {
+func (s *stack) Push(v any) error {
 	for _, x := range *s {
 		if reflect.DeepEqual(x, v) {
 			return errors.New("unexpected infinite loop") //nolint:goerr113
@@ -148,15 +336,15 @@ func (s *stack) push(v any) error {
 }
 
 type antiLoopExporter struct {
-	stack *stack
+	stack VisitedSet
 	next  exporter
 }
 
 func (a antiLoopExporter) export(v any) (string, error) {
-	if err := a.stack.push(v); err != nil {
-		return "", err
+	if err := a.stack.Push(v); err != nil {
+		return "", err //nolint:wrapcheck
 	}
-	defer a.stack.pop()
+	defer a.stack.Pop()
 
 	return a.next.export(v) //nolint:wrapcheck
 }
@@ -165,8 +353,8 @@ func (a antiLoopExporter) supports(v any) bool {
 	return a.next.supports(v)
 }
 
-func newAntiLoopExporter(next exporter) *antiLoopExporter {
-	return &antiLoopExporter{stack: newStack(), next: next}
+func newAntiLoopExporter(stack VisitedSet, next exporter) *antiLoopExporter {
+	return &antiLoopExporter{stack: stack, next: next}
 }
 
 type chainExporter struct {
@@ -213,10 +401,12 @@ func (boolExporter) supports(v any) bool {
 	return ok
 }
 
-type nilExporter struct{}
+type nilExporter struct {
+	backend Backend
+}
 
-func (nilExporter) export(any) (string, error) {
-	return "nil", nil
+func (n nilExporter) export(any) (string, error) {
+	return n.backend.renderNil(), nil
 }
 
 func (nilExporter) supports(v any) bool {
@@ -224,7 +414,19 @@ func (nilExporter) supports(v any) bool {
 }
 
 type numberExporter struct {
-	explicitType bool
+	explicitType   bool
+	backend        Backend
+	floatPrecision int
+	// hexLargeUints enables the hexadecimal notation of unsigned integers greater than math.MaxInt64,
+	// see WithHexLargeUints
+	hexLargeUints bool
+	// minimal omits conversions of numbers of default types, see WithMinimalConversions
+	minimal bool
+	// noInts and noFloats disable integers and floats respectively, see WithoutBuiltIns
+	noInts, noFloats bool
+	names            numberNames
+	// ratios is the maximal denominator of floats exported as ratios, see WithFloatRatios
+	ratios int
 }
 
 func (n numberExporter) export(v any) (string, error) {
@@ -235,20 +437,64 @@ func (n numberExporter) export(v any) (string, error) {
 	//nolint:exhaustive
 	switch t.Kind() {
 	case reflect.Float32:
-		sv = strconv.FormatFloat(float64(v.(float32)), 'f', -1, 32) //nolint:forcetypeassert
+		sv = formatFloat32(v.(float32), n.floatPrecision) //nolint:forcetypeassert
 	case reflect.Float64:
-		sv = strconv.FormatFloat(v.(float64), 'f', -1, 64) //nolint:forcetypeassert
+		sv = formatFloat(v.(float64), n.floatPrecision, 64) //nolint:forcetypeassert
+	case reflect.Uint, reflect.Uint64:
+		sv = fmt.Sprintf("%d", v)
+
+		if _, ok := n.backend.(goBackend); ok && n.hexLargeUints && reflect.ValueOf(v).Uint() > math.MaxInt64 {
+			sv = fmt.Sprintf("%#x", v)
+		}
 	default:
 		sv = fmt.Sprintf("%d", v)
 	}
 
+	if r, ok := n.ratio(v, sv); ok {
+		return r, nil
+	}
+
+	if _, ok := n.backend.(goBackend); ok && n.minimal && hasDefaultType(t.Kind(), sv) {
+		return sv, nil
+	}
+
 	if n.explicitType {
-		sv = fmt.Sprintf("%s(%s)", t.Kind().String(), sv)
+		if _, ok := n.backend.(goBackend); ok && n.names != nil {
+			return n.names.name(t.Kind()) + "(" + sv + ")", nil
+		}
+
+		return n.backend.renderNumber(t, sv)
 	}
 
 	return sv, nil
 }
 
+// ratio returns the given float as a ratio of integers, if it is shorter than the given literal, see WithFloatRatios.
+func (n numberExporter) ratio(v any, literal string) (string, bool) {
+	t := reflect.TypeOf(v)
+	if _, ok := n.backend.(goBackend); !ok || !n.explicitType || n.floatPrecision >= 0 ||
+		(t.Kind() != reflect.Float32 && t.Kind() != reflect.Float64) {
+		return "", false
+	}
+
+	p, q, ok := floatRatio(reflect.ValueOf(v).Float(), t.Bits(), n.ratios)
+	if !ok {
+		return "", false
+	}
+
+	name := t.Kind().String()
+	if n.names != nil {
+		name = n.names.name(t.Kind())
+	}
+
+	r := fmt.Sprintf("%s(%d)/%s(%d)", name, p, name, q)
+	if len(r) >= len(name)+len("()")+len(literal) {
+		return "", false
+	}
+
+	return r, true
+}
+
 func (n numberExporter) supports(v any) bool {
 	t := reflect.TypeOf(v)
 	if t == nil {
@@ -270,19 +516,31 @@ func (n numberExporter) supports(v any) bool {
 		reflect.Uint8,
 		reflect.Uint16,
 		reflect.Uint32,
-		reflect.Uint64,
+		reflect.Uint64:
+		return !n.noInts
+	case
 		reflect.Float32,
 		reflect.Float64:
-		return true
+		return !n.noFloats
 	}
 
 	return false
 }
 
-type stringExporter struct{}
+type stringExporter struct {
+	backend Backend
+	// invisibles enables escaping of invisible characters, see WithEscapedInvisibles
+	invisibles bool
+	pseudonyms *pseudonymizer
+}
 
-func (stringExporter) export(v any) (string, error) {
-	return fmt.Sprintf("%+q", v), nil
+func (s stringExporter) export(v any) (string, error) {
+	code := s.backend.renderString(s.pseudonyms.replace(v.(string))) //nolint:forcetypeassert
+	if s.invisibles {
+		code = escapeInvisibles(s.backend, code)
+	}
+
+	return code, nil
 }
 
 func (stringExporter) supports(v any) bool {
@@ -291,71 +549,141 @@ func (stringExporter) supports(v any) bool {
 	return ok
 }
 
-type bytesExporter struct{}
-
-func (bytesExporter) export(v any) (string, error) {
-	s, _ := stringExporter{}.export(v)
+// bytesExporter exports byte slices that contain valid UTF-8 as conversions of strings, e.g.:
+//
+//	[]byte("hello")
+//
+// Other byte slices are exported by multiArray, see WithByteLists.
+type bytesExporter struct {
+	backend  Backend
+	disabled bool
+	// invisibles enables escaping of invisible characters, see WithEscapedInvisibles
+	invisibles bool
+}
+
+func (b bytesExporter) export(v any) (string, error) {
+	code := b.backend.renderBytes(v.([]byte)) //nolint:forcetypeassert
+	if b.invisibles {
+		code = escapeInvisibles(b.backend, code)
+	}
 
-	return fmt.Sprintf("[]byte(%s)", s), nil
+	return code, nil
 }
 
-func (bytesExporter) supports(v any) bool {
-	b, ok := v.([]byte)
+func (b bytesExporter) supports(v any) bool {
+	if b.disabled {
+		return false
+	}
 
-	return ok && utf8.Valid(b)
+	bs, ok := v.([]byte)
+
+	return ok && utf8.Valid(bs)
 }
 
 type multiArray struct {
-	exporter exporter
-}
+	exporter    exporter
+	backend     Backend
+	maxElements int
+	warn        func(string)
+	sparse      bool
+	annotations int
+	// integralFloats is the type of integers that replace integral floats, see WithIntegralFloats
+	integralFloats reflect.Type
+	elide          scalarElision
+	nils           nilStyles
+	// keyed enables index keys in arrays of structs, see WithKeyedArrays
+	keyed bool
+	// elideLiterals omits types of elements, see WithCompositeElision
+	elideLiterals bool
+}
+
+// isSliceOrArray reports whether the given type is a slice or an array, including defined types, e.g.:
+//
+//	type IDs []int64
+//
+// Defined types that have dedicated exporters are excluded, e.g. net.IP.
+func isSliceOrArray(t reflect.Type) bool {
+	return (t.Kind() == reflect.Slice || t.Kind() == reflect.Array) && !isNamedScalar(t)
+}
+
+// typeName returns the name of the given type in a GO code.
+func typeName(t reflect.Type) string {
+	switch {
+	case t.Name() != "":
+		return t.String()
+	case t.Kind() == reflect.Interface && t.NumMethod() == 0:
+		return "interface{}"
+	case t.Kind() == reflect.Slice:
+		return "[]" + typeName(t.Elem())
+	case t.Kind() == reflect.Array:
+		return fmt.Sprintf("[%d]%s", t.Len(), typeName(t.Elem()))
+	case t.Kind() == reflect.Ptr:
+		return "*" + typeName(t.Elem())
+	case t.Kind() == reflect.Map:
+		return fmt.Sprintf("map[%s]%s", typeName(t.Key()), typeName(t.Elem()))
+	}
 
-func isBuiltInSliceOrArray(t reflect.Type) bool {
-	return t.PkgPath() == "" && (t.Kind() == reflect.Slice || t.Kind() == reflect.Array)
+	return t.String()
 }
 
 func (m multiArray) export(v any) (string, error) {
 	val := reflect.ValueOf(v)
-	t := val.Type()
-	prefix := ""
-
-	for isBuiltInSliceOrArray(t) {
-		if t.Kind() == reflect.Array {
-			prefix += fmt.Sprintf("[%d]", t.Len())
-		} else {
-			prefix += "[]"
-		}
 
-		t = t.Elem()
+	if val.Kind() == reflect.Slice && m.sparse && !val.IsNil() {
+		val = trimNilTail(val)
 	}
 
-	var ts string
-	if t.Kind() == reflect.Interface {
-		ts = "interface{}"
-	} else {
-		ts = t.Kind().String()
-	}
-
-	if val.Type().Kind() == reflect.Slice {
+	if val.Kind() == reflect.Slice {
 		switch {
 		case val.IsNil():
-			return fmt.Sprintf("(%s%s)(nil)", prefix, ts), nil
+			return m.backend.renderNilSlice(val.Type()), nil
 		case val.Len() == 0:
-			return fmt.Sprintf("make(%s%s, 0)", prefix, ts), nil
+			return m.backend.renderEmptySlice(val.Type()), nil
 		}
 	}
 
 	parts := make([]string, val.Len())
+	// elements of chunked slices are arguments of append, so they must keep their types
+	appended := val.Kind() == reflect.Slice && m.maxElements > 0 && val.Len() > m.maxElements
 
 	for i := 0; i < val.Len(); i++ {
 		var err error
-		parts[i], err = m.exporter.export(val.Index(i).Interface())
+		parts[i], err = m.exporter.export(integralFloat(m.integralFloats, val.Type().Elem(), val.Index(i).Interface()))
 
 		if err != nil {
-			return "", fmt.Errorf("cannot export (%s)[%d]: %w", prefix+ts, i, err)
+			return "", newPathError(typeName(val.Type()), IndexStep(i), err)
 		}
+
+		parts[i] = elideScalar(m.elide, val.Type().Elem(), parts[i])
+		parts[i] = elideComposite(m.elideLiterals && !appended, val.Type().Elem(), parts[i])
+		parts[i] = m.nils.render(m.backend, val.Type().Elem(), val.Index(i), parts[i])
 	}
 
-	return prefix + ts + "{" + strings.Join(parts, ", ") + "}", nil
+	if annotate(m.annotations, len(parts)) {
+		for i := range parts {
+			parts[i] = m.backend.renderAnnotation(parts[i], fmt.Sprintf("[%d]", i))
+		}
+	}
+
+	if m.maxElements > 0 && len(parts) > m.maxElements {
+		m.warn(fmt.Sprintf(
+			"(%s) has %d elements, it is built from chunks of %d elements",
+			typeName(val.Type()),
+			len(parts),
+			m.maxElements,
+		))
+
+		return m.backend.renderChunkedSequence(val.Type(), chunks(parts, m.maxElements)), nil
+	}
+
+	if _, ok := m.backend.(goBackend); ok && m.keyed && val.Kind() == reflect.Array &&
+		val.Type().Elem().Kind() == reflect.Struct {
+		for i := range parts {
+			parts[i] = strconv.Itoa(i) + ": " + parts[i]
+		}
+	}
+
+	return m.backend.renderSequence(val.Type(), parts), nil
 }
 
 func (m multiArray) supports(v any) bool {
@@ -366,27 +694,49 @@ func (m multiArray) supports(v any) bool {
 
 	t := val.Type()
 
-	if !isBuiltInSliceOrArray(t) {
+	if !isSliceOrArray(t) {
 		return false
 	}
 
-	for isBuiltInSliceOrArray(t) {
+	for isSliceOrArray(t) {
 		t = t.Elem()
 	}
 
-	// workaround: we have to check PkgPath && NumMethod, otherwise
-	//
-	// z := reflect.Zero(t).Interface()
-	// m.exporter.supports(z) // it will return true for interface with methods, e.g. interface{ Do() }
-	if t.PkgPath() != "" {
-		return false
+	return supportsType(m.exporter, t)
+}
+
+// namedScalarTypes contains defined types of other kinds than struct that have dedicated exporters.
+//
+//nolint:gochecknoglobals
+var namedScalarTypes = map[reflect.Type]struct{}{
+	fileModeType:     {},
+	structTagType:    {},
+	ipType:           {},
+	ipMaskType:       {},
+	hardwareAddrType: {},
+}
+
+func isNamedScalar(t reflect.Type) bool {
+	if _, ok := namedScalarTypes[t]; ok {
+		return true
 	}
 
-	if t.Kind() == reflect.Interface && t.NumMethod() > 0 {
-		return false
+	_, ok := htmlTemplateTypes[t]
+
+	return ok
+}
+
+// supportsType reports whether the given exporter supports values of the given type.
+func supportsType(e exporter, t reflect.Type) bool {
+	// dynamic values of interfaces are checked while exporting, e.g. elements of []interface{ Do() },
+	// the zero value of an interface is nil, so it says nothing about the dynamic values, see also errorExporter
+	if t.Kind() == reflect.Interface {
+		return true
 	}
 
-	z := reflect.Zero(t).Interface()
+	if c, ok := e.(*typeCacheExporter); ok {
+		return c.supportsType(t)
+	}
 
-	return m.exporter.supports(z)
+	return e.supports(reflect.Zero(t).Interface())
 }
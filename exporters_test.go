@@ -36,8 +36,21 @@ type (
 	aliasInt    = int
 	myBool      bool
 	aliasBool   = bool
+
+	doer struct {
+		Name string
+	}
+
+	chanDoer chan int
+
+	ids    []int64
+	matrix [2][2]float64
 )
 
+func (doer) Do() {}
+
+func (chanDoer) Do() {}
+
 //nolint:testifylint
 func TestChainExporter_Export(t *testing.T) {
 	t.Parallel()
@@ -67,6 +80,14 @@ func TestChainExporter_Export(t *testing.T) {
 				input:  int(123),
 				output: "int(123)",
 			},
+			"math.MaxUint64": {
+				input:  uint64(math.MaxUint64),
+				output: "uint64(18446744073709551615)",
+			},
+			"math.MaxInt64 + 1": {
+				input:  uint(math.MaxInt64 + 1),
+				output: "uint(9223372036854775808)",
+			},
 			"`hello world`": {
 				input:  "hello world",
 				output: `"hello world"`,
@@ -76,32 +97,36 @@ func TestChainExporter_Export(t *testing.T) {
 				output: `[]byte("hello world \u4f60\u597d\uff0c\u4e16\u754c")`,
 			},
 			"struct {}": {
-				input: struct{}{},
-				error: "type struct {} is not supported",
+				input:  struct{}{},
+				output: "struct {}{}",
+			},
+			"chan int": {
+				input: make(chan int),
+				error: "type chan int is not supported",
 			},
 			"*testing.T": {
 				input: t,
-				error: "type *testing.T is not supported",
+				error: "cannot export (testing.T).common: unexported field is not zero",
 			},
 			`myString("foo")`: {
-				input: myString("foo"),
-				error: "type exporter.myString is not supported",
+				input:  myString("foo"),
+				output: `exporter.myString("foo")`,
 			},
 			`aliasString("foo")`: {
 				input:  aliasString("foo"),
 				output: `"foo"`,
 			},
 			`myInt(5)`: {
-				input: myInt(5),
-				error: "type exporter.myInt is not supported",
+				input:  myInt(5),
+				output: `exporter.myInt(5)`,
 			},
 			`aliasInt(5)`: {
 				input:  aliasInt(5),
 				output: "int(5)",
 			},
 			`myBool(true)`: {
-				input: myBool(true),
-				error: "type exporter.myBool is not supported",
+				input:  myBool(true),
+				output: `exporter.myBool(true)`,
 			},
 			`aliasBool(true)`: {
 				input:  aliasBool(true),
@@ -116,12 +141,12 @@ func TestChainExporter_Export(t *testing.T) {
 				output: `[][2][][]int{[2][][]int{[][]int{[]int{int(1), int(2)}}, ([][]int)(nil)}}`,
 			},
 			`[][]any{nil, nil, {(*int)(nil)}}`: {
-				input: [][]any{nil, nil, {(*int)(nil)}},
-				error: `cannot export ([][]interface{})[2]: cannot export ([]interface{})[0]: type *int is not supported`,
+				input:  [][]any{nil, nil, {(*int)(nil)}},
+				output: `[][]interface{}{([]interface{})(nil), ([]interface{})(nil), []interface{}{(*int)(nil)}}`,
 			},
 			`[]any{(*int)(nil)}`: {
-				input: []any{(*int)(nil)},
-				error: `cannot export ([]interface{})[0]: type *int is not supported`,
+				input:  []any{(*int)(nil)},
+				output: `[]interface{}{(*int)(nil)}`,
 			},
 			`[0][][]any{}`: {
 				input:  [0][][]any{},
@@ -196,14 +221,14 @@ func TestExport(t *testing.T) {
 			output: "[0]interface{}{}",
 		},
 		{
-			input: []any{struct{}{}},
-			error: "cannot export ([]interface{})[0]: type struct {} is not supported",
-			panic: "cannot export []interface {} to string: cannot export ([]interface{})[0]: type struct {} is not supported",
+			input: []any{make(chan int)},
+			error: "cannot export ([]interface{})[0]: type chan int is not supported",
+			panic: "cannot export []interface {} to string: cannot export ([]interface{})[0]: type chan int is not supported",
 		},
 		{
-			input: [1]any{struct{}{}},
-			error: "cannot export ([1]interface{})[0]: type struct {} is not supported",
-			panic: "cannot export [1]interface {} to string: cannot export ([1]interface{})[0]: type struct {} is not supported",
+			input: [1]any{make(chan int)},
+			error: "cannot export ([1]interface{})[0]: type chan int is not supported",
+			panic: "cannot export [1]interface {} to string: cannot export ([1]interface{})[0]: type chan int is not supported",
 		},
 		{
 			input:  []int{1, 2, 3, -1000000},
@@ -238,19 +263,17 @@ func TestExport(t *testing.T) {
 			output: "[0]float32{}",
 		},
 		{
-			input: struct{}{},
-			error: "type struct {} is not supported",
-			panic: "cannot export struct {} to string: type struct {} is not supported",
+			input: make(chan int),
+			error: "type chan int is not supported",
+			panic: "cannot export chan int to string: type chan int is not supported",
 		},
 		{
-			input: []interface{ Do() }{nil, nil, nil},
-			error: "type []interface { Do() } is not supported",
-			panic: "cannot export []interface { Do() } to string: type []interface { Do() } is not supported",
+			input:  []interface{ Do() }{nil, nil, nil},
+			output: "[]interface { Do() }{nil, nil, nil}",
 		},
 		{
-			input: [3]interface{ Do() }{},
-			error: "type [3]interface { Do() } is not supported",
-			panic: "cannot export [3]interface { Do() } to string: type [3]interface { Do() } is not supported",
+			input:  [3]interface{ Do() }{},
+			output: "[3]interface { Do() }{nil, nil, nil}",
 		},
 		{
 			input:  []any{nil, nil, nil},
@@ -261,9 +284,8 @@ func TestExport(t *testing.T) {
 			output: "[3]interface{}{nil, nil, nil}",
 		},
 		{
-			input: []interface{ Do() }{nil},
-			error: `type []interface { Do() } is not supported`,
-			panic: `cannot export []interface { Do() } to string: type []interface { Do() } is not supported`,
+			input:  []interface{ Do() }{nil},
+			output: `[]interface { Do() }{nil}`,
 		},
 	}
 
@@ -450,3 +472,105 @@ func TestNumericExporter_Supports(t *testing.T) {
 		})
 	}
 }
+
+func TestWithByteLists(t *testing.T) {
+	t.Parallel()
+
+	bom := []byte("\ufeffid")
+
+	assert.Equal(t, `[]byte("\ufeffid")`, MustExport(bom))
+	assert.Equal(
+		t,
+		`[]uint8{uint8(239), uint8(187), uint8(191), uint8(105), uint8(100)}`,
+		New(WithByteLists()).MustExport(bom),
+	)
+	assert.Equal(
+		t,
+		`[]uint8{239, 187, 191, 105, 100}`,
+		New(WithByteLists(), WithScalarElision()).MustExport(bom),
+	)
+}
+
+func TestWithHexLargeUints(t *testing.T) {
+	t.Parallel()
+
+	input := []any{uint64(math.MaxUint64), uint(math.MaxInt64 + 1), uint64(math.MaxInt64), int64(math.MinInt64)}
+
+	assert.Equal(
+		t,
+		`[]interface{}{uint64(0xffffffffffffffff), uint(0x8000000000000000), uint64(9223372036854775807), `+
+			`int64(-9223372036854775808)}`,
+		New(WithHexLargeUints()).MustExport(input),
+	)
+	assert.Equal(
+		t,
+		`[18446744073709551615,9223372036854775808,9223372036854775807,-9223372036854775808]`,
+		New(WithHexLargeUints(), WithBackend(JSONBackend())).MustExport(input),
+	)
+}
+
+func TestExport_interfaceElements(t *testing.T) {
+	t.Parallel()
+
+	assert.Equal(
+		t,
+		`[]interface { Do() }{exporter.doer{Name: "a"}, nil}`,
+		MustExport([]interface{ Do() }{doer{Name: "a"}, nil}),
+	)
+	assert.Equal(
+		t,
+		`map[string]fmt.Stringer{"a": nil}`,
+		MustExport(map[string]fmt.Stringer{"a": nil}),
+	)
+
+	_, err := Export([]interface{ Do() }{doer{}, make(chanDoer)})
+	assert.EqualError(t, err, `cannot export ([]interface { Do() })[1]: type exporter.chanDoer is not supported`)
+}
+
+func TestExport_definedSlices(t *testing.T) {
+	t.Parallel()
+
+	scenarios := []struct {
+		input  any
+		output string
+	}{
+		{
+			input:  ids{1, 2},
+			output: `exporter.ids{int64(1), int64(2)}`,
+		},
+		{
+			input:  ids(nil),
+			output: `(exporter.ids)(nil)`,
+		},
+		{
+			input:  ids{},
+			output: `make(exporter.ids, 0)`,
+		},
+		{
+			input:  matrix{{1, 0}, {0, 1}},
+			output: `exporter.matrix{[2]float64{float64(1), float64(0)}, [2]float64{float64(0), float64(1)}}`,
+		},
+		{
+			input:  []ids{{1}, nil},
+			output: `[]exporter.ids{exporter.ids{int64(1)}, (exporter.ids)(nil)}`,
+		},
+		{
+			input: map[string]matrix{"a": {}},
+			output: `map[string]exporter.matrix{"a": exporter.matrix{[2]float64{float64(0), float64(0)}, ` +
+				`[2]float64{float64(0), float64(0)}}}`,
+		},
+	}
+
+	for _, s := range scenarios {
+		s := s
+
+		t.Run(s.output, func(t *testing.T) {
+			t.Parallel()
+
+			assert.Equal(t, s.output, MustExport(s.input))
+		})
+	}
+
+	_, err := Export([]chan int{nil})
+	assert.EqualError(t, err, `type []chan int is not supported`)
+}
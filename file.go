@@ -0,0 +1,562 @@
+// Copyright (c) 2023–present Bartłomiej Krukowski
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is furnished
+// to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package exporter
+
+import (
+	"bytes"
+	"fmt"
+	"go/ast"
+	"go/parser"
+	"go/printer"
+	"go/token"
+	"reflect"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// GeneratedHeader is the first line of files generated by ExportFile.
+const GeneratedHeader = "// Code generated by github.com/gontainer/exporter. DO NOT EDIT."
+
+// LineEnding defines line endings of files generated by ExportFile, see WithLineEnding.
+type LineEnding int
+
+const (
+	// LineEndingLF ends lines with "\n", it is the default value.
+	LineEndingLF LineEnding = iota
+	// LineEndingCRLF ends lines with "\r\n".
+	LineEndingCRLF
+)
+
+// WithLineEnding sets line endings of files generated by ExportFile.
+func WithLineEnding(l LineEnding) Option {
+	return func(c *config) {
+		c.lineEnding = l
+	}
+}
+
+// Declaration defines how ExportFile declares the exported value, see WithDeclaration.
+type Declaration int
+
+const (
+	// DeclarationVar declares a package-level variable, it is the default value, e.g.:
+	//
+	//	var Users = []fixtures.User{...}
+	//
+	// The value is built once, and it is shared by all its users.
+	DeclarationVar Declaration = iota
+	// DeclarationFunc declares a function that returns a new copy of the value on each call, e.g.:
+	//
+	//	func Users() []fixtures.User { return []fixtures.User{...} }
+	//
+	// Tests that modify the value, e.g. parallel tests, do not affect each other.
+	DeclarationFunc
+)
+
+// WithDeclaration sets how ExportFile declares the exported value.
+// Files generated by ExportEqualityTest and ExportShapeTest refer to the value accordingly.
+func WithDeclaration(d Declaration) Option {
+	return func(c *config) {
+		c.declaration = d
+	}
+}
+
+// WithCopyAccessors makes ExportFile declare, alongside the variable, a function that returns a deep copy
+// of the exported value, e.g. for the suffix "Copy":
+//
+//	func UsersCopy() []fixtures.User {
+//		return []fixtures.User{...}
+//	}
+//
+//	var Users = UsersCopy()
+//
+// Each call builds the value from scratch, so tests that modify the copy do not affect other tests.
+// The name of the function consists of the name of the variable and the given suffix.
+// An empty suffix disables accessors, it is the default behavior. DeclarationFunc ignores this option.
+func WithCopyAccessors(suffix string) Option {
+	return func(c *config) {
+		c.copySuffix = suffix
+	}
+}
+
+// WithTypedAccessors makes ExportFile declare, alongside slices and arrays of interfaces, a generic function
+// that returns the element of the given index asserted to the given type, e.g. for the suffix "At":
+//
+//	func EventsAt[T any](i int) T {
+//		return Events[i].(T)
+//	}
+//
+// so tests can write fixtures.EventsAt[fixtures.Login](0) instead of fixtures.Events[0].(fixtures.Login).
+// The name of the function consists of the name of the variable and the given suffix.
+// Generated functions require GO 1.18 or newer.
+// An empty suffix disables accessors, it is the default behavior.
+func WithTypedAccessors(suffix string) Option {
+	return func(c *config) {
+		c.typedSuffix = suffix
+	}
+}
+
+// WithSpaceIndentation makes ExportFile indent generated files with the given number of spaces instead of tabs.
+// A non-positive value restores the default indentation with tabs.
+func WithSpaceIndentation(width int) Option {
+	return func(c *config) {
+		c.spaceIndentation = width
+	}
+}
+
+// ExportFile exports the given value to a complete GO file, e.g.:
+//
+//	// Code generated by github.com/gontainer/exporter. DO NOT EDIT.
+//	// Checksum: sha256:2fd4e1c67a2d28fced849ee1bb76e7391b93eb12...
+//
+//	package fixtures
+//
+//	import (
+//		"time"
+//	)
+//
+//	var Deadline = time.Date(2023, time.March, 1, 12, 30, 0, 0, time.UTC)
+//
+// The checksum allows to detect manual changes, see VerifyGenerated.
+//
+// See Exporter.ExportFile.
+func ExportFile(pkg string, varName string, v any) ([]byte, error) {
+	return defaultExporter.ExportFile(pkg, varName, v)
+}
+
+// ExportFile exports the given value to a complete GO file that declares the variable varName in the package pkg,
+// or the function varName, see WithDeclaration.
+// The file imports all packages required by the exported value, see the function ExportFile and WithMapSplitting.
+func (e *Exporter) ExportFile(pkg string, varName string, v any) ([]byte, error) {
+	if e.config.tracing != nil {
+		return e.traceFile(func(e *Exporter) ([]byte, error) {
+			return e.exportFile(pkg, varName, v)
+		})
+	}
+
+	return e.exportFile(pkg, varName, v)
+}
+
+func (e *Exporter) exportFile(pkg string, varName string, v any) ([]byte, error) {
+	pkg, varName, err := e.validateFile("ExportFile", pkg, varName, v)
+	if err != nil {
+		return nil, err
+	}
+
+	names := map[string]struct{}{varName: {}, e.copyAccessor(varName): {}}
+
+	decls, exprs, err := e.exportDecls(varName, v, names)
+	if err != nil {
+		return nil, err
+	}
+
+	imports, err := findImports(v, e.config, exprs...)
+	if err != nil {
+		return nil, err
+	}
+
+	return e.renderFile(pkg, imports, decls)
+}
+
+// exportDecls exports the given value, and returns its declarations and the exported expressions.
+// Names of helper functions differ from the given names, and they are added to them, see WithMapSplitting.
+func (e *Exporter) exportDecls(varName string, v any, names map[string]struct{}) (string, []string, error) {
+	var (
+		decls string
+		exprs []string
+		err   error
+	)
+
+	if val := reflect.ValueOf(v); e.config.splitPrefix != "" && isSplittable(val) {
+		decls, exprs, err = e.exportSplitMap(varName, val, names)
+	} else {
+		var code string
+		code, err = e.Export(v)
+		decls, exprs = e.declare(varName, reflect.TypeOf(v), code), []string{code}
+	}
+
+	if err != nil {
+		return "", nil, err
+	}
+
+	if e.config.summary != nil {
+		decls = e.config.summary.render(v, exprs) + decls
+	}
+
+	return decls, exprs, nil
+}
+
+// declare returns the declaration of the given code of the given type,
+// see WithDeclaration, WithCopyAccessors and WithTypedAccessors.
+func (e *Exporter) declare(name string, t reflect.Type, code string) string {
+	typ := declaredType(t)
+
+	var decl string
+
+	switch fn := e.copyAccessor(name); {
+	case e.config.declaration == DeclarationFunc:
+		decl = fmt.Sprintf("func %s() %s {\nreturn %s\n}\n", name, typ, code)
+	case fn != "":
+		decl = fmt.Sprintf("func %s() %s {\nreturn %s\n}\n\nvar %s = %s()\n", fn, typ, code, name, fn)
+	default:
+		decl = "var " + name + " = " + code + "\n"
+	}
+
+	if fn := e.typedAccessor(name, t); fn != "" {
+		decl += fmt.Sprintf("\nfunc %s[T any](i int) T {\nreturn %s[i].(T)\n}\n", fn, e.reference(name))
+	}
+
+	return decl
+}
+
+// declaredType returns the name of the given type in declarations of functions, see WithDeclaration
+// and WithCopyAccessors. Types that cannot be named outside their packages, e.g. *fmt.wrapError
+// returned by fmt.Errorf, are replaced by error or interface{}.
+func declaredType(t reflect.Type) string {
+	switch {
+	case t == nil:
+		return "interface{}"
+	case isNameable(t):
+		return typeName(t)
+	case t.Implements(errorType):
+		return "error"
+	}
+
+	return "interface{}"
+}
+
+// isNameable reports whether the given type can be named outside its package,
+// i.e. it does not refer to unexported types or unexported fields of other packages.
+func isNameable(t reflect.Type) bool {
+	if t.Name() != "" {
+		return t.PkgPath() == "" || token.IsExported(t.Name())
+	}
+
+	//nolint:exhaustive
+	switch t.Kind() {
+	case reflect.Ptr, reflect.Slice, reflect.Array, reflect.Chan:
+		return isNameable(t.Elem())
+	case reflect.Map:
+		return isNameable(t.Key()) && isNameable(t.Elem())
+	case reflect.Struct:
+		for i := 0; i < t.NumField(); i++ {
+			if f := t.Field(i); f.PkgPath != "" || !isNameable(f.Type) {
+				return false
+			}
+		}
+	case reflect.Func:
+		for i := 0; i < t.NumIn(); i++ {
+			if !isNameable(t.In(i)) {
+				return false
+			}
+		}
+
+		for i := 0; i < t.NumOut(); i++ {
+			if !isNameable(t.Out(i)) {
+				return false
+			}
+		}
+	}
+
+	return true
+}
+
+// copyAccessor returns the name of the function that returns a copy of the given variable,
+// or an empty string if there is no such function, see WithCopyAccessors.
+func (e *Exporter) copyAccessor(name string) string {
+	if e.config.copySuffix == "" || e.config.declaration == DeclarationFunc {
+		return ""
+	}
+
+	return name + e.config.copySuffix
+}
+
+// typedAccessor returns the name of the generic function that returns the elements of the given variable
+// of the given type, or an empty string if there is no such function, see WithTypedAccessors.
+func (e *Exporter) typedAccessor(name string, t reflect.Type) string {
+	if e.config.typedSuffix == "" || t == nil || !isSliceOrArray(t) || t.Elem().Kind() != reflect.Interface {
+		return ""
+	}
+
+	return name + e.config.typedSuffix
+}
+
+// reference returns the expression that refers to the value declared by ExportFile, see WithDeclaration.
+func (e *Exporter) reference(name string) string {
+	if e.config.declaration == DeclarationFunc {
+		return name + "()"
+	}
+
+	return name
+}
+
+// validateFile verifies whether the given value can be exported to a file, and returns the names
+// of the package and the variable that must be used, see WithIdentifierSanitizing.
+func (e *Exporter) validateFile(fn string, pkg string, varName string, v any) (string, string, error) {
+	if _, ok := e.config.backend.(goBackend); !ok {
+		return "", "", fmt.Errorf("%s requires the GO backend", fn) //nolint:goerr113
+	}
+
+	return e.identifiers(pkg, varName, v)
+}
+
+// renderFile renders a formatted GO file with the given imports and declarations.
+func (e *Exporter) renderFile(pkg string, imports []string, decls string) ([]byte, error) {
+	buf := bytes.NewBuffer(nil)
+	buf.WriteString(GeneratedHeader + "\n")
+
+	if e.config.formatVersion >= FormatVersion2 {
+		buf.WriteString(FormatVersionPrefix + strconv.Itoa(int(e.config.formatVersion)) + "\n")
+	}
+
+	buf.WriteString("\npackage " + pkg + "\n\n")
+
+	if len(imports) > 0 {
+		buf.WriteString("import (\n")
+
+		for _, i := range imports {
+			buf.WriteString(strconv.Quote(i) + "\n")
+		}
+
+		buf.WriteString(")\n\n")
+	}
+
+	if e.config.anyAlias {
+		decls = replaceEmptyInterfaces(decls)
+	}
+
+	buf.WriteString(decls)
+
+	src, err := formatFile(buf.Bytes(), e.config.lineEnding, e.config.spaceIndentation)
+	if err != nil {
+		return nil, err
+	}
+
+	eol := "\n"
+	if e.config.lineEnding == LineEndingCRLF {
+		eol = "\r\n"
+	}
+
+	return addChecksum(src, eol), nil
+}
+
+// formatFile works like format.Source, but it respects the given line ending and indentation,
+// see WithLineEnding and WithSpaceIndentation.
+func formatFile(src []byte, lineEnding LineEnding, spaceIndentation int) ([]byte, error) {
+	fset := token.NewFileSet()
+
+	file, err := parser.ParseFile(fset, "", src, parser.ParseComments)
+	if err != nil {
+		return nil, fmt.Errorf("cannot parse generated file: %w", err)
+	}
+
+	//nolint:gomnd
+	cfg := printer.Config{Mode: printer.UseSpaces | printer.TabIndent, Tabwidth: 8, Indent: 0}
+	if spaceIndentation > 0 {
+		cfg = printer.Config{Mode: printer.UseSpaces, Tabwidth: spaceIndentation, Indent: 0}
+	}
+
+	buf := bytes.NewBuffer(nil)
+	if err := cfg.Fprint(buf, fset, file); err != nil {
+		return nil, fmt.Errorf("cannot print generated file: %w", err)
+	}
+
+	result := buf.Bytes()
+	if lineEnding == LineEndingCRLF {
+		// carriage returns in raw strings are discarded by the compiler, so it is safe to replace all line feeds
+		result = bytes.ReplaceAll(result, []byte("\n"), []byte("\r\n"))
+	}
+
+	return result, nil
+}
+
+// findImports returns the sorted paths of packages referenced by the given expressions exported from the given value.
+//
+// All named types reachable from the value are candidates, but only those packages that are
+// actually referenced by the code are returned, e.g. unexported zero fields are not exported.
+func findImports(v any, cfg config, exprs ...string) ([]string, error) {
+	pkgs, err := findPackages(v, cfg, exprs...)
+	if err != nil {
+		return nil, err
+	}
+
+	result := make([]string, 0, len(pkgs))
+	for _, path := range pkgs {
+		result = append(result, path)
+	}
+
+	return mergeImports(result), nil
+}
+
+// findPackages works like findImports, but it returns the paths of packages by the names used by the code.
+func findPackages(v any, cfg config, exprs ...string) (map[string]string, error) {
+	c := packageCollector{
+		pkgs:   make(map[string]map[string]struct{}),
+		types:  make(map[reflect.Type]struct{}),
+		values: make(map[cacheKey]struct{}),
+	}
+	c.collect(reflect.ValueOf(v))
+	// the exported code may refer to the package time, even if the value does not, see WithTimestamps
+	c.collectType(timeType)
+	// sentinel errors are referenced by their names, see WithSentinelErrors
+	for _, s := range cfg.sentinels {
+		c.addPackage(strings.SplitN(s.name, ".", 2)[0], s.path)
+	}
+
+	used := make(map[string]struct{})
+
+	for _, code := range exprs {
+		expr, err := parser.ParseExpr(code)
+		if err != nil {
+			return nil, fmt.Errorf("cannot parse exported code: %w", err)
+		}
+
+		ast.Inspect(expr, func(n ast.Node) bool {
+			if sel, ok := n.(*ast.SelectorExpr); ok {
+				if id, ok := sel.X.(*ast.Ident); ok {
+					used[id.Name] = struct{}{}
+				}
+			}
+
+			return true
+		})
+	}
+
+	result := make(map[string]string, len(used))
+
+	for name, paths := range c.pkgs {
+		if _, ok := used[name]; !ok {
+			continue
+		}
+
+		if len(paths) > 1 {
+			conflicts := make([]string, 0, len(paths))
+			for path := range paths {
+				conflicts = append(conflicts, path)
+			}
+
+			sort.Strings(conflicts)
+
+			return nil, fmt.Errorf("packages %q have the same name %q", conflicts, name) //nolint:goerr113
+		}
+
+		for path := range paths {
+			resolved, err := resolveImport(cfg, path)
+			if err != nil {
+				return nil, err
+			}
+
+			result[name] = resolved
+		}
+	}
+
+	return result, nil
+}
+
+// packageCollector walks through values and their types, and stores packages of all named types.
+type packageCollector struct {
+	pkgs   map[string]map[string]struct{} // package name => package paths
+	types  map[reflect.Type]struct{}
+	values map[cacheKey]struct{}
+}
+
+func (c packageCollector) collect(v reflect.Value) {
+	if !v.IsValid() {
+		return
+	}
+
+	c.collectType(v.Type())
+
+	//nolint:exhaustive
+	switch v.Kind() {
+	case reflect.Ptr, reflect.Map, reflect.Slice:
+		if v.IsNil() {
+			return
+		}
+
+		// values may contain cycles, e.g. elements of a list.List point to the list
+		k := cacheKey{t: v.Type(), ptr: v.Pointer(), len: 0}
+		if v.Kind() == reflect.Slice {
+			k.len = v.Len()
+		}
+
+		if _, ok := c.values[k]; ok {
+			return
+		}
+
+		c.values[k] = struct{}{}
+	}
+
+	//nolint:exhaustive
+	switch v.Kind() {
+	case reflect.Interface, reflect.Ptr:
+		if !v.IsNil() {
+			c.collect(v.Elem())
+		}
+	case reflect.Slice, reflect.Array:
+		for i := 0; i < v.Len(); i++ {
+			c.collect(v.Index(i))
+		}
+	case reflect.Map:
+		iter := v.MapRange()
+		for iter.Next() {
+			c.collect(iter.Key())
+			c.collect(iter.Value())
+		}
+	case reflect.Struct:
+		for i := 0; i < v.NumField(); i++ {
+			c.collect(v.Field(i))
+		}
+	}
+}
+
+func (c packageCollector) addPackage(name string, path string) {
+	if c.pkgs[name] == nil {
+		c.pkgs[name] = make(map[string]struct{})
+	}
+
+	c.pkgs[name][path] = struct{}{}
+}
+
+func (c packageCollector) collectType(t reflect.Type) {
+	if _, ok := c.types[t]; ok {
+		return
+	}
+
+	c.types[t] = struct{}{}
+
+	if t.PkgPath() != "" && t.Name() != "" {
+		c.addPackage(strings.SplitN(t.String(), ".", 2)[0], t.PkgPath())
+	}
+
+	//nolint:exhaustive
+	switch t.Kind() {
+	case reflect.Ptr, reflect.Slice, reflect.Array:
+		c.collectType(t.Elem())
+	case reflect.Map:
+		c.collectType(t.Key())
+		c.collectType(t.Elem())
+	case reflect.Struct:
+		for i := 0; i < t.NumField(); i++ {
+			c.collectType(t.Field(i).Type)
+		}
+	}
+}
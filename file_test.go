@@ -0,0 +1,258 @@
+// Copyright (c) 2023–present Bartłomiej Krukowski
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is furnished
+// to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package exporter_test
+
+import (
+	"container/list"
+	"fmt"
+	"io"
+	"testing"
+	"time"
+
+	"github.com/gontainer/exporter"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestExportFile(t *testing.T) {
+	t.Parallel()
+
+	t.Run("Imports", func(t *testing.T) {
+		t.Parallel()
+
+		l := list.New()
+		l.PushBack(time.Date(2023, time.March, 1, 0, 0, 0, 0, time.UTC))
+
+		code, err := exporter.ExportFile("fixtures", "Events", []any{l, StructServer{Port: 80}})
+		require.NoError(t, err)
+		assert.Equal(
+			t,
+			`// Code generated by github.com/gontainer/exporter. DO NOT EDIT.
+// Format: 2
+
+package fixtures
+
+import (
+	"container/list"
+	"github.com/gontainer/exporter_test"
+	"time"
+)
+
+var Events = []interface{}{func() *list.List {
+	l := list.New()
+	l.PushBack(time.Date(2023, time.March, 1, 0, 0, 0, 0, time.UTC))
+	return l
+}(), exporter_test.StructServer{Port: int(80)}}
+`,
+			withoutChecksum(t, code),
+		)
+	})
+
+	t.Run("No imports", func(t *testing.T) {
+		t.Parallel()
+
+		code, err := exporter.ExportFile("fixtures", "Numbers", []int{1, 2})
+		require.NoError(t, err)
+		assert.Equal(
+			t,
+			`// Code generated by github.com/gontainer/exporter. DO NOT EDIT.
+// Format: 2
+
+package fixtures
+
+var Numbers = []int{int(1), int(2)}
+`,
+			withoutChecksum(t, code),
+		)
+	})
+
+	t.Run("Unused packages", func(t *testing.T) {
+		t.Parallel()
+
+		// time.Time has unexported fields of the type *time.Location, but the package time is not used
+		code, err := exporter.ExportFile("fixtures", "Config", structConfig{Name: "config"})
+		require.NoError(t, err)
+		assert.Contains(t, string(code), "import (\n\t\"github.com/gontainer/exporter_test\"\n)\n")
+	})
+
+	t.Run("Formatting", func(t *testing.T) {
+		t.Parallel()
+
+		e := exporter.New(
+			exporter.WithLineEnding(exporter.LineEndingCRLF),
+			exporter.WithSpaceIndentation(4),
+			exporter.WithMapSplitting("fixture"),
+		)
+		code, err := e.ExportFile("fixtures", "Values", map[string]string{"a": "a\nb"})
+		require.NoError(t, err)
+		assert.Equal(
+			t,
+			"// Code generated by github.com/gontainer/exporter. DO NOT EDIT.\r\n"+
+				"// Format: 2\r\n"+
+				"\r\n"+
+				"package fixtures\r\n"+
+				"\r\n"+
+				"func fixtureA() string {\r\n"+
+				"    return \"a\\nb\"\r\n"+
+				"}\r\n"+
+				"\r\n"+
+				"var Values = map[string]string{\"a\": fixtureA()}\r\n",
+			withoutChecksum(t, code),
+		)
+	})
+
+	t.Run("Functions", func(t *testing.T) {
+		t.Parallel()
+
+		e := exporter.New(exporter.WithDeclaration(exporter.DeclarationFunc))
+		code, err := e.ExportFile("fixtures", "Numbers", []int{1, 2})
+		require.NoError(t, err)
+		assert.Equal(
+			t,
+			`// Code generated by github.com/gontainer/exporter. DO NOT EDIT.
+// Format: 2
+
+package fixtures
+
+func Numbers() []int {
+	return []int{int(1), int(2)}
+}
+`,
+			withoutChecksum(t, code),
+		)
+
+		code, err = e.ExportFile("fixtures", "Value", nil)
+		require.NoError(t, err)
+		assert.Contains(t, string(code), "func Value() interface{} {\n\treturn nil\n}\n")
+
+		// fmt.Errorf returns *fmt.wrapError, that cannot be named outside the package fmt
+		code, err = e.ExportFile("fixtures", "Err", fmt.Errorf("read: %w", io.EOF))
+		require.NoError(t, err)
+		assert.Contains(t, string(code), "func Err() error {\n\treturn fmt.Errorf(\"read: %w\", io.EOF)\n}\n")
+		vetFile(t, code)
+
+		e = exporter.New(exporter.WithDeclaration(exporter.DeclarationFunc), exporter.WithMapSplitting("fixture"))
+		code, err = e.ExportFile("fixtures", "Values", map[string]int{"a": 1})
+		require.NoError(t, err)
+		assert.Contains(t, string(code), "func Values() map[string]int {\n\treturn map[string]int{\"a\": fixtureA()}\n}\n")
+
+		code, err = e.ExportEqualityTest("fixtures", "Values", map[string]int{"a": 1})
+		require.NoError(t, err)
+		assert.Contains(t, string(code), "if !reflect.DeepEqual(Values(), expected) {")
+
+		code, err = e.ExportShapeTest("fixtures", "Values", map[string]int{"a": 1})
+		require.NoError(t, err)
+		assert.Contains(t, string(code), "if len(Values()) != 1 {")
+	})
+
+	t.Run("Copy accessors", func(t *testing.T) {
+		t.Parallel()
+
+		e := exporter.New(exporter.WithCopyAccessors("Copy"))
+		code, err := e.ExportFile("fixtures", "Numbers", []int{1, 2})
+		require.NoError(t, err)
+		assert.Equal(
+			t,
+			`// Code generated by github.com/gontainer/exporter. DO NOT EDIT.
+// Format: 2
+
+package fixtures
+
+func NumbersCopy() []int {
+	return []int{int(1), int(2)}
+}
+
+var Numbers = NumbersCopy()
+`,
+			withoutChecksum(t, code),
+		)
+
+		// the accessor returns the static type of *fmt.wrapError
+		code, err = e.ExportFile("fixtures", "Err", fmt.Errorf("read: %w", io.EOF))
+		require.NoError(t, err)
+		assert.Contains(t, string(code), "func ErrCopy() error {\n\treturn fmt.Errorf(\"read: %w\", io.EOF)\n}\n")
+		vetFile(t, code)
+
+		e = exporter.New(exporter.WithCopyAccessors("Copy"), exporter.WithMapSplitting("Values"))
+		code, err = e.ExportFile("fixtures", "Values", map[string]int{"copy": 1})
+		require.NoError(t, err)
+		assert.Contains(t, string(code), "func ValuesCopy2() int {")
+		assert.Contains(t, string(code), "return map[string]int{\"copy\": ValuesCopy2()}")
+
+		// functions return copies anyway
+		e = exporter.New(exporter.WithCopyAccessors("Copy"), exporter.WithDeclaration(exporter.DeclarationFunc))
+		code, err = e.ExportFile("fixtures", "Numbers", []int{1, 2})
+		require.NoError(t, err)
+		assert.NotContains(t, string(code), "NumbersCopy")
+
+		_, err = exporter.New(exporter.WithCopyAccessors("-")).ExportFile("fixtures", "Numbers", []int{1, 2})
+		assert.EqualError(t, err, `"Numbers-" is not a valid identifier`)
+	})
+
+	t.Run("Typed accessors", func(t *testing.T) {
+		t.Parallel()
+
+		e := exporter.New(exporter.WithTypedAccessors("At"))
+		code, err := e.ExportFile("fixtures", "Values", []any{1, "a"})
+		require.NoError(t, err)
+		assert.Equal(
+			t,
+			`// Code generated by github.com/gontainer/exporter. DO NOT EDIT.
+// Format: 2
+
+package fixtures
+
+var Values = []interface{}{int(1), "a"}
+
+func ValuesAt[T any](i int) T {
+	return Values[i].(T)
+}
+`,
+			withoutChecksum(t, code),
+		)
+
+		e = exporter.New(exporter.WithTypedAccessors("At"), exporter.WithDeclaration(exporter.DeclarationFunc))
+		code, err = e.ExportFile("fixtures", "Values", [1]any{1})
+		require.NoError(t, err)
+		assert.Contains(t, string(code), "func ValuesAt[T any](i int) T {\n\treturn Values()[i].(T)\n}\n")
+
+		// only elements of interface types require assertions
+		code, err = exporter.New(exporter.WithTypedAccessors("At")).ExportFile("fixtures", "Numbers", []int{1, 2})
+		require.NoError(t, err)
+		assert.NotContains(t, string(code), "NumbersAt")
+
+		_, err = exporter.New(exporter.WithTypedAccessors("-")).ExportFile("fixtures", "Values", []any{1})
+		assert.EqualError(t, err, `"Values-" is not a valid identifier`)
+	})
+
+	t.Run("Errors", func(t *testing.T) {
+		t.Parallel()
+
+		_, err := exporter.ExportFile("my-pkg", "Numbers", nil)
+		assert.EqualError(t, err, `"my-pkg" is not a valid identifier`)
+
+		_, err = exporter.ExportFile("fixtures", "Numbers", make(chan int))
+		assert.EqualError(t, err, `type chan int is not supported`)
+
+		_, err = exporter.New(exporter.WithBackend(exporter.JSONBackend())).ExportFile("fixtures", "Numbers", nil)
+		assert.EqualError(t, err, `ExportFile requires the GO backend`)
+	})
+}
@@ -0,0 +1,99 @@
+// Copyright (c) 2023–present Bartłomiej Krukowski
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is furnished
+// to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package exporter
+
+import (
+	"fmt"
+	"os"
+	"reflect"
+	"strings"
+)
+
+//nolint:gochecknoglobals
+var fileModeType = reflect.TypeOf(os.FileMode(0))
+
+// fileModeBits contains names of the bits of os.FileMode, the most significant bits go first.
+//
+//nolint:gochecknoglobals
+var fileModeBits = []struct {
+	name string
+	bit  os.FileMode
+}{
+	{name: "ModeDir", bit: os.ModeDir},
+	{name: "ModeAppend", bit: os.ModeAppend},
+	{name: "ModeExclusive", bit: os.ModeExclusive},
+	{name: "ModeTemporary", bit: os.ModeTemporary},
+	{name: "ModeSymlink", bit: os.ModeSymlink},
+	{name: "ModeDevice", bit: os.ModeDevice},
+	{name: "ModeNamedPipe", bit: os.ModeNamedPipe},
+	{name: "ModeSocket", bit: os.ModeSocket},
+	{name: "ModeSetuid", bit: os.ModeSetuid},
+	{name: "ModeSetgid", bit: os.ModeSetgid},
+	{name: "ModeCharDevice", bit: os.ModeCharDevice},
+	{name: "ModeSticky", bit: os.ModeSticky},
+	{name: "ModeIrregular", bit: os.ModeIrregular},
+}
+
+// fileModeExporter exports os.FileMode (fs.FileMode since GO 1.16) as OR-ed constants and octal permissions, e.g.:
+//
+//	fs.ModeDir | 0o755
+//	fs.FileMode(0o644)
+//
+// The package of the constants is the package of the type, so the generated code does not require additional imports.
+type fileModeExporter struct{}
+
+func (fileModeExporter) export(v any) (string, error) {
+	m := v.(os.FileMode) //nolint:forcetypeassert
+	pkg := strings.SplitN(fileModeType.String(), ".", 2)[0]
+
+	parts := make([]string, 0, len(fileModeBits)+1)
+
+	for _, b := range fileModeBits {
+		if m&b.bit != 0 {
+			parts = append(parts, pkg+"."+b.name)
+			m &^= b.bit
+		}
+	}
+
+	if m&^os.ModePerm != 0 {
+		// bits without names
+		parts = append(parts, fmt.Sprintf("%s(%#x)", fileModeType.String(), uint32(m&^os.ModePerm)))
+	}
+
+	perm := "0"
+	if m&os.ModePerm != 0 {
+		perm = fmt.Sprintf("0o%o", uint32(m&os.ModePerm))
+	}
+
+	switch {
+	case len(parts) == 0:
+		// an untyped constant would become an int in interfaces
+		return fileModeType.String() + "(" + perm + ")", nil
+	case perm != "0":
+		parts = append(parts, perm)
+	}
+
+	return strings.Join(parts, " | "), nil
+}
+
+func (fileModeExporter) supports(v any) bool {
+	return reflect.TypeOf(v) == fileModeType
+}
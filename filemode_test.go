@@ -0,0 +1,95 @@
+// Copyright (c) 2023–present Bartłomiej Krukowski
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is furnished
+// to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+//go:build go1.16
+// +build go1.16
+
+// since GO 1.16 os.FileMode is an alias of fs.FileMode
+
+package exporter_test
+
+import (
+	"os"
+	"testing"
+
+	"github.com/gontainer/exporter"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestExport_fileMode(t *testing.T) {
+	t.Parallel()
+
+	scenarios := []struct {
+		name   string
+		input  any
+		output string
+	}{
+		{
+			name:   "Zero value",
+			input:  os.FileMode(0),
+			output: `fs.FileMode(0)`,
+		},
+		{
+			name:   "Permissions",
+			input:  os.FileMode(0o644),
+			output: `fs.FileMode(0o644)`,
+		},
+		{
+			name:   "Directory",
+			input:  os.ModeDir | 0o755,
+			output: `fs.ModeDir | 0o755`,
+		},
+		{
+			name:   "Multiple bits",
+			input:  os.ModeSymlink | os.ModeSetuid | os.ModeSticky,
+			output: `fs.ModeSymlink | fs.ModeSetuid | fs.ModeSticky`,
+		},
+		{
+			name:   "Unnamed bits",
+			input:  os.ModeDir | 1<<12 | 0o700,
+			output: `fs.ModeDir | fs.FileMode(0x1000) | 0o700`,
+		},
+		{
+			name:   "Slice",
+			input:  []os.FileMode{0o600},
+			output: `[]fs.FileMode{fs.FileMode(0o600)}`,
+		},
+		{
+			name:   "Empty slice",
+			input:  []os.FileMode{},
+			output: `make([]fs.FileMode, 0)`,
+		},
+		{
+			name:   "Interface",
+			input:  []any{os.ModeDir},
+			output: `[]interface{}{fs.ModeDir}`,
+		},
+	}
+
+	for _, s := range scenarios {
+		s := s
+
+		t.Run(s.name, func(t *testing.T) {
+			t.Parallel()
+
+			assert.Equal(t, s.output, exporter.MustExport(s.input))
+		})
+	}
+}
@@ -0,0 +1,437 @@
+// Copyright (c) 2023–present Bartłomiej Krukowski
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is furnished
+// to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package exporter
+
+import (
+	"fmt"
+	"reflect"
+	"strings"
+	"unicode"
+)
+
+// WithIncludedPaths limits the exported value to the sub-trees addressed by the given patterns, e.g.:
+//
+//	exporter.WithIncludedPaths("Users[*].ID")
+//
+// Patterns use the format of ParsePath extended by the following wildcards, the leading dot is optional:
+//
+//	.*  any field
+//	[*] any index or key
+//	**  any number of steps, including zero, e.g. **.Metadata
+//
+// Fields that are not included are zeroed, elements of slices and entries of maps are removed.
+// Structs, slices, arrays and maps left without included descendants are removed as well.
+// The option can be used multiple times, each call adds patterns. Invalid patterns are reported by Exporter.Export.
+func WithIncludedPaths(patterns ...string) Option {
+	return func(c *config) {
+		c.includedPaths = append(c.includedPaths, patterns...)
+	}
+}
+
+// WithExcludedPaths removes the sub-trees addressed by the given patterns from the exported value,
+// see WithIncludedPaths for the format of patterns. Exclusions take precedence over inclusions.
+func WithExcludedPaths(patterns ...string) Option {
+	return func(c *config) {
+		c.excludedPaths = append(c.excludedPaths, patterns...)
+	}
+}
+
+type patternStepKind int
+
+const (
+	patternLiteral patternStepKind = iota
+	patternAnyField
+	patternAnyElement
+	patternAnyDepth
+)
+
+type patternStep struct {
+	kind patternStepKind
+	step Step
+}
+
+func (p patternStep) matches(s Step) bool {
+	switch p.kind {
+	case patternLiteral:
+		return p.step == s
+	case patternAnyField:
+		return s.Kind == StepField
+	case patternAnyElement:
+		return s.Kind == StepIndex || s.Kind == StepKey
+	case patternAnyDepth:
+		return true
+	}
+
+	return false
+}
+
+// pathPattern is a parsed pattern of WithIncludedPaths and WithExcludedPaths.
+type pathPattern []patternStep
+
+func parsePathPattern(s string) (pathPattern, error) {
+	rest := s
+	if rest != "" && (rest[0] == '_' || unicode.IsLetter(rune(rest[0]))) {
+		rest = "." + rest
+	}
+
+	result := make(pathPattern, 0)
+
+	for rest != "" {
+		switch {
+		case strings.HasPrefix(rest, ".**"):
+			result = append(result, patternStep{kind: patternAnyDepth, step: Step{}}) //nolint:exhaustruct
+			rest = rest[3:]
+		case strings.HasPrefix(rest, "**"):
+			result = append(result, patternStep{kind: patternAnyDepth, step: Step{}}) //nolint:exhaustruct
+			rest = rest[2:]
+		case strings.HasPrefix(rest, ".*"):
+			result = append(result, patternStep{kind: patternAnyField, step: Step{}}) //nolint:exhaustruct
+			rest = rest[2:]
+		case strings.HasPrefix(rest, "[*]"):
+			result = append(result, patternStep{kind: patternAnyElement, step: Step{}}) //nolint:exhaustruct
+			rest = rest[3:]
+		default:
+			i := nextWildcard(rest)
+
+			p, err := ParsePath(rest[:i])
+			if err != nil {
+				return nil, fmt.Errorf("invalid path pattern %q: %w", s, err)
+			}
+
+			for _, step := range p {
+				result = append(result, patternStep{kind: patternLiteral, step: step})
+			}
+
+			rest = rest[i:]
+		}
+	}
+
+	return result, nil
+}
+
+// nextWildcard returns the offset of the first wildcard outside quoted keys, or the length of the given pattern.
+func nextWildcard(s string) int {
+	var (
+		quote  byte
+		escape bool
+	)
+
+	for i := 0; i < len(s); i++ {
+		c := s[i]
+
+		if quote != 0 {
+			switch {
+			case escape:
+				escape = false
+			case c == '\\' && quote != '`':
+				escape = true
+			case c == quote:
+				quote = 0
+			}
+
+			continue
+		}
+
+		switch {
+		case c == '"' || c == '\'' || c == '`':
+			quote = c
+		case strings.HasPrefix(s[i:], "**"), strings.HasPrefix(s[i:], ".*"), strings.HasPrefix(s[i:], "[*]"):
+			return i
+		}
+	}
+
+	return len(s)
+}
+
+// matches reports whether the pattern addresses the given path.
+func (p pathPattern) matches(path Path) bool {
+	return matchPattern(p, path, false)
+}
+
+// leadsTo reports whether the pattern addresses the given path or any of its descendants.
+func (p pathPattern) leadsTo(path Path) bool {
+	return matchPattern(p, path, true)
+}
+
+func matchPattern(p pathPattern, path Path, prefix bool) bool {
+	if len(p) > 0 && p[0].kind == patternAnyDepth {
+		return matchPattern(p[1:], path, prefix) || (len(path) > 0 && matchPattern(p, path[1:], prefix))
+	}
+
+	if len(path) == 0 {
+		return len(p) == 0 || prefix
+	}
+
+	if len(p) == 0 || !p[0].matches(path[0]) {
+		return false
+	}
+
+	return matchPattern(p[1:], path[1:], prefix)
+}
+
+// pathFilter removes the sub-trees of values that are not included or are excluded,
+// see WithIncludedPaths and WithExcludedPaths.
+type pathFilter struct {
+	include []pathPattern
+	exclude []pathPattern
+	// keys exports keys of maps, see KeyStep
+	keys exporter
+}
+
+func newPathFilter(include []string, exclude []string, keys exporter) (*pathFilter, error) {
+	result := pathFilter{
+		include: make([]pathPattern, 0, len(include)),
+		exclude: make([]pathPattern, 0, len(exclude)),
+		keys:    keys,
+	}
+
+	for _, s := range include {
+		p, err := parsePathPattern(s)
+		if err != nil {
+			return nil, err
+		}
+
+		result.include = append(result.include, p)
+	}
+
+	for _, s := range exclude {
+		p, err := parsePathPattern(s)
+		if err != nil {
+			return nil, err
+		}
+
+		result.exclude = append(result.exclude, p)
+	}
+
+	return &result, nil
+}
+
+// decide reports whether the node of the given path is kept, and whether it is included as a whole.
+func (f *pathFilter) decide(path Path, included bool) (keep bool, whole bool) {
+	for _, p := range f.exclude {
+		if p.matches(path) {
+			return false, false
+		}
+	}
+
+	if included || len(f.include) == 0 {
+		return true, true
+	}
+
+	for _, p := range f.include {
+		if p.matches(path) {
+			return true, true
+		}
+	}
+
+	for _, p := range f.include {
+		if p.leadsTo(path) {
+			return true, false
+		}
+	}
+
+	return false, false
+}
+
+// apply returns a copy of the given value without the removed sub-trees.
+func (f *pathFilter) apply(v any) (any, error) {
+	val := reflect.ValueOf(v)
+	if !val.IsValid() {
+		return v, nil
+	}
+
+	r, keep, err := filtering{pathFilter: f, pointers: make(map[uintptr]bool)}.filter(val, make(Path, 0), false)
+	if err != nil {
+		return nil, err
+	}
+
+	if !keep {
+		return reflect.Zero(val.Type()).Interface(), nil
+	}
+
+	return r.Interface(), nil
+}
+
+// filtering holds the state of a single pathFilter.apply.
+type filtering struct {
+	*pathFilter
+	// pointers contains the ancestors of the current node to avoid infinite recursion
+	pointers map[uintptr]bool
+}
+
+//nolint:cyclop,exhaustive
+func (f filtering) filter(v reflect.Value, path Path, included bool) (reflect.Value, bool, error) {
+	keep, whole := f.decide(path, included)
+	if !keep {
+		return v, false, nil
+	}
+
+	// nothing below can be removed
+	if whole && len(f.exclude) == 0 {
+		return v, true, nil
+	}
+
+	switch v.Kind() {
+	case reflect.Interface:
+		if v.IsNil() {
+			return v, whole, nil
+		}
+
+		return f.filter(v.Elem(), path, whole)
+
+	case reflect.Ptr:
+		if v.IsNil() || f.pointers[v.Pointer()] {
+			return v, whole, nil
+		}
+
+		f.pointers[v.Pointer()] = true
+		defer delete(f.pointers, v.Pointer())
+
+		elem, keep, err := f.filter(v.Elem(), path, whole)
+		if err != nil || !keep {
+			return v, keep, err
+		}
+
+		r := reflect.New(v.Type().Elem())
+		r.Elem().Set(elem)
+
+		return r, true, nil
+
+	case reflect.Struct:
+		r := reflect.New(v.Type()).Elem()
+		r.Set(v)
+
+		kept := 0
+
+		for i := 0; i < v.NumField(); i++ {
+			field := v.Type().Field(i)
+			if field.PkgPath != "" {
+				continue
+			}
+
+			fv, keep, err := f.filter(v.Field(i), append(path[:len(path):len(path)], FieldStep(field.Name)), whole)
+			if err != nil {
+				return v, false, err
+			}
+
+			if keep {
+				kept++
+			} else {
+				fv = reflect.Zero(field.Type)
+			}
+
+			r.Field(i).Set(fv)
+		}
+
+		return r, whole || kept > 0, nil
+
+	case reflect.Slice, reflect.Array:
+		if v.Kind() == reflect.Slice && v.IsNil() {
+			return v, whole, nil
+		}
+
+		return f.filterElements(v, path, whole)
+
+	case reflect.Map:
+		if v.IsNil() {
+			return v, whole, nil
+		}
+
+		return f.filterMap(v, path, whole)
+	}
+
+	return v, whole, nil
+}
+
+func (f filtering) filterElements(v reflect.Value, path Path, included bool) (reflect.Value, bool, error) {
+	var r reflect.Value
+	if v.Kind() == reflect.Slice {
+		r = reflect.MakeSlice(v.Type(), 0, v.Len())
+	} else {
+		r = reflect.New(v.Type()).Elem()
+	}
+
+	kept := 0
+
+	for i := 0; i < v.Len(); i++ {
+		elem, keep, err := f.filter(v.Index(i), append(path[:len(path):len(path)], IndexStep(i)), included)
+		if err != nil {
+			return v, false, err
+		}
+
+		if !keep {
+			continue
+		}
+
+		kept++
+
+		if v.Kind() == reflect.Array {
+			r.Index(i).Set(elem)
+		} else {
+			r = reflect.Append(r, elem)
+		}
+	}
+
+	return r, included || kept > 0, nil
+}
+
+func (f filtering) filterMap(v reflect.Value, path Path, included bool) (reflect.Value, bool, error) {
+	r := reflect.MakeMapWithSize(v.Type(), v.Len())
+	iter := v.MapRange()
+
+	for iter.Next() {
+		k, err := f.keys.export(iter.Key().Interface())
+		if err != nil {
+			return v, false, err //nolint:wrapcheck
+		}
+
+		elem, keep, err := f.filter(iter.Value(), append(path[:len(path):len(path)], KeyStep(k)), included)
+		if err != nil {
+			return v, false, err
+		}
+
+		if keep {
+			r.SetMapIndex(iter.Key(), elem)
+		}
+	}
+
+	return r, included || r.Len() > 0, nil
+}
+
+// pathFilterExporter removes sub-trees of exported values, see WithIncludedPaths and WithExcludedPaths.
+type pathFilterExporter struct {
+	exporter
+	filter *pathFilter
+	err    error
+}
+
+func (p pathFilterExporter) export(v any) (string, error) {
+	if p.err != nil {
+		return "", p.err
+	}
+
+	filtered, err := p.filter.apply(v)
+	if err != nil {
+		return "", err
+	}
+
+	return p.exporter.export(filtered) //nolint:wrapcheck
+}
@@ -0,0 +1,218 @@
+// Copyright (c) 2023–present Bartłomiej Krukowski
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is furnished
+// to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package exporter_test
+
+import (
+	"testing"
+
+	"github.com/gontainer/exporter"
+	"github.com/stretchr/testify/assert"
+)
+
+type filteredUser struct {
+	ID       int
+	Name     string
+	Metadata map[string]string
+}
+
+type filteredDirectory struct {
+	Users    []filteredUser
+	Groups   map[string][]int
+	Metadata map[string]string
+	Owner    *filteredUser
+}
+
+func TestWithIncludedPaths(t *testing.T) {
+	t.Parallel()
+
+	input := filteredDirectory{
+		Users: []filteredUser{
+			{ID: 1, Name: "Jane", Metadata: map[string]string{"role": "admin"}},
+			{ID: 2, Name: "John", Metadata: nil},
+		},
+		Groups:   map[string][]int{"admins": {1}, "users": {1, 2}},
+		Metadata: map[string]string{"version": "1"},
+		Owner:    &filteredUser{ID: 1, Name: "Jane", Metadata: map[string]string{"role": "admin"}},
+	}
+
+	//nolint:exhaustruct
+	scenarios := []struct {
+		name    string
+		input   any
+		output  string
+		error   string
+		options []exporter.Option
+	}{
+		{
+			name:  "Include fields of all elements",
+			input: input,
+			output: `exporter_test.filteredDirectory{Users: []exporter_test.filteredUser{` +
+				`exporter_test.filteredUser{ID: int(1)}, exporter_test.filteredUser{ID: int(2)}}}`,
+			options: []exporter.Option{exporter.WithIncludedPaths("Users[*].ID")},
+		},
+		{
+			name:    "Include an entry of a map",
+			input:   input,
+			output:  `exporter_test.filteredDirectory{Groups: map[string][]int{"users": []int{int(1), int(2)}}}`,
+			options: []exporter.Option{exporter.WithIncludedPaths(`.Groups["users"]`)},
+		},
+		{
+			name:    "Include an element",
+			input:   input,
+			output:  `exporter_test.filteredDirectory{Groups: map[string][]int{"users": []int{int(2)}}}`,
+			options: []exporter.Option{exporter.WithIncludedPaths(`Groups[*][1]`)},
+		},
+		{
+			name:    "Include through pointers",
+			input:   input,
+			output:  `exporter_test.filteredDirectory{Owner: &exporter_test.filteredUser{Name: "Jane"}}`,
+			options: []exporter.Option{exporter.WithIncludedPaths("Owner.Name")},
+		},
+		{
+			name:    "Include any field",
+			input:   []any{filteredUser{ID: 1, Name: "Jane", Metadata: nil}, 5},
+			output:  `[]interface{}{exporter_test.filteredUser{ID: int(1), Name: "Jane"}}`,
+			options: []exporter.Option{exporter.WithIncludedPaths("[*].*")},
+		},
+		{
+			name:  "Many patterns",
+			input: input,
+			output: `exporter_test.filteredDirectory{Metadata: map[string]string{"version": "1"}, ` +
+				`Owner: &exporter_test.filteredUser{ID: int(1)}}`,
+			options: []exporter.Option{exporter.WithIncludedPaths("Metadata"), exporter.WithIncludedPaths("Owner.ID")},
+		},
+		{
+			name:    "Include and exclude",
+			input:   input,
+			output:  `exporter_test.filteredDirectory{Owner: &exporter_test.filteredUser{Name: "Jane"}}`,
+			options: []exporter.Option{exporter.WithIncludedPaths("Owner"), exporter.WithExcludedPaths("**.ID", "**.Metadata")},
+		},
+		{
+			name:    "Invalid pattern",
+			input:   input,
+			error:   `invalid path pattern "Users[": invalid path ".Users[": unclosed bracket at the position 6`,
+			options: []exporter.Option{exporter.WithIncludedPaths("Users[")},
+		},
+	}
+
+	for _, s := range scenarios {
+		s := s
+
+		t.Run(s.name, func(t *testing.T) {
+			t.Parallel()
+
+			output, err := exporter.New(s.options...).Export(s.input)
+			if s.error != "" {
+				assert.EqualError(t, err, s.error)
+				assert.Empty(t, output)
+
+				return
+			}
+
+			assert.NoError(t, err)
+			assert.Equal(t, s.output, output)
+		})
+	}
+}
+
+func TestWithExcludedPaths(t *testing.T) {
+	t.Parallel()
+
+	input := filteredDirectory{
+		Users: []filteredUser{
+			{ID: 1, Name: "Jane", Metadata: map[string]string{"role": "admin"}},
+		},
+		Groups:   map[string][]int{"admins": {1}, "users": {1, 2}},
+		Metadata: map[string]string{"version": "1"},
+		Owner:    nil,
+	}
+
+	scenarios := []struct {
+		name     string
+		input    any
+		output   string
+		patterns []string
+	}{
+		{
+			name:  "Metadata at any depth",
+			input: input,
+			output: `exporter_test.filteredDirectory{` +
+				`Users: []exporter_test.filteredUser{exporter_test.filteredUser{ID: int(1), Name: "Jane"}}, ` +
+				`Groups: map[string][]int{"admins": []int{int(1)}, "users": []int{int(1), int(2)}}}`,
+			patterns: []string{"**.Metadata"},
+		},
+		{
+			name:     "Entries of maps",
+			input:    map[int]string{1: "one", 2: "two"},
+			output:   `map[int]string{int(2): "two"}`,
+			patterns: []string{"[int(1)]"},
+		},
+		{
+			name:     "Elements of arrays",
+			input:    [3]int{1, 2, 3},
+			output:   `[3]int{int(1), int(0), int(3)}`,
+			patterns: []string{"[1]"},
+		},
+		{
+			name:     "Keys with wildcards",
+			input:    map[string]int{"**": 1, "[*]": 2},
+			output:   `map[string]int{"[*]": int(2)}`,
+			patterns: []string{`["**"]`},
+		},
+		{
+			name:     "Root",
+			input:    []int{1},
+			output:   `([]int)(nil)`,
+			patterns: []string{""},
+		},
+		{
+			name:     "Nil",
+			input:    nil,
+			output:   `nil`,
+			patterns: []string{"**"},
+		},
+	}
+
+	for _, s := range scenarios {
+		s := s
+
+		t.Run(s.name, func(t *testing.T) {
+			t.Parallel()
+
+			assert.Equal(t, s.output, exporter.New(exporter.WithExcludedPaths(s.patterns...)).MustExport(s.input))
+		})
+	}
+}
+
+func TestWithExcludedPaths_cycles(t *testing.T) {
+	t.Parallel()
+
+	type node struct {
+		Next *node
+		Name string
+	}
+
+	n := &node{Next: nil, Name: "a"}
+	n.Next = n
+
+	_, err := exporter.New(exporter.WithExcludedPaths(".Name")).Export(n)
+	assert.Error(t, err)
+}
@@ -0,0 +1,172 @@
+// Copyright (c) 2023–present Bartłomiej Krukowski
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is furnished
+// to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package exporter
+
+import (
+	"math"
+	"reflect"
+	"strconv"
+)
+
+//nolint:gochecknoglobals
+var integerTypes = map[reflect.Kind]reflect.Type{
+	reflect.Int:    reflect.TypeOf(int(0)),
+	reflect.Int8:   reflect.TypeOf(int8(0)),
+	reflect.Int16:  reflect.TypeOf(int16(0)),
+	reflect.Int32:  reflect.TypeOf(int32(0)),
+	reflect.Int64:  reflect.TypeOf(int64(0)),
+	reflect.Uint:   reflect.TypeOf(uint(0)),
+	reflect.Uint8:  reflect.TypeOf(uint8(0)),
+	reflect.Uint16: reflect.TypeOf(uint16(0)),
+	reflect.Uint32: reflect.TypeOf(uint32(0)),
+	reflect.Uint64: reflect.TypeOf(uint64(0)),
+}
+
+// WithFloatPrecision rounds exported floats to n decimal places, e.g. float64(0.12345) is exported
+// as float64(0.12) for n = 2. It helps to produce stable fixtures from measured values.
+// Trailing zeros are not exported. A negative value disables rounding, it is the default behavior.
+func WithFloatPrecision(n int) Option {
+	return func(c *config) {
+		c.floatPrecision = n
+	}
+}
+
+// formatFloat returns the shortest representation of the given float rounded to the given number
+// of decimal places. A negative precision disables rounding.
+func formatFloat(f float64, precision int, bitSize int) string {
+	if precision >= 0 {
+		rounded, err := strconv.ParseFloat(strconv.FormatFloat(f, 'f', precision, bitSize), bitSize)
+		if err == nil {
+			f = rounded
+		}
+
+		// avoid "-0"
+		if f == 0 {
+			f = 0
+		}
+	}
+
+	return strconv.FormatFloat(f, 'f', -1, bitSize)
+}
+
+// formatFloat32 works like formatFloat for float32 values. The output does not depend on the architecture:
+// strconv does not use floating-point instructions of the CPU, and the result of rounding is converted
+// explicitly to float32, so the compiler cannot keep it in a wider register or fuse it with other operations.
+// Widening float32 to float64 is exact, so the shortest representation of the float32 value is preserved.
+func formatFloat32(f float32, precision int) string {
+	if precision >= 0 {
+		rounded, err := strconv.ParseFloat(strconv.FormatFloat(float64(f), 'f', precision, 32), 32)
+		if err == nil {
+			f = float32(rounded)
+		}
+
+		// avoid "-0"
+		if f == 0 {
+			f = 0
+		}
+	}
+
+	return strconv.FormatFloat(float64(f), 'f', -1, 32)
+}
+
+// WithFloatRatios exports floats that are equal to ratios of integers with denominators up to maxDenominator
+// as divisions, if the division is shorter than the decimal representation, e.g.:
+//
+//	float64(1)/float64(3) // instead of float64(0.3333333333333333)
+//	float64(0.5)          // the decimal representation is shorter than float64(1)/float64(2)
+//
+// The division of constants is exact, and the result is rounded to the nearest float like the original value,
+// so the result is exactly the exported value. Other floats are exported as usual.
+// The search takes time proportional to maxDenominator for each float, so keep it small, e.g. 1000.
+// Values lower than 2 disable ratios, it is the default behavior. WithFloatPrecision disables ratios too.
+// Only the GO backend exports ratios.
+func WithFloatRatios(maxDenominator int) Option {
+	return func(c *config) {
+		c.floatRatios = maxDenominator
+	}
+}
+
+// floatRatio returns the integers whose ratio rounded to the float of the given size equals the given float.
+// It returns the smallest denominator up to the given maximum, integral floats are not ratios.
+func floatRatio(f float64, bitSize int, maxDenominator int) (int64, int64, bool) {
+	if math.IsNaN(f) || math.IsInf(f, 0) || math.Trunc(f) == f {
+		return 0, 0, false
+	}
+
+	const maxExact = 1 << 53 // integers greater than this cannot be represented exactly by float64
+
+	for q := 2; q <= maxDenominator; q++ {
+		p := math.Round(f * float64(q))
+		if math.Abs(p) > maxExact {
+			return 0, 0, false
+		}
+
+		if (bitSize == 32 && float32(p)/float32(q) == float32(f)) || (bitSize == 64 && p/float64(q) == f) {
+			return int64(p), int64(q), true
+		}
+	}
+
+	return 0, 0, false
+}
+
+// WithIntegralFloats exports floats with no fractional part as integers of the given kind,
+// e.g. []any{float64(7), float64(1.5)} is exported as []interface{}{int(7), float64(1.5)} for reflect.Int.
+// It makes fixtures derived from decoded JSON look like the original data.
+//
+// Only values stored in interfaces, e.g. elements of []any or fields of the type any, are converted,
+// since values of other types must keep their types to compile. Floats that do not fit the given type
+// are not converted. Kinds other than integers disable the conversion, it is the default behavior.
+func WithIntegralFloats(kind reflect.Kind) Option {
+	return func(c *config) {
+		c.integralFloats = integerTypes[kind]
+	}
+}
+
+// integralFloat returns the value that is exported in place of the given value stored in a variable
+// of the given static type, see WithIntegralFloats.
+func integralFloat(intType reflect.Type, static reflect.Type, v any) any {
+	if intType == nil || static.Kind() != reflect.Interface {
+		return v
+	}
+
+	var f float64
+
+	switch x := v.(type) {
+	case float64:
+		f = x
+	case float32:
+		f = float64(x)
+	default:
+		return v
+	}
+
+	if math.IsInf(f, 0) || math.Trunc(f) != f {
+		return v
+	}
+
+	i := reflect.ValueOf(f).Convert(intType)
+	// out of range
+	if i.Convert(reflect.TypeOf(f)).Float() != f {
+		return v
+	}
+
+	return i.Interface()
+}
@@ -0,0 +1,264 @@
+// Copyright (c) 2023–present Bartłomiej Krukowski
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is furnished
+// to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package exporter_test
+
+import (
+	"container/list"
+	"encoding/json"
+	"math"
+	"reflect"
+	"testing"
+
+	"github.com/gontainer/exporter"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestWithFloatPrecision(t *testing.T) {
+	t.Parallel()
+
+	scenarios := []struct {
+		name      string
+		precision int
+		input     any
+		output    string
+	}{
+		{
+			name:      "Disabled",
+			precision: -1,
+			input:     0.123456789,
+			output:    `float64(0.123456789)`,
+		},
+		{
+			name:      "float64",
+			precision: 2,
+			input:     0.125001,
+			output:    `float64(0.13)`,
+		},
+		{
+			name:      "float32",
+			precision: 3,
+			input:     float32(1.23456),
+			output:    `float32(1.235)`,
+		},
+		{
+			name:      "Trailing zeros",
+			precision: 3,
+			input:     1.5,
+			output:    `float64(1.5)`,
+		},
+		{
+			name:      "Zero decimal places",
+			precision: 0,
+			input:     12.7,
+			output:    `float64(13)`,
+		},
+		{
+			name:      "Negative zero",
+			precision: 2,
+			input:     -0.0001,
+			output:    `float64(0)`,
+		},
+		{
+			name:      "Integers",
+			precision: 2,
+			input:     []any{12345, int64(math.MaxInt64)},
+			output:    `[]interface{}{int(12345), int64(9223372036854775807)}`,
+		},
+		{
+			name:      "Nested",
+			precision: 1,
+			input:     map[string]float64{"latency": 12.3456, "score": 0.98},
+			output:    `map[string]float64{"latency": float64(12.3), "score": float64(1)}`,
+		},
+	}
+
+	for _, s := range scenarios {
+		s := s
+
+		t.Run(s.name, func(t *testing.T) {
+			t.Parallel()
+
+			e := exporter.New(exporter.WithFloatPrecision(s.precision))
+			assert.Equal(t, s.output, e.MustExport(s.input))
+		})
+	}
+
+	t.Run("Colliding keys", func(t *testing.T) {
+		t.Parallel()
+
+		e := exporter.New(exporter.WithFloatPrecision(2))
+
+		_, err := e.Export(map[float64]int{1.0001: 1, 1.0002: 2})
+		assert.EqualError(
+			t,
+			err,
+			`cannot export (map[float64]int)[float64(1)]: distinct keys are exported to the same code`,
+		)
+
+		_, err = e.Export(map[any]int{1.0001: 1, 1.0002: 2, "a": 3})
+		assert.EqualError(
+			t,
+			err,
+			`cannot export (map[interface{}]int)[float64(1)]: distinct keys are exported to the same code`,
+		)
+
+		code, err := e.Export(map[float64]int{1.001: 1, 1.01: 2})
+		assert.NoError(t, err)
+		assert.Equal(t, `map[float64]int{float64(1): int(1), float64(1.01): int(2)}`, code)
+	})
+}
+
+func TestWithIntegralFloats(t *testing.T) {
+	t.Parallel()
+
+	var decoded any
+	require.NoError(t, json.Unmarshal([]byte(`{"id":7,"score":1.5,"tags":[1,2],"big":1e20}`), &decoded))
+
+	l := list.New()
+	l.PushBack(float32(2))
+
+	scenarios := []struct {
+		name   string
+		kind   reflect.Kind
+		input  any
+		output string
+	}{
+		{
+			name:  "Decoded JSON",
+			kind:  reflect.Int,
+			input: decoded,
+			output: `map[string]interface{}{"big": float64(100000000000000000000), "id": int(7), "score": float64(1.5), ` +
+				`"tags": []interface{}{int(1), int(2)}}`,
+		},
+		{
+			name:   "Typed values",
+			kind:   reflect.Int,
+			input:  []float64{7},
+			output: `[]float64{float64(7)}`,
+		},
+		{
+			name:   "Struct fields",
+			kind:   reflect.Uint8,
+			input:  structConfig{Extra: float32(255)},
+			output: `exporter_test.structConfig{Extra: uint8(255)}`,
+		},
+		{
+			name:   "Out of range",
+			kind:   reflect.Uint8,
+			input:  []any{256.0, -1.0},
+			output: `[]interface{}{float64(256), float64(-1)}`,
+		},
+		{
+			name:   "List",
+			kind:   reflect.Int64,
+			input:  l,
+			output: `func() *list.List { l := list.New(); l.PushBack(int64(2)); return l }()`,
+		},
+		{
+			name:   "Disabled",
+			kind:   reflect.String,
+			input:  []any{7.0},
+			output: `[]interface{}{float64(7)}`,
+		},
+	}
+
+	for _, s := range scenarios {
+		s := s
+
+		t.Run(s.name, func(t *testing.T) {
+			t.Parallel()
+
+			e := exporter.New(exporter.WithIntegralFloats(s.kind))
+			assert.Equal(t, s.output, e.MustExport(s.input))
+		})
+	}
+}
+
+// TestExport_float32Golden verifies that float32 values are exported to the same code on all architectures.
+// Inputs are defined by their bits, so they do not depend on the floating-point arithmetic of the platform.
+func TestExport_float32Golden(t *testing.T) {
+	t.Parallel()
+
+	scenarios := []struct {
+		bits      uint32
+		precision int
+		output    string
+	}{
+		{bits: 0x3dcccccd, precision: -1, output: `float32(0.1)`},
+		{bits: 0x3eaaaaab, precision: -1, output: `float32(0.33333334)`},
+		{bits: 0xc2f6e979, precision: -1, output: `float32(-123.456)`},
+		{bits: 0x4b800001, precision: -1, output: `float32(16777218)`},
+		{bits: 0x4e6e6b28, precision: -1, output: `float32(1000000000)`},
+		{bits: 0x7f7fffff, precision: -1, output: `float32(340282350000000000000000000000000000000)`},
+		{bits: 0x00800000, precision: -1, output: `float32(0.000000000000000000000000000000000000011754944)`},
+		{bits: 0x00000001, precision: -1, output: `float32(0.000000000000000000000000000000000000000000001)`},
+		{bits: 0x3eaaaaab, precision: 2, output: `float32(0.33)`},
+		{bits: 0xc2f6e979, precision: 1, output: `float32(-123.5)`},
+		{bits: 0x00000001, precision: 3, output: `float32(0)`},
+		{bits: 0x80000000, precision: 0, output: `float32(0)`},
+	}
+
+	for _, s := range scenarios {
+		s := s
+
+		t.Run(s.output, func(t *testing.T) {
+			t.Parallel()
+
+			f := math.Float32frombits(s.bits)
+			e := exporter.New(exporter.WithFloatPrecision(s.precision))
+			assert.Equal(t, s.output, e.MustExport(f))
+			// the conversion from float64 must not change the result
+			assert.Equal(t, s.output, e.MustExport(float32(float64(f))))
+		})
+	}
+}
+
+func TestWithFloatRatios(t *testing.T) {
+	t.Parallel()
+
+	input := []any{1.0 / 3, -2.0 / 7, 0.5, 0.1, 2.0, float32(1) / 3, math.Inf(1), 1.0 / 1009}
+
+	assert.Equal(
+		t,
+		`[]interface{}{float64(1)/float64(3), float64(-2)/float64(7), float64(0.5), float64(0.1), float64(2), `+
+			`float32(0.33333334), float64(+Inf), float64(0.0009910802775024777)}`,
+		exporter.New(exporter.WithFloatRatios(1000)).MustExport(input),
+	)
+
+	// the compiler computes the same values
+	assert.Equal(t, float64(1)/float64(3), input[0])
+	assert.Equal(t, float64(-2)/float64(7), input[1])
+
+	assert.Equal(
+		t,
+		`[]interface{}{F64(1)/F64(3), F64(0.5)}`,
+		exporter.New(
+			exporter.WithFloatRatios(10),
+			exporter.WithNumberTypeNames(map[reflect.Kind]string{reflect.Float64: "F64"}),
+		).MustExport([]any{1.0 / 3, 0.5}),
+	)
+	assert.Equal(
+		t,
+		`[]interface{}{float64(0.3333333333333333)}`,
+		exporter.New(exporter.WithFloatRatios(10), exporter.WithFloatPrecision(16)).MustExport([]any{1.0 / 3}),
+	)
+}
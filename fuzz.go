@@ -0,0 +1,91 @@
+// Copyright (c) 2023–present Bartłomiej Krukowski
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is furnished
+// to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package exporter
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+	"strings"
+)
+
+// ExportFuzzSeeds exports the given seeds of a fuzz test as calls to f.Add, one call per line, e.g.:
+//
+//	f.Add("hello", int(5), []byte("\x00"))
+//	f.Add("world", int(-1), []byte(""))
+//
+// Fuzzing supports only arguments of the following types:
+// string, []byte, bool, float32, float64, and all integer types. Types cannot be named types.
+//
+// See Exporter.ExportFuzzSeeds.
+func ExportFuzzSeeds(seeds ...[]any) (string, error) {
+	return defaultExporter.ExportFuzzSeeds(seeds...)
+}
+
+// ExportFuzzSeeds exports the given seeds of a fuzz test as calls to f.Add, see the function ExportFuzzSeeds.
+func (e *Exporter) ExportFuzzSeeds(seeds ...[]any) (string, error) {
+	if _, ok := e.config.backend.(goBackend); !ok {
+		return "", errors.New("ExportFuzzSeeds requires the GO backend") //nolint:goerr113
+	}
+
+	lines := make([]string, len(seeds))
+
+	for i, seed := range seeds {
+		args := make([]string, len(seed))
+
+		for j, arg := range seed {
+			if !isFuzzType(reflect.TypeOf(arg)) {
+				return "", fmt.Errorf( //nolint:goerr113
+					"cannot export seed %d: argument %d of the type %T is not supported by fuzzing",
+					i,
+					j,
+					arg,
+				)
+			}
+
+			code, err := e.Export(arg)
+			if err != nil {
+				return "", fmt.Errorf("cannot export seed %d: argument %d: %w", i, j, err)
+			}
+
+			args[j] = code
+		}
+
+		lines[i] = "f.Add(" + strings.Join(args, ", ") + ")"
+	}
+
+	return strings.Join(lines, "\n"), nil
+}
+
+func isFuzzType(t reflect.Type) bool {
+	if t == nil || t.PkgPath() != "" {
+		return false
+	}
+
+	switch t.Kind() { //nolint:exhaustive
+	case reflect.String, reflect.Bool:
+		return true
+	case reflect.Slice:
+		return t.Elem().Kind() == reflect.Uint8 && t.Elem().PkgPath() == ""
+	}
+
+	return t.Kind() != reflect.Uintptr && isNumberKind(t.Kind())
+}
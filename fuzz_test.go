@@ -0,0 +1,67 @@
+// Copyright (c) 2023–present Bartłomiej Krukowski
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is furnished
+// to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package exporter_test
+
+import (
+	"testing"
+
+	"github.com/gontainer/exporter"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestExportFuzzSeeds(t *testing.T) {
+	t.Parallel()
+
+	t.Run("OK", func(t *testing.T) {
+		t.Parallel()
+
+		code, err := exporter.ExportFuzzSeeds(
+			[]any{"hello", 5, []byte{0}, true},
+			[]any{"", int8(-1), []byte(nil), 1.5},
+			[]any{},
+		)
+		require.NoError(t, err)
+		assert.Equal(
+			t,
+			`f.Add("hello", int(5), []byte("\x00"), true)`+"\n"+
+				`f.Add("", int8(-1), []byte(""), float64(1.5))`+"\n"+
+				`f.Add()`,
+			code,
+		)
+	})
+
+	t.Run("Unsupported types", func(t *testing.T) {
+		t.Parallel()
+
+		for _, arg := range []any{nil, []int{1}, StructServer{}, uintptr(1), complex(1, 1)} {
+			_, err := exporter.ExportFuzzSeeds([]any{"ok"}, []any{1, arg})
+			assert.Regexp(t, `^cannot export seed 1: argument 1 of the type .* is not supported by fuzzing$`, err)
+		}
+	})
+
+	t.Run("JSON", func(t *testing.T) {
+		t.Parallel()
+
+		_, err := exporter.New(exporter.WithBackend(exporter.JSONBackend())).ExportFuzzSeeds([]any{1})
+		assert.EqualError(t, err, `ExportFuzzSeeds requires the GO backend`)
+	})
+}
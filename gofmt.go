@@ -0,0 +1,55 @@
+// Copyright (c) 2023–present Bartłomiej Krukowski
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is furnished
+// to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package exporter
+
+import (
+	"fmt"
+	"go/format"
+	"strings"
+)
+
+// expressionPrefix turns an expression into a GO file, so it can be parsed or formatted.
+const expressionPrefix = "package p\n\nvar _ = "
+
+// WithGofmt passes the code returned by Exporter.Export through gofmt, e.g.:
+//
+//	float64(1)/float64(3)
+//
+// becomes:
+//
+//	float64(1) / float64(3)
+//
+// It applies to the GO backend only, see WithBackend.
+func WithGofmt(enabled bool) Option {
+	return func(c *config) {
+		c.gofmt = enabled
+	}
+}
+
+// formatExpression formats the given GO expression by gofmt.
+func formatExpression(code string) (string, error) {
+	result, err := format.Source([]byte(expressionPrefix + code))
+	if err != nil {
+		return "", fmt.Errorf("cannot format exported code: %w", err)
+	}
+
+	return strings.TrimSuffix(strings.TrimPrefix(string(result), expressionPrefix), "\n"), nil
+}
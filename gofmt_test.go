@@ -0,0 +1,75 @@
+// Copyright (c) 2023–present Bartłomiej Krukowski
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is furnished
+// to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package exporter_test
+
+import (
+	"testing"
+
+	"github.com/gontainer/exporter"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestWithGofmt(t *testing.T) {
+	t.Parallel()
+
+	scenarios := []struct {
+		name    string
+		input   any
+		output  string
+		options []exporter.Option
+	}{
+		{
+			name:    "Binary expressions",
+			input:   []float64{1.0 / 3},
+			output:  `[]float64{float64(1) / float64(3)}`,
+			options: []exporter.Option{exporter.WithFloatRatios(10)},
+		},
+		{
+			name:    "Disabled",
+			input:   []float64{1.0 / 3},
+			output:  `[]float64{float64(1)/float64(3)}`,
+			options: []exporter.Option{exporter.WithFloatRatios(10), exporter.WithGofmt(false)},
+		},
+		{
+			name:    "Indentation",
+			input:   []float64{1.0 / 3},
+			output:  "[]float64{\n  float64(1) / float64(3),\n}",
+			options: []exporter.Option{exporter.WithFloatRatios(10), exporter.WithIndent("  ")},
+		},
+		{
+			name:    "JSON",
+			input:   []int{1, 2},
+			output:  `[1,2]`,
+			options: []exporter.Option{exporter.WithBackend(exporter.JSONBackend())},
+		},
+	}
+
+	for _, s := range scenarios {
+		s := s
+
+		t.Run(s.name, func(t *testing.T) {
+			t.Parallel()
+
+			options := append([]exporter.Option{exporter.WithGofmt(true)}, s.options...)
+			assert.Equal(t, s.output, exporter.New(options...).MustExport(s.input))
+		})
+	}
+}
@@ -0,0 +1,116 @@
+// Copyright (c) 2023–present Bartłomiej Krukowski
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is furnished
+// to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+// Package gontainerconfig generates static GO files that reconstruct resolved configurations
+// of github.com/gontainer/gontainer, so applications do not have to parse YAML files at runtime.
+//
+//	cfg := gontainerconfig.Config{...} // the resolved configuration tree
+//	_ = gontainerconfig.WriteFile("container/config_gen.go", "container", "Config", cfg)
+//
+// The package does not depend on github.com/gontainer/gontainer. Config is a standalone schema
+// that mirrors the resolved configuration, and callers convert their configurations to it,
+// so generated files do not import gontainer either.
+package gontainerconfig
+
+import (
+	"sort"
+
+	"github.com/gontainer/exporter"
+)
+
+// Config is a resolved configuration tree.
+type Config struct {
+	Parameters map[string]interface{}
+	Services   map[string]Service
+}
+
+// Service is a definition of a service.
+type Service struct {
+	Getter      string
+	Type        string
+	Value       string
+	Constructor string
+	Args        []interface{}
+	Calls       []Call
+	Fields      map[string]interface{}
+	Tags        []Tag
+	Scope       string
+}
+
+// Call is a call of a method of a service.
+type Call struct {
+	Method    string
+	Args      []interface{}
+	Immutable bool
+}
+
+// Tag is a tag of a service. Services with higher priorities come first, see Config.TaggedServices.
+type Tag struct {
+	Name     string
+	Priority int
+}
+
+// TaggedServices returns the names of services tagged by the given tag, ordered by the priority of the tag.
+// Services with the same priority are ordered by their names.
+func (c Config) TaggedServices(tag string) []string {
+	type tagged struct {
+		name     string
+		priority int
+	}
+
+	var services []tagged
+
+	for name, s := range c.Services {
+		for _, t := range s.Tags {
+			if t.Name == tag {
+				services = append(services, tagged{name: name, priority: t.Priority})
+
+				break
+			}
+		}
+	}
+
+	sort.Slice(services, func(i, j int) bool {
+		if services[i].priority != services[j].priority {
+			return services[i].priority > services[j].priority
+		}
+
+		return services[i].name < services[j].name
+	})
+
+	result := make([]string, len(services))
+	for i, s := range services {
+		result[i] = s.name
+	}
+
+	return result
+}
+
+// Generate returns a GO file that declares the package-level variable varName holding the given configuration.
+func Generate(pkg string, varName string, cfg Config) ([]byte, error) {
+	return exporter.ExportFile(pkg, varName, cfg) //nolint:wrapcheck
+}
+
+// WriteFile writes the file returned by Generate to the given path atomically, see exporter.WriteFile.
+func WriteFile(path string, pkg string, varName string, cfg Config) error {
+	_, err := exporter.WriteFile(path, pkg, varName, cfg)
+
+	return err //nolint:wrapcheck
+}
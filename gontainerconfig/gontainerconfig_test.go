@@ -0,0 +1,123 @@
+// Copyright (c) 2023–present Bartłomiej Krukowski
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is furnished
+// to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package gontainerconfig_test
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"regexp"
+	"testing"
+
+	"github.com/gontainer/exporter/gontainerconfig"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newConfig() gontainerconfig.Config {
+	return gontainerconfig.Config{
+		Parameters: map[string]interface{}{
+			"host": "localhost",
+			"port": 8080,
+		},
+		Services: map[string]gontainerconfig.Service{
+			"db": {
+				Constructor: "NewDB",
+				Args:        []interface{}{"%host%", "%port%"},
+				Tags:        []gontainerconfig.Tag{{Name: "closer", Priority: 10}},
+			},
+			"logger": {
+				Value: "log.Default()",
+				Calls: []gontainerconfig.Call{{Method: "SetPrefix", Args: []interface{}{"app "}}},
+				Tags:  []gontainerconfig.Tag{{Name: "closer"}},
+			},
+			"cache": {
+				Constructor: "NewCache",
+				Tags:        []gontainerconfig.Tag{{Name: "closer", Priority: 10}},
+			},
+		},
+	}
+}
+
+func TestGenerate(t *testing.T) {
+	t.Parallel()
+
+	code, err := gontainerconfig.Generate("container", "Config", gontainerconfig.Config{
+		Parameters: map[string]interface{}{"port": 8080},
+		Services: map[string]gontainerconfig.Service{
+			"db": {
+				Constructor: "NewDB",
+				Args:        []interface{}{"%port%"},
+				Tags:        []gontainerconfig.Tag{{Name: "closer", Priority: 10}},
+			},
+		},
+	})
+	require.NoError(t, err)
+	// remove the checksum
+	code = regexp.MustCompile(`(?m)^// Checksum: .*\n`).ReplaceAll(code, nil)
+	assert.Equal(
+		t,
+		`// Code generated by github.com/gontainer/exporter. DO NOT EDIT.
+// Format: 2
+
+package container
+
+import (
+	"github.com/gontainer/exporter/gontainerconfig"
+)
+
+var Config = gontainerconfig.Config{Parameters: map[string]interface{}{"port": int(8080)}, `+
+			`Services: map[string]gontainerconfig.Service{"db": gontainerconfig.Service{Constructor: "NewDB", `+
+			`Args: []interface{}{"%port%"}, Tags: []gontainerconfig.Tag{gontainerconfig.Tag{Name: "closer", `+
+			`Priority: int(10)}}}}}
+`,
+		string(code),
+	)
+}
+
+func TestWriteFile(t *testing.T) {
+	t.Parallel()
+
+	dir, err := ioutil.TempDir("", "gontainerconfig") //nolint:staticcheck
+	require.NoError(t, err)
+	defer os.RemoveAll(dir)
+
+	path := filepath.Join(dir, "config_gen.go")
+	require.NoError(t, gontainerconfig.WriteFile(path, "container", "Config", newConfig()))
+
+	written, err := ioutil.ReadFile(path) //nolint:staticcheck
+	require.NoError(t, err)
+
+	expected, err := gontainerconfig.Generate("container", "Config", newConfig())
+	require.NoError(t, err)
+	assert.Equal(t, string(expected), string(written))
+
+	err = gontainerconfig.WriteFile(path, "container", "my-config", newConfig())
+	assert.EqualError(t, err, `"my-config" is not a valid identifier`)
+}
+
+func TestConfig_TaggedServices(t *testing.T) {
+	t.Parallel()
+
+	cfg := newConfig()
+	assert.Equal(t, []string{"cache", "db", "logger"}, cfg.TaggedServices("closer"))
+	assert.Empty(t, cfg.TaggedServices("listener"))
+}
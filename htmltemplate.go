@@ -0,0 +1,58 @@
+// Copyright (c) 2023–present Bartłomiej Krukowski
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is furnished
+// to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package exporter
+
+import (
+	"html/template"
+	"reflect"
+)
+
+// htmlTemplateTypes contains defined string types from the package html/template.
+//
+//nolint:gochecknoglobals
+var htmlTemplateTypes = map[reflect.Type]struct{}{
+	reflect.TypeOf(template.CSS("")):      {},
+	reflect.TypeOf(template.HTML("")):     {},
+	reflect.TypeOf(template.HTMLAttr("")): {},
+	reflect.TypeOf(template.JS("")):       {},
+	reflect.TypeOf(template.JSStr("")):    {},
+	reflect.TypeOf(template.URL("")):      {},
+	reflect.TypeOf(template.Srcset("")):   {},
+}
+
+// htmlTemplateExporter exports defined string types from the package html/template as conversions, e.g.:
+//
+//	template.HTML("<b>Hello</b>")
+type htmlTemplateExporter struct {
+	backend Backend
+}
+
+func (h htmlTemplateExporter) export(v any) (string, error) {
+	val := reflect.ValueOf(v)
+
+	return h.backend.renderConversion(val.Type(), h.backend.renderString(val.String())), nil
+}
+
+func (htmlTemplateExporter) supports(v any) bool {
+	_, ok := htmlTemplateTypes[reflect.TypeOf(v)]
+
+	return ok
+}
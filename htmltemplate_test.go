@@ -0,0 +1,103 @@
+// Copyright (c) 2023–present Bartłomiej Krukowski
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is furnished
+// to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package exporter_test
+
+import (
+	"html/template"
+	"testing"
+
+	"github.com/gontainer/exporter"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestExport_htmlTemplate(t *testing.T) {
+	t.Parallel()
+
+	scenarios := []struct {
+		name   string
+		input  any
+		output string
+	}{
+		{
+			name:   "HTML",
+			input:  template.HTML(`<b class="title">Hello</b>`),
+			output: `template.HTML("<b class=\"title\">Hello</b>")`,
+		},
+		{
+			name:   "JS",
+			input:  template.JS(`alert("hi")`),
+			output: `template.JS("alert(\"hi\")")`,
+		},
+		{
+			name:   "CSS",
+			input:  template.CSS("color: red"),
+			output: `template.CSS("color: red")`,
+		},
+		{
+			name:   "URL",
+			input:  template.URL("https://example.com/?q=1"),
+			output: `template.URL("https://example.com/?q=1")`,
+		},
+		{
+			name:   "Empty slice",
+			input:  []template.HTML{},
+			output: `make([]template.HTML, 0)`,
+		},
+		{
+			name:   "Map",
+			input:  map[string]template.HTMLAttr{"title": `title="x"`},
+			output: `map[string]template.HTMLAttr{"title": template.HTMLAttr("title=\"x\"")}`,
+		},
+	}
+
+	for _, s := range scenarios {
+		s := s
+
+		t.Run(s.name, func(t *testing.T) {
+			t.Parallel()
+
+			assert.Equal(t, s.output, exporter.MustExport(s.input))
+		})
+	}
+}
+
+func TestExportFile_htmlTemplate(t *testing.T) {
+	t.Parallel()
+
+	src, err := exporter.ExportFile("fixtures", "Page", template.HTML("<p></p>"))
+	require.NoError(t, err)
+	assert.Equal(
+		t,
+		`// Code generated by github.com/gontainer/exporter. DO NOT EDIT.
+// Format: 2
+
+package fixtures
+
+import (
+	"html/template"
+)
+
+var Page = template.HTML("<p></p>")
+`,
+		withoutChecksum(t, src),
+	)
+}
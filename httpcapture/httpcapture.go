@@ -0,0 +1,189 @@
+// Copyright (c) 2023–present Bartłomiej Krukowski
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is furnished
+// to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+// Package httpcapture captures HTTP requests and responses and exports them to a GO code,
+// so they can be reused as fixtures in tests of handlers.
+package httpcapture
+
+import (
+	"bytes"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+
+	"github.com/gontainer/exporter"
+)
+
+// Request is a captured HTTP request.
+type Request struct {
+	Method string
+	URL    string
+	Header map[string][]string
+	Body   string
+}
+
+// Response is a captured HTTP response.
+type Response struct {
+	StatusCode int
+	Header     map[string][]string
+	Body       string
+}
+
+// Exchange is a captured pair of an HTTP request and the response to it.
+type Exchange struct {
+	Request  Request
+	Response Response
+}
+
+// Recorder captures exchanges handled by the handlers wrapped by Recorder.Middleware.
+// It is safe for concurrent use.
+type Recorder struct {
+	mu        sync.Mutex
+	exchanges []Exchange
+}
+
+// NewRecorder creates a new Recorder.
+func NewRecorder() *Recorder {
+	return &Recorder{
+		mu:        sync.Mutex{},
+		exchanges: nil,
+	}
+}
+
+// Middleware wraps the given handler, so it captures all handled requests and responses.
+// Bodies of requests are read in advance and passed to the next handler unchanged.
+func (r *Recorder) Middleware(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		captured := captureRequest(req)
+		cw := &responseWriter{
+			ResponseWriter: w,
+			statusCode:     0,
+			header:         nil,
+			body:           bytes.Buffer{},
+		}
+
+		next.ServeHTTP(cw, req)
+
+		r.mu.Lock()
+		defer r.mu.Unlock()
+
+		r.exchanges = append(r.exchanges, Exchange{
+			Request:  captured,
+			Response: cw.response(),
+		})
+	})
+}
+
+// Exchanges returns all captured exchanges in the order of their completion.
+func (r *Recorder) Exchanges() []Exchange {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	return append([]Exchange(nil), r.exchanges...)
+}
+
+// Reset removes all captured exchanges.
+func (r *Recorder) Reset() {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.exchanges = nil
+}
+
+// Export exports all captured exchanges to a GO code, e.g.:
+//
+//	[]httpcapture.Exchange{httpcapture.Exchange{Request: httpcapture.Request{Method: "GET", URL: "/"}, ...}}
+func (r *Recorder) Export(opts ...exporter.Option) (string, error) {
+	return exporter.New(opts...).Export(r.Exchanges()) //nolint:wrapcheck
+}
+
+// Capture serves the given request by the given handler using httptest.ResponseRecorder
+// and returns the captured exchange.
+func Capture(h http.Handler, req *http.Request) Exchange {
+	r := NewRecorder()
+	r.Middleware(h).ServeHTTP(httptest.NewRecorder(), req)
+
+	return r.Exchanges()[0]
+}
+
+func captureRequest(req *http.Request) Request {
+	result := Request{
+		Method: req.Method,
+		URL:    req.URL.String(),
+		Header: cloneHeader(req.Header),
+		Body:   "",
+	}
+
+	if req.Body != nil {
+		body, _ := ioutil.ReadAll(req.Body) //nolint:staticcheck
+		_ = req.Body.Close()
+		req.Body = ioutil.NopCloser(bytes.NewReader(body)) //nolint:staticcheck
+		result.Body = string(body)
+	}
+
+	return result
+}
+
+func cloneHeader(h http.Header) map[string][]string {
+	if len(h) == 0 {
+		return nil
+	}
+
+	return map[string][]string(h.Clone())
+}
+
+type responseWriter struct {
+	http.ResponseWriter
+	statusCode int
+	header     http.Header
+	body       bytes.Buffer
+}
+
+func (w *responseWriter) WriteHeader(statusCode int) {
+	if w.statusCode == 0 {
+		w.statusCode = statusCode
+		w.header = w.ResponseWriter.Header().Clone()
+	}
+
+	w.ResponseWriter.WriteHeader(statusCode)
+}
+
+func (w *responseWriter) Write(b []byte) (int, error) {
+	if w.statusCode == 0 {
+		w.WriteHeader(http.StatusOK)
+	}
+
+	w.body.Write(b)
+
+	return w.ResponseWriter.Write(b) //nolint:wrapcheck
+}
+
+func (w *responseWriter) response() Response {
+	if w.statusCode == 0 {
+		w.WriteHeader(http.StatusOK)
+	}
+
+	return Response{
+		StatusCode: w.statusCode,
+		Header:     cloneHeader(w.header),
+		Body:       w.body.String(),
+	}
+}
@@ -0,0 +1,134 @@
+// Copyright (c) 2023–present Bartłomiej Krukowski
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is furnished
+// to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package httpcapture_test
+
+import (
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/gontainer/exporter"
+	"github.com/gontainer/exporter/httpcapture"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func echoHandler() http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := ioutil.ReadAll(r.Body) //nolint:staticcheck
+		w.Header().Set("Content-Type", "text/plain")
+		w.WriteHeader(http.StatusCreated)
+		_, _ = fmt.Fprintf(w, "%s %s", r.Method, body)
+	})
+}
+
+func TestCapture(t *testing.T) {
+	t.Parallel()
+
+	req := httptest.NewRequest(http.MethodPost, "/users?id=5", strings.NewReader(`{"name":"Mary"}`))
+	req.Header.Set("Accept", "text/plain")
+
+	x := httpcapture.Capture(echoHandler(), req)
+
+	assert.Equal(
+		t,
+		httpcapture.Exchange{
+			Request: httpcapture.Request{
+				Method: "POST",
+				URL:    "/users?id=5",
+				Header: map[string][]string{"Accept": {"text/plain"}},
+				Body:   `{"name":"Mary"}`,
+			},
+			Response: httpcapture.Response{
+				StatusCode: 201,
+				Header:     map[string][]string{"Content-Type": {"text/plain"}},
+				Body:       `POST {"name":"Mary"}`,
+			},
+		},
+		x,
+	)
+
+	code, err := exporter.Export(x)
+	require.NoError(t, err)
+	assert.Equal(
+		t,
+		`httpcapture.Exchange{`+
+			`Request: httpcapture.Request{Method: "POST", URL: "/users?id=5", `+
+			`Header: map[string][]string{"Accept": []string{"text/plain"}}, Body: "{\"name\":\"Mary\"}"}, `+
+			`Response: httpcapture.Response{StatusCode: int(201), `+
+			`Header: map[string][]string{"Content-Type": []string{"text/plain"}}, Body: "POST {\"name\":\"Mary\"}"}}`,
+		code,
+	)
+}
+
+func TestRecorder_Middleware(t *testing.T) {
+	t.Parallel()
+
+	t.Run("Implicit status", func(t *testing.T) {
+		t.Parallel()
+
+		r := httpcapture.NewRecorder()
+		h := r.Middleware(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+			_, _ = w.Write([]byte("OK"))
+		}))
+
+		w := httptest.NewRecorder()
+		h.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/", nil))
+		assert.Equal(t, "OK", w.Body.String())
+
+		code, err := r.Export()
+		require.NoError(t, err)
+		assert.Equal(
+			t,
+			`[]httpcapture.Exchange{httpcapture.Exchange{Request: httpcapture.Request{Method: "GET", URL: "/"}, `+
+				`Response: httpcapture.Response{StatusCode: int(200), Body: "OK"}}}`,
+			code,
+		)
+	})
+
+	t.Run("Concurrency", func(t *testing.T) {
+		t.Parallel()
+
+		r := httpcapture.NewRecorder()
+		h := r.Middleware(echoHandler())
+
+		const max = 50
+		wg := sync.WaitGroup{}
+		wg.Add(max)
+
+		for i := 0; i < max; i++ {
+			go func() {
+				defer wg.Done()
+				h.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(http.MethodGet, "/", nil))
+			}()
+		}
+
+		wg.Wait()
+		assert.Len(t, r.Exchanges(), max)
+
+		r.Reset()
+		assert.Empty(t, r.Exchanges())
+	})
+}
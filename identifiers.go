@@ -0,0 +1,227 @@
+// Copyright (c) 2023–present Bartłomiej Krukowski
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is furnished
+// to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package exporter
+
+import (
+	"errors"
+	"fmt"
+	"go/token"
+	"go/types"
+	"reflect"
+	"strings"
+	"unicode"
+)
+
+// ErrInvalidIdentifier is wrapped by *IdentifierError.
+//
+//nolint:gochecknoglobals
+var ErrInvalidIdentifier = errors.New("invalid identifier")
+
+// IdentifierError is returned when a name given to ExportFile, ExportEqualityTest or ExportShapeTest
+// cannot be used in the generated code, see WithIdentifierSanitizing.
+type IdentifierError struct {
+	Name string
+	// Reason explains why the name cannot be used, if the name is a valid identifier in general, e.g.
+	// "it shadows the predeclared identifier".
+	Reason string
+}
+
+func (e *IdentifierError) Error() string {
+	msg := fmt.Sprintf("%q is not a valid identifier", e.Name)
+	if e.Reason != "" {
+		msg += ": " + e.Reason
+	}
+
+	return msg
+}
+
+func (e *IdentifierError) Unwrap() error {
+	return ErrInvalidIdentifier
+}
+
+// WithIdentifierSanitizing makes ExportFile, ExportEqualityTest and ExportShapeTest turn invalid names
+// into valid identifiers instead of returning *IdentifierError, e.g.:
+//
+//	"user-orders" // user_orders
+//	"2023"        // _2023
+//	"type"        // type_
+//	"string"      // string_, since it would shadow the predeclared type used by the exported code
+//	"time"        // time_, if the exported value refers to the package time
+//
+// The same value gets the same names in all generated files, so they can refer to each other.
+func WithIdentifierSanitizing() Option {
+	return func(c *config) {
+		c.sanitizeNames = true
+	}
+}
+
+// identifiers verifies, or sanitizes according to WithIdentifierSanitizing, the name of the package
+// and the name of the variable that are used in files generated for the given value.
+func (e *Exporter) identifiers(pkg string, varName string, v any) (string, string, error) {
+	var err error
+
+	if pkg, err = e.identifier(pkg, pkgCheck); err != nil {
+		return "", "", err
+	}
+
+	if varName, err = e.identifier(varName, e.varCheck(reflect.TypeOf(v), reservedIdentifiers(v))); err != nil {
+		return "", "", err
+	}
+
+	return pkg, varName, nil
+}
+
+func pkgCheck(name string) (string, string) {
+	return name, identifierReason(name, nil)
+}
+
+// varCheck returns the function that verifies the name of the variable of the given type,
+// and the names of its accessors, against the given reserved names, see Exporter.identifier.
+func (e *Exporter) varCheck(t reflect.Type, reserved map[string]struct{}) func(string) (string, string) {
+	return func(name string) (string, string) {
+		if r := identifierReason(name, reserved); r != "" {
+			return name, r
+		}
+
+		// the function that returns a copy must be valid too, see WithCopyAccessors
+		if fn := e.copyAccessor(name); fn != "" {
+			if r := identifierReason(fn, reserved); r != "" {
+				return fn, r
+			}
+		}
+
+		// the same applies to the function that returns typed elements, see WithTypedAccessors
+		if fn := e.typedAccessor(name, t); fn != "" {
+			return fn, identifierReason(fn, reserved)
+		}
+
+		return name, ""
+	}
+}
+
+// reservedIdentifiers returns the names of packages of all named types of the given value,
+// and the names of packages imported by generated tests.
+func reservedIdentifiers(v any) map[string]struct{} {
+	reserved := map[string]struct{}{"reflect": {}, "testing": {}}
+	c := packageCollector{
+		pkgs:   make(map[string]map[string]struct{}),
+		types:  make(map[reflect.Type]struct{}),
+		values: make(map[cacheKey]struct{}),
+	}
+	c.collect(reflect.ValueOf(v))
+
+	for name := range c.pkgs {
+		reserved[name] = struct{}{}
+	}
+
+	return reserved
+}
+
+// identifier verifies the given name using the given function, that returns the invalid name and the reason,
+// and sanitizes the name, if needed, see WithIdentifierSanitizing.
+func (e *Exporter) identifier(name string, check func(string) (string, string)) (string, error) {
+	invalid, reason := check(name)
+	if reason == "" {
+		return name, nil
+	}
+
+	if !e.config.sanitizeNames {
+		return "", newIdentifierError(invalid, reason)
+	}
+
+	sanitized := sanitizeIdentifier(name)
+	for i := 0; ; i++ {
+		if _, r := check(sanitized); r == "" {
+			return sanitized, nil
+		}
+
+		// the reason does not depend on the name, e.g. an invalid suffix of the copy accessor
+		if i > len(name) {
+			return "", newIdentifierError(invalid, reason)
+		}
+
+		sanitized += "_"
+	}
+}
+
+func newIdentifierError(name string, reason string) error {
+	if reason == invalidSyntax {
+		reason = ""
+	}
+
+	return &IdentifierError{Name: name, Reason: reason}
+}
+
+const invalidSyntax = "invalid syntax"
+
+// identifierReason returns the reason why the given name cannot be used, or an empty string.
+// The given reserved names are the names of imported packages.
+func identifierReason(name string, reserved map[string]struct{}) string {
+	switch {
+	case token.IsKeyword(name):
+		return "it is a keyword"
+	case !token.IsIdentifier(name):
+		return invalidSyntax
+	case name == "_":
+		return "it is the blank identifier"
+	}
+
+	if reserved == nil {
+		return ""
+	}
+
+	if _, ok := reserved[name]; ok {
+		return "it collides with the name of an imported package"
+	}
+
+	if types.Universe.Lookup(name) != nil {
+		return "it shadows the predeclared identifier"
+	}
+
+	return ""
+}
+
+// sanitizeIdentifier replaces characters that are not allowed in identifiers by underscores,
+// and prepends an underscore to leading digits. Empty names are replaced by the blank identifier.
+func sanitizeIdentifier(name string) string {
+	buf := strings.Builder{}
+
+	for _, r := range name {
+		switch {
+		case unicode.IsLetter(r) || r == '_':
+			buf.WriteRune(r)
+		case unicode.IsDigit(r):
+			if buf.Len() == 0 {
+				buf.WriteRune('_')
+			}
+
+			buf.WriteRune(r)
+		default:
+			buf.WriteRune('_')
+		}
+	}
+
+	if buf.Len() == 0 {
+		return "_"
+	}
+
+	return buf.String()
+}
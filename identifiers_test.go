@@ -0,0 +1,148 @@
+// Copyright (c) 2023–present Bartłomiej Krukowski
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is furnished
+// to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package exporter_test
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/gontainer/exporter"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestExportFile_identifiers(t *testing.T) {
+	t.Parallel()
+
+	//nolint:exhaustruct
+	scenarios := []struct {
+		pkg       string
+		varName   string
+		input     any
+		error     string
+		sanitized string
+		options   []exporter.Option
+	}{
+		{
+			pkg:       "fixtures",
+			varName:   "user-orders",
+			error:     `"user-orders" is not a valid identifier`,
+			sanitized: "package fixtures\n\nvar user_orders = ",
+		},
+		{
+			pkg:       "fixtures",
+			varName:   "2023",
+			error:     `"2023" is not a valid identifier`,
+			sanitized: "package fixtures\n\nvar _2023 = ",
+		},
+		{
+			pkg:       "fixtures",
+			varName:   "type",
+			error:     `"type" is not a valid identifier: it is a keyword`,
+			sanitized: "package fixtures\n\nvar type_ = ",
+		},
+		{
+			pkg:       "fixtures",
+			varName:   "int",
+			error:     `"int" is not a valid identifier: it shadows the predeclared identifier`,
+			sanitized: "package fixtures\n\nvar int_ = ",
+		},
+		{
+			pkg:       "fixtures",
+			varName:   "time",
+			input:     time.Second,
+			error:     `"time" is not a valid identifier: it collides with the name of an imported package`,
+			sanitized: "var time_ = time.Duration(1000000000)",
+		},
+		{
+			pkg:       "fixtures",
+			varName:   "_",
+			error:     `"_" is not a valid identifier: it is the blank identifier`,
+			sanitized: "package fixtures\n\nvar __ = ",
+		},
+		{
+			pkg:       "my-fixtures",
+			varName:   "Values",
+			error:     `"my-fixtures" is not a valid identifier`,
+			sanitized: "package my_fixtures\n\nvar Values = ",
+		},
+		{
+			pkg:       "func",
+			varName:   "",
+			error:     `"func" is not a valid identifier: it is a keyword`,
+			sanitized: "package func_\n\nvar __ = ",
+		},
+		{
+			pkg:       "fixtures",
+			varName:   "Values",
+			options:   []exporter.Option{exporter.WithCopyAccessors("-copy")},
+			error:     `"Values-copy" is not a valid identifier`,
+			sanitized: "",
+		},
+	}
+
+	for _, s := range scenarios {
+		s := s
+
+		t.Run(s.varName, func(t *testing.T) {
+			t.Parallel()
+
+			input := s.input
+			if input == nil {
+				input = 1
+			}
+
+			_, err := exporter.New(s.options...).ExportFile(s.pkg, s.varName, input)
+			require.EqualError(t, err, s.error)
+			assert.True(t, errors.Is(err, exporter.ErrInvalidIdentifier))
+
+			var idErr *exporter.IdentifierError
+			require.True(t, errors.As(err, &idErr))
+
+			e := exporter.New(append(s.options, exporter.WithIdentifierSanitizing())...)
+			code, err := e.ExportFile(s.pkg, s.varName, input)
+
+			if s.sanitized == "" {
+				assert.EqualError(t, err, s.error)
+
+				return
+			}
+
+			require.NoError(t, err)
+			assert.Contains(t, string(code), s.sanitized)
+		})
+	}
+
+	t.Run("Tests", func(t *testing.T) {
+		t.Parallel()
+
+		e := exporter.New(exporter.WithIdentifierSanitizing())
+
+		code, err := e.ExportEqualityTest("fixtures", "testing", 1)
+		require.NoError(t, err)
+		assert.Contains(t, string(code), "if !reflect.DeepEqual(testing_, expected) {")
+
+		code, err = e.ExportShapeTest("fixtures", "reflect", []int{1})
+		require.NoError(t, err)
+		assert.Contains(t, string(code), "if len(reflect_) != 1 {")
+	})
+}
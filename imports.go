@@ -0,0 +1,148 @@
+// Copyright (c) 2023–present Bartłomiej Krukowski
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is furnished
+// to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package exporter
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+)
+
+// ExportWithImports exports the given value, and returns the sorted import paths of packages referenced by the code,
+// e.g.:
+//
+//	code, imports, err := exporter.ExportWithImports([]any{time.Second, pkg.User{}})
+//	// code:    []interface{}{time.Duration(1000000000), pkg.User{}}
+//	// imports: ["example.com/pkg", "time"]
+//
+// Imports are resolved like imports of files generated by ExportFile, see WithPackagePath and WithImportRewrite.
+//
+// See Exporter.ExportWithImports.
+func ExportWithImports(v any) (code string, imports []string, err error) {
+	return defaultExporter.ExportWithImports(v)
+}
+
+// ExportWithImports exports the given value, and returns the import paths of packages referenced by the code,
+// see the function ExportWithImports.
+func (e *Exporter) ExportWithImports(v any) (code string, imports []string, err error) {
+	if _, ok := e.config.backend.(goBackend); !ok {
+		return "", nil, errors.New("ExportWithImports requires the GO backend") //nolint:goerr113
+	}
+
+	code, err = e.Export(v)
+	if err != nil {
+		return "", nil, err
+	}
+
+	imports, err = findImports(v, e.config, code)
+	if err != nil {
+		return "", nil, err
+	}
+
+	return code, imports, nil
+}
+
+// WithPackagePath sets the import path of the package of files generated by ExportFile and ExportEqualityTest.
+// It lets the exporter verify that the package is allowed to import internal packages of the exported types, e.g.
+// "example.com/app/internal/model" can be imported by "example.com/app/fixtures",
+// but it cannot be imported by "example.com/other/fixtures".
+// Internal packages of the standard library, vendored packages and main packages are rejected regardless
+// of the path, see WithImportRewrite. Values of types declared by the package itself are rejected too,
+// because they cannot be referenced by qualified names without an import cycle. The path also lets the exporter
+// reject unnamed struct types with unexported fields of other packages, see ErrUndeclarableType.
+func WithPackagePath(path string) Option {
+	return func(c *config) {
+		c.packagePath = path
+	}
+}
+
+// WithImportRewrite sets the function that rewrites import paths of generated files, e.g.:
+//
+//	exporter.WithImportRewrite(func(path string) string {
+//		// types of vendored packages contain the vendor directory in their paths in the GOPATH mode
+//		if i := strings.LastIndex(path, "/vendor/"); i >= 0 {
+//			return path[i+len("/vendor/"):]
+//		}
+//		return path
+//	})
+//
+// The rewritten package must have the same name as the original one.
+func WithImportRewrite(fn func(path string) string) Option {
+	return func(c *config) {
+		c.importRewrite = fn
+	}
+}
+
+// resolveImport rewrites the given import path, and verifies whether it can be imported by the package of
+// the given path, see WithPackagePath and WithImportRewrite.
+func resolveImport(cfg config, path string) (string, error) {
+	if cfg.importRewrite != nil {
+		path = cfg.importRewrite(path)
+	}
+
+	// "command-line-arguments" is the path of packages of files given to "go run" or "go build"
+	if path == "main" || path == "command-line-arguments" {
+		return "", fmt.Errorf( //nolint:goerr113
+			"package %q is a main package and cannot be imported, export values of types of other packages",
+			path,
+		)
+	}
+
+	if cfg.packagePath != "" && path == cfg.packagePath {
+		return "", fmt.Errorf( //nolint:goerr113
+			"package %q cannot import itself, export values of its types to another package",
+			path,
+		)
+	}
+
+	elems := strings.Split(path, "/")
+
+	for i, e := range elems {
+		switch e {
+		case "vendor":
+			return "", fmt.Errorf( //nolint:goerr113
+				"package %q is vendored and cannot be imported by its path, see WithImportRewrite",
+				path,
+			)
+
+		case "internal":
+			root := strings.Join(elems[:i], "/")
+
+			if root == "" {
+				return "", fmt.Errorf("package %q is internal to the standard library", path) //nolint:goerr113
+			}
+
+			// the path of the generated package is not known, see WithPackagePath
+			if cfg.packagePath == "" || cfg.packagePath == root || strings.HasPrefix(cfg.packagePath, root+"/") {
+				continue
+			}
+
+			return "", fmt.Errorf( //nolint:goerr113
+				"package %q is internal to %q and cannot be imported by %q",
+				path,
+				root,
+				cfg.packagePath,
+			)
+		}
+	}
+
+	return path, nil
+}
@@ -0,0 +1,169 @@
+// Copyright (c) 2023–present Bartłomiej Krukowski
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is furnished
+// to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package exporter_test
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/gontainer/exporter"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestWithPackagePath(t *testing.T) {
+	t.Parallel()
+
+	// types of internal packages cannot be referenced in tests, so the rewrite simulates them
+	internal := exporter.WithImportRewrite(func(path string) string {
+		return "example.com/app/internal/" + path
+	})
+
+	//nolint:exhaustruct
+	scenarios := []struct {
+		name    string
+		options []exporter.Option
+		imports string
+		error   string
+	}{
+		{
+			name:    "Unknown package path",
+			options: []exporter.Option{internal},
+			imports: `"example.com/app/internal/time"`,
+		},
+		{
+			name:    "Package in the same tree",
+			options: []exporter.Option{internal, exporter.WithPackagePath("example.com/app/fixtures")},
+			imports: `"example.com/app/internal/time"`,
+		},
+		{
+			name:    "Root of the tree",
+			options: []exporter.Option{internal, exporter.WithPackagePath("example.com/app")},
+			imports: `"example.com/app/internal/time"`,
+		},
+		{
+			name:    "Package outside the tree",
+			options: []exporter.Option{internal, exporter.WithPackagePath("example.com/application/fixtures")},
+			error: `package "example.com/app/internal/time" is internal to "example.com/app" ` +
+				`and cannot be imported by "example.com/application/fixtures"`,
+		},
+		{
+			name:    "Same package",
+			options: []exporter.Option{exporter.WithPackagePath("time")},
+			error:   `package "time" cannot import itself, export values of its types to another package`,
+		},
+		{
+			name:    "Rewritten same package",
+			options: []exporter.Option{internal, exporter.WithPackagePath("example.com/app/internal/time")},
+			error: `package "example.com/app/internal/time" cannot import itself, ` +
+				`export values of its types to another package`,
+		},
+		{
+			name: "Main package",
+			options: []exporter.Option{exporter.WithImportRewrite(func(string) string {
+				return "main"
+			})},
+			error: `package "main" is a main package and cannot be imported, export values of types of other packages`,
+		},
+		{
+			name: "Package of command-line arguments",
+			options: []exporter.Option{exporter.WithImportRewrite(func(string) string {
+				return "command-line-arguments"
+			})},
+			error: `package "command-line-arguments" is a main package and cannot be imported, ` +
+				`export values of types of other packages`,
+		},
+		{
+			name: "Standard library",
+			options: []exporter.Option{exporter.WithImportRewrite(func(path string) string {
+				return "internal/" + path
+			})},
+			error: `package "internal/time" is internal to the standard library`,
+		},
+		{
+			name: "Vendored package",
+			options: []exporter.Option{exporter.WithImportRewrite(func(path string) string {
+				return "example.com/app/vendor/" + path
+			})},
+			error: `package "example.com/app/vendor/time" is vendored and cannot be imported by its path, ` +
+				`see WithImportRewrite`,
+		},
+		{
+			name: "Rewritten vendored package",
+			options: []exporter.Option{exporter.WithImportRewrite(func(path string) string {
+				path = "example.com/app/vendor/" + path
+				if i := strings.LastIndex(path, "/vendor/"); i >= 0 {
+					return path[i+len("/vendor/"):]
+				}
+
+				return path
+			})},
+			imports: `"time"`,
+		},
+	}
+
+	for _, s := range scenarios {
+		s := s
+
+		t.Run(s.name, func(t *testing.T) {
+			t.Parallel()
+
+			src, err := exporter.New(s.options...).ExportFile("fixtures", "Epoch", time.Unix(0, 0).UTC())
+			if s.error != "" {
+				assert.EqualError(t, err, s.error)
+				assert.Empty(t, src)
+
+				return
+			}
+
+			require.NoError(t, err)
+			assert.Contains(t, string(src), "import (\n\t"+s.imports+"\n)")
+		})
+	}
+}
+
+func TestExportWithImports(t *testing.T) {
+	t.Parallel()
+
+	code, imports, err := exporter.ExportWithImports([]any{time.Second, StructServer{}, 5})
+	require.NoError(t, err)
+	assert.Equal(t, `[]interface{}{time.Duration(1000000000), exporter_test.StructServer{}, int(5)}`, code)
+	assert.Equal(t, []string{"github.com/gontainer/exporter_test", "time"}, imports)
+
+	code, imports, err = exporter.ExportWithImports([]int{1})
+	require.NoError(t, err)
+	assert.Equal(t, `[]int{int(1)}`, code)
+	assert.Empty(t, imports)
+
+	e := exporter.New(exporter.WithImportRewrite(func(path string) string {
+		return "example.com/" + path
+	}))
+	_, imports, err = e.ExportWithImports(time.Second)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"example.com/time"}, imports)
+
+	_, _, err = exporter.New(exporter.WithBackend(exporter.JSONBackend())).ExportWithImports(5)
+	require.EqualError(t, err, "ExportWithImports requires the GO backend")
+
+	_, _, err = exporter.ExportWithImports(make(chan int))
+	require.EqualError(t, err, "type chan int is not supported")
+}
@@ -0,0 +1,419 @@
+// Copyright (c) 2023–present Bartłomiej Krukowski
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is furnished
+// to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package exporter
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"go/ast"
+	"go/format"
+	"go/parser"
+	"go/token"
+	"io/ioutil"
+	"os"
+	"reflect"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// InjectVar inserts a package-level variable "var name = <exported v>" into the given file.
+// When the file already declares such a variable, only its value is replaced and the rest of the file is preserved.
+// Packages referenced by the exported code are added to the imports of the file.
+//
+// See Exporter.InjectVar.
+func InjectVar(file *ast.File, name string, v any) error {
+	return defaultExporter.InjectVar(file, name, v)
+}
+
+// InjectVar inserts a package-level variable into the given file, see the function InjectVar.
+func (e *Exporter) InjectVar(file *ast.File, name string, v any) error {
+	code, pkgs, err := e.exportVarValue(name, v)
+	if err != nil {
+		return err
+	}
+
+	imports, err := missingImports(file, pkgs)
+	if err != nil {
+		return err
+	}
+
+	expr, err := parser.ParseExpr(code)
+	if err != nil {
+		return fmt.Errorf("cannot parse exported code: %w", err)
+	}
+
+	clearPositions(expr)
+
+	spec, idx, err := findVarSpec(file, name)
+	if err != nil {
+		return err
+	}
+
+	switch {
+	case spec == nil:
+		file.Decls = append(file.Decls, &ast.GenDecl{ //nolint:exhaustruct
+			Tok: token.VAR,
+			Specs: []ast.Spec{&ast.ValueSpec{ //nolint:exhaustruct
+				Names:  []*ast.Ident{ast.NewIdent(name)},
+				Values: []ast.Expr{expr},
+			}},
+		})
+	case len(spec.Values) == 0:
+		spec.Values = []ast.Expr{expr}
+	default:
+		removeComments(file, spec.Values[idx])
+		spec.Values[idx] = expr
+	}
+
+	addImportSpecs(file, imports)
+
+	return nil
+}
+
+// InjectVarFile works like InjectVar, but it reads the file from the given path,
+// and writes back the formatted result.
+//
+// See Exporter.InjectVarFile.
+func InjectVarFile(path string, name string, v any) error {
+	return defaultExporter.InjectVarFile(path, name, v)
+}
+
+// InjectVarFile works like Exporter.InjectVar, but it reads the file from the given path,
+// and writes back the formatted result.
+func (e *Exporter) InjectVarFile(path string, name string, v any) error {
+	src, err := ioutil.ReadFile(path) //nolint:staticcheck
+	if err != nil {
+		return err //nolint:wrapcheck
+	}
+
+	info, err := os.Stat(path)
+	if err != nil {
+		return err //nolint:wrapcheck
+	}
+
+	fset := token.NewFileSet()
+
+	file, err := parser.ParseFile(fset, path, src, parser.ParseComments)
+	if err != nil {
+		return err //nolint:wrapcheck
+	}
+
+	code, pkgs, err := e.exportVarValue(name, v)
+	if err != nil {
+		return err
+	}
+
+	imports, err := missingImports(file, pkgs)
+	if err != nil {
+		return err
+	}
+
+	spec, idx, err := findVarSpec(file, name)
+	if err != nil {
+		return err
+	}
+
+	// the source is patched textually, so the printer does not need to guess the layout of new nodes
+	offset := func(p token.Pos) int {
+		return fset.Position(p).Offset
+	}
+
+	var out []byte
+
+	switch {
+	case spec == nil:
+		out = append(append(out, src...), fmt.Sprintf("\nvar %s = %s\n", name, code)...)
+	case len(spec.Values) == 0:
+		end := offset(spec.End())
+		out = append(append(append(out, src[:end]...), " = "+code...), src[end:]...)
+	default:
+		pos, end := offset(spec.Values[idx].Pos()), offset(spec.Values[idx].End())
+		out = append(append(append(out, src[:pos]...), code...), src[end:]...)
+	}
+
+	// imports precede declarations, so the offset is valid in the patched source too
+	out = insertImports(out, fset, file, imports)
+
+	out, err = format.Source(out)
+	if err != nil {
+		return err //nolint:wrapcheck
+	}
+
+	return ioutil.WriteFile(path, out, info.Mode()) //nolint:staticcheck,wrapcheck
+}
+
+// exportVarValue exports the value of the variable of the given name, and returns the paths of packages
+// referenced by the code by their names.
+func (e *Exporter) exportVarValue(name string, v any) (string, map[string]string, error) {
+	if !token.IsIdentifier(name) {
+		return "", nil, fmt.Errorf("%q is not a valid identifier", name) //nolint:goerr113
+	}
+
+	if _, ok := e.config.backend.(goBackend); !ok {
+		return "", nil, errors.New("injecting variables requires the GO backend") //nolint:goerr113
+	}
+
+	code, err := e.Export(v)
+	if err != nil {
+		return "", nil, err
+	}
+
+	pkgs, err := findPackages(v, e.config, code)
+	if err != nil {
+		return "", nil, err
+	}
+
+	return code, pkgs, nil
+}
+
+// missingImports returns the imports of the given packages, indexed by their names, that the given file lacks.
+// It fails when the file uses the name of a package for another package.
+func missingImports(file *ast.File, pkgs map[string]string) ([]*ast.ImportSpec, error) {
+	names := make([]string, 0, len(pkgs))
+	for name := range pkgs {
+		names = append(names, name)
+	}
+
+	sort.Strings(names)
+
+	var result []*ast.ImportSpec
+
+	for _, name := range names {
+		path, imported := pkgs[name], false
+
+		for _, spec := range file.Imports {
+			specPath, err := strconv.Unquote(spec.Path.Value)
+			if err != nil {
+				return nil, fmt.Errorf("invalid import %s: %w", spec.Path.Value, err)
+			}
+
+			local := defaultPackageName(specPath)
+			if spec.Name != nil {
+				local = spec.Name.Name
+			}
+
+			switch {
+			case specPath == path && (spec.Name == nil || local == name):
+				imported = true
+			case specPath == path:
+				return nil, fmt.Errorf( //nolint:goerr113
+					"package %q is imported as %q, but the exported code refers to it as %q",
+					path, local, name,
+				)
+			case local == name:
+				return nil, fmt.Errorf( //nolint:goerr113
+					"package %q cannot be imported as %q, the name is used by the import of %q",
+					path, name, specPath,
+				)
+			}
+		}
+
+		if imported {
+			continue
+		}
+
+		//nolint:exhaustruct
+		spec := &ast.ImportSpec{Path: &ast.BasicLit{Kind: token.STRING, Value: strconv.Quote(path)}}
+		if name != defaultPackageName(path) {
+			spec.Name = ast.NewIdent(name)
+		}
+
+		result = append(result, spec)
+	}
+
+	return result, nil
+}
+
+// defaultPackageName returns the last element of the given import path,
+// that is the name of the package by convention.
+func defaultPackageName(path string) string {
+	return path[strings.LastIndex(path, "/")+1:]
+}
+
+// addImportSpecs adds the given imports to the first import declaration of the given file,
+// or to a new declaration that precedes other declarations.
+func addImportSpecs(file *ast.File, imports []*ast.ImportSpec) {
+	if len(imports) == 0 {
+		return
+	}
+
+	file.Imports = append(file.Imports, imports...)
+
+	for _, d := range file.Decls {
+		if decl, ok := d.(*ast.GenDecl); ok && decl.Tok == token.IMPORT {
+			for _, i := range imports {
+				decl.Specs = append(decl.Specs, i)
+			}
+
+			return
+		}
+	}
+
+	//nolint:exhaustruct
+	decl := &ast.GenDecl{Tok: token.IMPORT}
+	for _, i := range imports {
+		decl.Specs = append(decl.Specs, i)
+	}
+
+	file.Decls = append([]ast.Decl{decl}, file.Decls...)
+}
+
+// insertImports inserts the given imports into the given source of the given file,
+// after the last import declaration, or after the package clause.
+func insertImports(src []byte, fset *token.FileSet, file *ast.File, imports []*ast.ImportSpec) []byte {
+	if len(imports) == 0 {
+		return src
+	}
+
+	specs := ""
+
+	for _, i := range imports {
+		if i.Name != nil {
+			specs += i.Name.Name + " "
+		}
+
+		specs += i.Path.Value + "\n"
+	}
+
+	var (
+		last   *ast.GenDecl
+		offset = fset.Position(file.Name.End()).Offset
+		code   = "\n\nimport (\n" + specs + ")\n"
+	)
+
+	for _, d := range file.Decls {
+		if decl, ok := d.(*ast.GenDecl); ok && decl.Tok == token.IMPORT {
+			last = decl
+		}
+	}
+
+	switch {
+	case last != nil && last.Rparen.IsValid():
+		offset = fset.Position(last.Rparen).Offset
+		code = specs
+
+		// e.g. import ("fmt")
+		if before := bytes.TrimRight(src[:offset], " \t"); len(before) > 0 && before[len(before)-1] != '\n' {
+			code = "\n" + specs
+		}
+	case last != nil:
+		offset = fset.Position(last.End()).Offset
+		code = "\n\nimport (\n" + specs + ")"
+	}
+
+	out := make([]byte, 0, len(src)+len(code))
+
+	return append(append(append(out, src[:offset]...), code...), src[offset:]...)
+}
+
+// findVarSpec returns the package-level var spec that declares the given name, and the index of the name in that spec.
+func findVarSpec(file *ast.File, name string) (*ast.ValueSpec, int, error) {
+	for _, d := range file.Decls {
+		switch decl := d.(type) {
+		case *ast.FuncDecl:
+			if decl.Recv == nil && decl.Name.Name == name {
+				return nil, 0, fmt.Errorf("%q is already declared as a function", name) //nolint:goerr113
+			}
+		case *ast.GenDecl:
+			for _, s := range decl.Specs {
+				switch spec := s.(type) {
+				case *ast.TypeSpec:
+					if spec.Name.Name == name {
+						return nil, 0, fmt.Errorf("%q is already declared as a type", name) //nolint:goerr113
+					}
+				case *ast.ValueSpec:
+					for i, n := range spec.Names {
+						if n.Name != name {
+							continue
+						}
+
+						if decl.Tok != token.VAR {
+							return nil, 0, fmt.Errorf("%q is already declared as a constant", name) //nolint:goerr113
+						}
+
+						switch {
+						case len(spec.Values) == 0 && len(spec.Names) > 1:
+							return nil, 0, fmt.Errorf( //nolint:goerr113
+								"cannot set the value of %q, it is declared together with other variables",
+								name,
+							)
+						case len(spec.Values) != 0 && len(spec.Values) != len(spec.Names):
+							return nil, 0, fmt.Errorf( //nolint:goerr113
+								"cannot replace the value of %q, it is declared with a multi-value expression",
+								name,
+							)
+						}
+
+						return spec, i, nil
+					}
+				}
+			}
+		}
+	}
+
+	return nil, 0, nil
+}
+
+// removeComments removes comments placed within the given node, otherwise the printer would leave them in the output.
+func removeComments(file *ast.File, n ast.Node) {
+	groups := file.Comments[:0]
+
+	for _, g := range file.Comments {
+		if g.Pos() >= n.Pos() && g.End() <= n.End() {
+			continue
+		}
+
+		groups = append(groups, g)
+	}
+
+	file.Comments = groups
+}
+
+//nolint:gochecknoglobals
+var posType = reflect.TypeOf(token.NoPos)
+
+// clearPositions resets all positions in the given tree.
+// Positions of a parsed expression refer to its own file set,
+// they would confuse the printer once the expression is attached to another file.
+func clearPositions(n ast.Node) {
+	ast.Inspect(n, func(n ast.Node) bool {
+		if n == nil {
+			return false
+		}
+
+		v := reflect.ValueOf(n)
+		if v.Kind() != reflect.Ptr || v.Elem().Kind() != reflect.Struct {
+			return true
+		}
+
+		v = v.Elem()
+
+		for i := 0; i < v.NumField(); i++ {
+			if f := v.Field(i); f.Type() == posType && f.CanSet() {
+				f.SetInt(int64(token.NoPos))
+			}
+		}
+
+		return true
+	})
+}
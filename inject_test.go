@@ -0,0 +1,266 @@
+// Copyright (c) 2023–present Bartłomiej Krukowski
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is furnished
+// to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package exporter_test
+
+import (
+	"bytes"
+	"go/format"
+	"go/parser"
+	"go/token"
+	"io/ioutil"
+	"net"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/gontainer/exporter"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestInjectVar(t *testing.T) {
+	t.Parallel()
+
+	//nolint:exhaustruct
+	scenarios := []struct {
+		name   string
+		input  string
+		value  any
+		output string
+		error  string
+	}{
+		{
+			name:  "New variable",
+			input: "package fixtures\n\n// Foo is hand-written.\nfunc Foo() {}\n",
+			value: []int{1, 2},
+			output: `package fixtures
+
+// Foo is hand-written.
+func Foo() {}
+
+var numbers = []int{int(1), int(2)}
+`,
+		},
+		{
+			name: "Existing variable",
+			input: `package fixtures
+
+// numbers is generated.
+var numbers = []int{
+	// old value
+	5,
+}
+
+var other = 5
+`,
+			value: []int{1, 2},
+			output: `package fixtures
+
+// numbers is generated.
+var numbers = []int{int(1), int(2)}
+
+var other = 5
+`,
+		},
+		{
+			name:   "Existing variable with a type",
+			input:  "package fixtures\n\nvar numbers []int\n",
+			value:  []int{1},
+			output: "package fixtures\n\nvar numbers []int = []int{int(1)}\n",
+		},
+		{
+			name:   "Group of variables",
+			input:  "package fixtures\n\nvar (\n\tfirst, numbers = 1, 2\n)\n",
+			value:  "hello",
+			output: "package fixtures\n\nvar (\n\tfirst, numbers = 1, \"hello\"\n)\n",
+		},
+		{
+			name:  "Multi-value expression",
+			input: "package fixtures\n\nvar first, numbers = foo()\n",
+			value: 5,
+			error: `cannot replace the value of "numbers", it is declared with a multi-value expression`,
+		},
+		{
+			name:  "Multiple variables without values",
+			input: "package fixtures\n\nvar first, numbers int\n",
+			value: 5,
+			error: `cannot set the value of "numbers", it is declared together with other variables`,
+		},
+		{
+			name:  "Constant",
+			input: "package fixtures\n\nconst numbers = 5\n",
+			value: 5,
+			error: `"numbers" is already declared as a constant`,
+		},
+		{
+			name:  "Function",
+			input: "package fixtures\n\nfunc numbers() {}\n",
+			value: 5,
+			error: `"numbers" is already declared as a function`,
+		},
+		{
+			name:  "Type",
+			input: "package fixtures\n\ntype numbers int\n",
+			value: 5,
+			error: `"numbers" is already declared as a type`,
+		},
+		{
+			name:  "Unsupported value",
+			input: "package fixtures\n",
+			value: make(chan int),
+			error: "type chan int is not supported",
+		},
+		{
+			name:   "New import",
+			input:  "package fixtures\n\nvar numbers int\n",
+			value:  time.Second,
+			output: "package fixtures\n\nimport \"time\"\n\nvar numbers int = time.Duration(1000000000)\n",
+		},
+		{
+			name:  "Existing imports",
+			input: "package fixtures\n\nimport (\n\t\"fmt\"\n\t\"time\"\n)\n\nvar _ = fmt.Sprint\n",
+			value: map[time.Duration]net.IP{time.Second: net.IPv4(127, 0, 0, 1)},
+			output: "package fixtures\n\nimport (\n\t\"fmt\"\n\t\"net\"\n\t\"time\"\n)\n\nvar _ = fmt.Sprint\n" +
+				"var numbers = map[time.Duration]net.IP{time.Duration(1000000000): net.ParseIP(\"127.0.0.1\")}\n",
+		},
+		{
+			name:  "Aliased import",
+			input: "package fixtures\n\nimport t \"time\"\n\nvar _ = t.Now\n",
+			value: time.Second,
+			error: `package "time" is imported as "t", but the exported code refers to it as "time"`,
+		},
+		{
+			name:  "Import with the same name",
+			input: "package fixtures\n\nimport \"example.com/time\"\n\nvar _ = time.Now\n",
+			value: time.Second,
+			error: `package "time" cannot be imported as "time", the name is used by the import of "example.com/time"`,
+		},
+	}
+
+	for _, s := range scenarios {
+		s := s
+
+		t.Run(s.name, func(t *testing.T) {
+			t.Parallel()
+
+			fset := token.NewFileSet()
+			file, err := parser.ParseFile(fset, "", s.input, parser.ParseComments)
+			require.NoError(t, err)
+
+			err = exporter.InjectVar(file, "numbers", s.value)
+			if s.error != "" {
+				assert.EqualError(t, err, s.error)
+
+				return
+			}
+
+			require.NoError(t, err)
+
+			var buf bytes.Buffer
+			require.NoError(t, format.Node(&buf, fset, file))
+			assert.Equal(t, s.output, buf.String())
+		})
+	}
+
+	t.Run("Invalid name", func(t *testing.T) {
+		t.Parallel()
+
+		file, err := parser.ParseFile(token.NewFileSet(), "", "package fixtures\n", 0)
+		require.NoError(t, err)
+		assert.EqualError(t, exporter.InjectVar(file, "my-var", 5), `"my-var" is not a valid identifier`)
+	})
+}
+
+func TestInjectVarFile(t *testing.T) {
+	t.Parallel()
+
+	f, err := ioutil.TempFile("", "fixtures*.go") //nolint:staticcheck
+	require.NoError(t, err)
+
+	defer os.Remove(f.Name())
+
+	_, err = f.WriteString("package fixtures\n\nvar greeting = \"hi\" // a comment\n\nvar numbers []int\n")
+	require.NoError(t, err)
+	require.NoError(t, f.Close())
+
+	require.NoError(t, exporter.InjectVarFile(f.Name(), "greeting", "hello world"))
+	require.NoError(t, exporter.InjectVarFile(f.Name(), "numbers", []int{1}))
+	require.NoError(t, exporter.InjectVarFile(f.Name(), "pi", 3.14))
+	require.NoError(t, exporter.New(exporter.WithGofmt(true)).
+		InjectVarFile(f.Name(), "timeout", time.Second))
+
+	content, err := ioutil.ReadFile(f.Name()) //nolint:staticcheck
+	require.NoError(t, err)
+	assert.Equal(
+		t,
+		`package fixtures
+
+import (
+	"time"
+)
+
+var greeting = "hello world" // a comment
+
+var numbers []int = []int{int(1)}
+
+var pi = float64(3.14)
+
+var timeout = time.Duration(1000000000)
+`,
+		string(content),
+	)
+	vetFile(t, content)
+}
+
+func TestInjectVarFile_imports(t *testing.T) {
+	t.Parallel()
+
+	scenarios := map[string]string{
+		"package fixtures\n\nimport \"fmt\"\n\nvar _ = fmt.Sprint\n":   "import \"fmt\"\n\nimport (\n\t\"time\"\n)\n",
+		"package fixtures\n\nimport (\"fmt\")\n\nvar _ = fmt.Sprint\n": "import (\n\t\"fmt\"\n\t\"time\"\n)\n",
+	}
+
+	for input, imports := range scenarios {
+		input, imports := input, imports
+
+		t.Run(input, func(t *testing.T) {
+			t.Parallel()
+
+			f, err := ioutil.TempFile("", "fixtures*.go") //nolint:staticcheck
+			require.NoError(t, err)
+
+			defer os.Remove(f.Name())
+
+			_, err = f.WriteString(input)
+			require.NoError(t, err)
+			require.NoError(t, f.Close())
+			require.NoError(t, exporter.InjectVarFile(f.Name(), "timeout", time.Second))
+
+			content, err := ioutil.ReadFile(f.Name()) //nolint:staticcheck
+			require.NoError(t, err)
+			assert.Equal(
+				t,
+				"package fixtures\n\n"+imports+"\nvar _ = fmt.Sprint\n\nvar timeout = time.Duration(1000000000)\n",
+				string(content),
+			)
+		})
+	}
+}
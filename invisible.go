@@ -0,0 +1,104 @@
+// Copyright (c) 2023–present Bartłomiej Krukowski
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is furnished
+// to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package exporter
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"unicode"
+	"unicode/utf16"
+)
+
+// WithEscapedInvisibles makes backends escape invisible and ambiguous characters in strings,
+// e.g. byte order marks, non-breaking spaces and zero-width joiners:
+//
+//	"\ufeffhello\u00a0world" // instead of "hello world" with an invisible BOM and a non-breaking space
+//
+// Other characters are rendered as they are, so fixtures stay readable, but the invisible ones are
+// distinguishable in review. The GO backend applies this option to the styles QuoteUnicode and QuoteMinimal,
+// the default style QuoteASCII escapes all non-ASCII characters anyway, see WithQuoteStyle.
+func WithEscapedInvisibles() Option {
+	return func(c *config) {
+		c.escapeInvisibles = true
+	}
+}
+
+// isInvisible reports whether the given character is invisible or it may be confused with a regular space.
+// It covers format characters (e.g. U+FEFF and U+200D), space separators other than the space,
+// and all other characters that are not graphic.
+func isInvisible(r rune) bool {
+	return r != ' ' && (!unicode.IsGraphic(r) || unicode.In(r, unicode.Zs, unicode.Cf))
+}
+
+// escapeInvisibles escapes invisible characters in the given string literal rendered by the given backend,
+// see WithEscapedInvisibles.
+func escapeInvisibles(b Backend, code string) string {
+	var escape func(r rune) string
+
+	switch b.(type) {
+	case jsonBackend:
+		escape = func(r rune) string {
+			if r1, r2 := utf16.EncodeRune(r); r1 != unicode.ReplacementChar {
+				return fmt.Sprintf(`\u%04x\u%04x`, r1, r2)
+			}
+
+			return fmt.Sprintf(`\u%04x`, r)
+		}
+	case goBackend:
+		if b.(goBackend).quote == QuoteASCII {
+			return code
+		}
+
+		escape = func(r rune) string {
+			// the shortest escape sequence, e.g. \t or \u200b
+			return strings.Trim(strconv.QuoteRuneToASCII(r), "'")
+		}
+	case cueBackend:
+		escape = func(r rune) string {
+			if r > 0xffff { //nolint:gomnd
+				return fmt.Sprintf(`\U%08x`, r)
+			}
+
+			return fmt.Sprintf(`\u%04x`, r)
+		}
+	default:
+		return code
+	}
+
+	if strings.IndexFunc(code, isInvisible) < 0 {
+		return code
+	}
+
+	buf := strings.Builder{}
+
+	for _, r := range code {
+		if isInvisible(r) {
+			buf.WriteString(escape(r))
+
+			continue
+		}
+
+		buf.WriteRune(r)
+	}
+
+	return buf.String()
+}
@@ -0,0 +1,96 @@
+// Copyright (c) 2023–present Bartłomiej Krukowski
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is furnished
+// to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package exporter_test
+
+import (
+	"testing"
+
+	"github.com/gontainer/exporter"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestWithEscapedInvisibles(t *testing.T) {
+	t.Parallel()
+
+	input := []any{"\ufeffzażółć\u00a0jaźń\u200d!", []byte("a\u2060b"), "\U000e0001 \x7f"}
+
+	//nolint:exhaustruct
+	scenarios := []struct {
+		name    string
+		backend exporter.Backend
+		options []exporter.Option
+		output  string
+		escaped string
+	}{
+		{
+			name:    "JSON",
+			backend: exporter.JSONBackend(),
+			output:  "[\"\ufeffzażółć\u00a0jaźń\u200d!\",\"a\u2060b\",\"\U000e0001 \x7f\"]",
+			escaped: `["\ufeffzażółć\u00a0jaźń\u200d!","a\u2060b","\udb40\udc01 \u007f"]`,
+		},
+		{
+			name:    "CUE",
+			backend: exporter.CUEBackend(),
+			output:  "[\"\ufeffzażółć\u00a0jaźń\u200d!\", 'a\\xe2\\x81\\xa0b', \"\U000e0001 \x7f\"]",
+			escaped: `["\ufeffzażółć\u00a0jaźń\u200d!", 'a\xe2\x81\xa0b', "\U000e0001 \u007f"]`,
+		},
+		{
+			name:    "GO",
+			backend: exporter.GoBackend(),
+			output: `[]interface{}{"\ufeffza\u017c\u00f3\u0142\u0107\u00a0ja\u017a\u0144\u200d!", ` +
+				`[]byte("a\u2060b"), "\U000e0001 \x7f"}`,
+		},
+		{
+			name:    "GO unicode",
+			backend: exporter.GoBackend(),
+			options: []exporter.Option{exporter.WithQuoteStyle(exporter.QuoteUnicode)},
+			output:  "[]interface{}{\"\\ufeffzażółć\\u00a0jaźń\\u200d!\", []byte(\"a\\u2060b\"), \"\\U000e0001 \\x7f\"}",
+		},
+		{
+			name:    "GO minimal",
+			backend: exporter.GoBackend(),
+			options: []exporter.Option{exporter.WithQuoteStyle(exporter.QuoteMinimal)},
+			output:  "[]interface{}{\"\\ufeffzażółć\u00a0jaźń\u200d!\", []byte(\"a\u2060b\"), \"\U000e0001 \\x7f\"}",
+			escaped: `[]interface{}{"\ufeffzażółć\u00a0jaźń\u200d!", []byte("a\u2060b"), "\U000e0001 \x7f"}`,
+		},
+	}
+
+	for _, s := range scenarios {
+		s := s
+
+		t.Run(s.name, func(t *testing.T) {
+			t.Parallel()
+
+			opts := append([]exporter.Option{exporter.WithBackend(s.backend)}, s.options...)
+			assert.Equal(t, s.output, exporter.New(opts...).MustExport(input))
+			// the style QuoteASCII escapes all non-ASCII characters anyway
+			if s.escaped == "" {
+				s.escaped = s.output
+			}
+
+			assert.Equal(
+				t,
+				s.escaped,
+				exporter.New(append(opts, exporter.WithEscapedInvisibles())...).MustExport(input),
+			)
+		})
+	}
+}
@@ -0,0 +1,124 @@
+// Copyright (c) 2023–present Bartłomiej Krukowski
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is furnished
+// to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package exporter
+
+import (
+	"fmt"
+	"reflect"
+)
+
+// WithIterators enables exporting iterators, e.g. iter.Seq[T] and iter.Seq2[K, V] added in GO 1.23.
+// Iterators are drained, and the yielded values are exported as slices and maps respectively, e.g.:
+//
+//	[]int{int(1), int(2)}
+//	map[string]int{"a": int(1)}
+//
+// Values yielded by iter.Seq2 with duplicated keys overwrite each other.
+// The exporter fails, when an iterator yields more than maxElements values.
+// A non-positive maxElements means no limit, in that case exporting an infinite iterator never ends.
+func WithIterators(maxElements int) Option {
+	return func(c *config) {
+		c.iterators = true
+		c.iteratorLimit = maxElements
+	}
+}
+
+// iteratorExporter drains iterators, and exports yielded values, see WithIterators.
+// Iterators are functions of the following types:
+//
+//	func(yield func(V) bool)
+//	func(yield func(K, V) bool)
+type iteratorExporter struct {
+	exporter    exporter
+	maxElements int
+}
+
+func (i iteratorExporter) export(v any) (string, error) {
+	val := reflect.ValueOf(v)
+	yieldType := val.Type().In(0)
+	seq2 := yieldType.NumIn() == 2 //nolint:gomnd
+
+	var collected reflect.Value
+	if seq2 {
+		collected = reflect.MakeMap(reflect.MapOf(yieldType.In(0), yieldType.In(1)))
+	} else {
+		collected = reflect.MakeSlice(reflect.SliceOf(yieldType.In(0)), 0, 0)
+	}
+
+	if val.IsNil() {
+		// a nil iterator yields nothing, but it cannot be called
+		return i.exporter.export(reflect.Zero(collected.Type()).Interface())
+	}
+
+	count := 0
+	exceeded := false
+
+	yield := reflect.MakeFunc(yieldType, func(args []reflect.Value) []reflect.Value {
+		if i.maxElements > 0 && count >= i.maxElements {
+			exceeded = true
+
+			return []reflect.Value{reflect.ValueOf(false)}
+		}
+
+		count++
+
+		if seq2 {
+			collected.SetMapIndex(args[0], args[1])
+		} else {
+			collected = reflect.Append(collected, args[0])
+		}
+
+		return []reflect.Value{reflect.ValueOf(true)}
+	})
+
+	val.Call([]reflect.Value{yield})
+
+	if exceeded {
+		return "", fmt.Errorf( //nolint:goerr113
+			"iterator %s yields more than %d elements, see WithIterators",
+			typeName(val.Type()),
+			i.maxElements,
+		)
+	}
+
+	return i.exporter.export(collected.Interface())
+}
+
+func (iteratorExporter) supports(v any) bool {
+	t := reflect.TypeOf(v)
+	if t == nil || t.Kind() != reflect.Func || t.NumIn() != 1 || t.NumOut() != 0 {
+		return false
+	}
+
+	yield := t.In(0)
+	if yield.Kind() != reflect.Func || yield.NumOut() != 1 || yield.Out(0) != reflect.TypeOf(true) {
+		return false
+	}
+
+	switch yield.NumIn() {
+	case 1:
+		return true
+	case 2: //nolint:gomnd
+		return yield.In(0).Comparable()
+	}
+
+	return false
+}
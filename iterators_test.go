@@ -0,0 +1,129 @@
+// Copyright (c) 2023–present Bartłomiej Krukowski
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is furnished
+// to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package exporter_test
+
+import (
+	"testing"
+
+	"github.com/gontainer/exporter"
+	"github.com/stretchr/testify/assert"
+)
+
+func iteratorsCount(n int) func(func(int) bool) {
+	return func(yield func(int) bool) {
+		for i := 0; i < n; i++ {
+			if !yield(i) {
+				return
+			}
+		}
+	}
+}
+
+func iteratorsNaturals(yield func(int) bool) {
+	for i := 0; ; i++ {
+		if !yield(i) {
+			return
+		}
+	}
+}
+
+//nolint:testifylint
+func TestWithIterators(t *testing.T) {
+	t.Parallel()
+
+	//nolint:exhaustruct
+	scenarios := []struct {
+		name    string
+		input   any
+		output  string
+		error   string
+		options []exporter.Option
+	}{
+		{
+			name:    "Sequence",
+			input:   iteratorsCount(3),
+			output:  `[]int{int(0), int(1), int(2)}`,
+			options: []exporter.Option{exporter.WithIterators(0)},
+		},
+		{
+			name:    "Empty sequence",
+			input:   iteratorsCount(0),
+			output:  `make([]int, 0)`,
+			options: []exporter.Option{exporter.WithIterators(0)},
+		},
+		{
+			name:    "Nil sequence",
+			input:   (func(func(string) bool))(nil),
+			output:  `([]string)(nil)`,
+			options: []exporter.Option{exporter.WithIterators(0)},
+		},
+		{
+			name: "Pairs",
+			input: func(yield func(string, int) bool) {
+				_ = yield("b", 2) && yield("a", 1)
+			},
+			output:  `map[string]int{"a": int(1), "b": int(2)}`,
+			options: []exporter.Option{exporter.WithIterators(0)},
+		},
+		{
+			name:    "Within the limit",
+			input:   iteratorsCount(3),
+			output:  `[]int{int(0), int(1), int(2)}`,
+			options: []exporter.Option{exporter.WithIterators(3)},
+		},
+		{
+			name:    "Infinite sequence",
+			input:   iteratorsNaturals,
+			error:   `iterator func(func(int) bool) yields more than 100 elements, see WithIterators`,
+			options: []exporter.Option{exporter.WithIterators(100)},
+		},
+		{
+			name:  "Disabled",
+			input: iteratorsCount(3),
+			error: `type func(func(int) bool) is not supported`,
+		},
+		{
+			name:    "Unsupported function",
+			input:   func(int) bool { return true },
+			error:   `type func(int) bool is not supported`,
+			options: []exporter.Option{exporter.WithIterators(0)},
+		},
+	}
+
+	for _, s := range scenarios {
+		s := s
+
+		t.Run(s.name, func(t *testing.T) {
+			t.Parallel()
+
+			output, err := exporter.New(s.options...).Export(s.input)
+			if s.error != "" {
+				assert.EqualError(t, err, s.error)
+				assert.Empty(t, output)
+
+				return
+			}
+
+			assert.NoError(t, err)
+			assert.Equal(t, s.output, output)
+		})
+	}
+}
@@ -0,0 +1,141 @@
+// Copyright (c) 2023–present Bartłomiej Krukowski
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is furnished
+// to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package exporter
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"math"
+)
+
+// JSONNumbers defines how ExportJSON maps JSON numbers to GO types, see WithJSONNumbers.
+type JSONNumbers int
+
+const (
+	// JSONNumbersFloat64 maps all numbers to float64, like encoding/json does by default.
+	JSONNumbersFloat64 JSONNumbers = iota
+	// JSONNumbersNumber maps all numbers to json.Number, so they keep their original text, e.g. json.Number("1e3").
+	JSONNumbersNumber
+	// JSONNumbersIntegral maps integral numbers that fit in int to int, e.g. 1 or 1e3, and other numbers to float64.
+	// Numbers in the exponent notation are mapped to int only if their absolute values do not exceed 2^53.
+	JSONNumbersIntegral
+)
+
+// WithJSONNumbers sets how ExportJSON maps JSON numbers to GO types. The default value is JSONNumbersFloat64.
+func WithJSONNumbers(n JSONNumbers) Option {
+	return func(c *config) {
+		c.jsonNumbers = n
+	}
+}
+
+// ExportJSON decodes the given JSON document to map[string]any, []any and primitives,
+// and exports the result, e.g.:
+//
+//	exporter.ExportJSON(strings.NewReader(`{"ids": [1, 2]}`))
+//	// map[string]interface{}{"ids": []interface{}{float64(1), float64(2)}}
+//
+// Use bytes.NewReader for documents held in memory. The reader must contain exactly one document.
+//
+// See Exporter.ExportJSON and WithJSONNumbers.
+func ExportJSON(r io.Reader) (string, error) {
+	return defaultExporter.ExportJSON(r)
+}
+
+// ExportJSON decodes the given JSON document and exports the result, see the function ExportJSON.
+func (e *Exporter) ExportJSON(r io.Reader) (string, error) {
+	v, err := decodeJSON(r, e.config.jsonNumbers)
+	if err != nil {
+		return "", err
+	}
+
+	return e.Export(v)
+}
+
+// decodeJSON decodes exactly one JSON document, and maps its numbers according to the given mode.
+func decodeJSON(r io.Reader, numbers JSONNumbers) (any, error) {
+	d := json.NewDecoder(r)
+	d.UseNumber()
+
+	var v any
+	if err := d.Decode(&v); err != nil {
+		return nil, fmt.Errorf("cannot decode JSON: %w", err)
+	}
+
+	var extra any
+	if err := d.Decode(&extra); !errors.Is(err, io.EOF) {
+		return nil, errors.New("cannot decode JSON: unexpected data after the top-level value") //nolint:goerr113
+	}
+
+	return mapJSONNumbers(v, numbers)
+}
+
+func mapJSONNumbers(v any, numbers JSONNumbers) (any, error) {
+	var err error
+
+	switch v := v.(type) {
+	case map[string]any:
+		for k, x := range v {
+			if v[k], err = mapJSONNumbers(x, numbers); err != nil {
+				return nil, err
+			}
+		}
+	case []any:
+		for i, x := range v {
+			if v[i], err = mapJSONNumbers(x, numbers); err != nil {
+				return nil, err
+			}
+		}
+	case json.Number:
+		return mapJSONNumber(v, numbers)
+	}
+
+	return v, nil
+}
+
+// maxExactFloat is the greatest integer such that all smaller integers are representable by float64.
+const maxExactFloat = 1 << 53
+
+func mapJSONNumber(n json.Number, numbers JSONNumbers) (any, error) {
+	switch numbers {
+	case JSONNumbersNumber:
+		return n, nil
+	case JSONNumbersIntegral:
+		if i, err := n.Int64(); err == nil && int64(int(i)) == i {
+			return int(i), nil
+		}
+	case JSONNumbersFloat64:
+	}
+
+	// the decoder has verified the syntax, so the error is caused by the range, e.g. 1e400
+	f, err := n.Float64()
+	if err != nil {
+		return nil, fmt.Errorf("cannot decode JSON: number %s is out of the range of float64", n) //nolint:goerr113
+	}
+
+	// e.g. 1e3
+	if numbers == JSONNumbersIntegral && f == math.Trunc(f) && math.Abs(f) <= maxExactFloat && float64(int(f)) == f {
+		return int(f), nil
+	}
+
+	return f, nil
+}
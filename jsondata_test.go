@@ -0,0 +1,108 @@
+// Copyright (c) 2023–present Bartłomiej Krukowski
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is furnished
+// to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package exporter_test
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/gontainer/exporter"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestExportJSON(t *testing.T) {
+	t.Parallel()
+
+	const input = `{"ids": [1, -2, 1.5, 1e3, 9223372036854775808], "name": "Mary", "admin": true, "manager": null}`
+
+	//nolint:exhaustruct
+	scenarios := []struct {
+		name    string
+		input   string
+		output  string
+		error   string
+		options []exporter.Option
+	}{
+		{
+			name:  "Float64",
+			input: input,
+			output: `map[string]interface{}{"admin": true, "ids": []interface{}{float64(1), float64(-2), float64(1.5), ` +
+				`float64(1000), float64(9223372036854776000)}, "manager": nil, "name": "Mary"}`,
+		},
+		{
+			name:  "Numbers",
+			input: input,
+			output: `map[string]interface{}{"admin": true, "ids": []interface{}{json.Number("1"), json.Number("-2"), ` +
+				`json.Number("1.5"), json.Number("1e3"), json.Number("9223372036854775808")}, "manager": nil, "name": "Mary"}`,
+			options: []exporter.Option{exporter.WithJSONNumbers(exporter.JSONNumbersNumber)},
+		},
+		{
+			name:  "Integral",
+			input: input,
+			output: `map[string]interface{}{"admin": true, "ids": []interface{}{int(1), int(-2), float64(1.5), ` +
+				`int(1000), float64(9223372036854776000)}, "manager": nil, "name": "Mary"}`,
+			options: []exporter.Option{exporter.WithJSONNumbers(exporter.JSONNumbersIntegral)},
+		},
+		{
+			name:   "Scalar",
+			input:  ` "hello" `,
+			output: `"hello"`,
+		},
+		{
+			name:  "Invalid JSON",
+			input: `{"a": }`,
+			error: "cannot decode JSON: invalid character '}' looking for beginning of value",
+		},
+		{
+			name:  "Many documents",
+			input: `{} {}`,
+			error: "cannot decode JSON: unexpected data after the top-level value",
+		},
+		{
+			name:  "Out of range",
+			input: `[1e400]`,
+			error: "cannot decode JSON: number 1e400 is out of the range of float64",
+		},
+	}
+
+	for _, s := range scenarios {
+		s := s
+
+		t.Run(s.name, func(t *testing.T) {
+			t.Parallel()
+
+			output, err := exporter.New(s.options...).ExportJSON(strings.NewReader(s.input))
+			if s.error != "" {
+				assert.EqualError(t, err, s.error)
+				assert.Empty(t, output)
+
+				return
+			}
+
+			assert.NoError(t, err)
+			assert.Equal(t, s.output, output)
+		})
+	}
+
+	output, err := exporter.ExportJSON(strings.NewReader(`[]`))
+	assert.NoError(t, err)
+	assert.Equal(t, `make([]interface{}, 0)`, output)
+}
@@ -0,0 +1,161 @@
+// Copyright (c) 2023–present Bartłomiej Krukowski
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is furnished
+// to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package exporter
+
+import (
+	"fmt"
+)
+
+const (
+	// DefaultMaxLiteralElements is the default maximal number of elements of a single composite literal,
+	// see WithLiteralLimits.
+	DefaultMaxLiteralElements = 10000
+	// DefaultMaxLiteralDepth is the default maximal nesting depth of exported values, see WithLiteralLimits.
+	DefaultMaxLiteralDepth = 1000
+)
+
+// WithLiteralLimits sets practical limits of the generated code.
+//
+// Slices and arrays with more than maxElements elements are not rendered as a single composite literal,
+// they are built by a function literal from chunks of at most maxElements elements:
+//
+//	func() []int { v := make([]int, 0, 20000); v = append(v, int(1), ...); v = append(v, ...); return v }()
+//
+// Maps with more than maxElements entries are built the same way from chunks of at most maxElements entries:
+//
+//	func() map[string]int {
+//		v := make(map[string]int, 20000); for k, e := range map[string]int{...} { v[k] = e }; ...; return v
+//	}()
+//
+// Chunks are reported to the function set by WithWarnings.
+// Values nested deeper than maxDepth cannot be exported, since the compiler may reject the generated code.
+// Non-positive values disable the given limit.
+func WithLiteralLimits(maxElements int, maxDepth int) Option {
+	return func(c *config) {
+		c.maxElements = maxElements
+		c.maxDepth = maxDepth
+	}
+}
+
+// WithMaxNodes limits the total number of values visited while exporting a single value, e.g. elements of slices,
+// keys and values of maps, and fields of structs. The exporter fails when the limit is exceeded,
+// so services that export user-provided values can bound the time of exporting them regardless of their shapes.
+// A non-positive value disables the limit, it is the default behavior.
+func WithMaxNodes(n int) Option {
+	return func(c *config) {
+		c.maxNodes = n
+	}
+}
+
+// WithWarnings sets the function that receives warnings about the generated code, e.g. see WithLiteralLimits.
+func WithWarnings(fn func(warning string)) Option {
+	return func(c *config) {
+		if fn == nil {
+			fn = func(string) {}
+		}
+
+		c.warn = fn
+	}
+}
+
+// depthExporter fails when values are nested deeper than the given limit, see WithLiteralLimits.
+type depthExporter struct {
+	maxDepth int
+	depth    int
+	next     exporter
+}
+
+func newDepthExporter(maxDepth int, next exporter) *depthExporter {
+	return &depthExporter{
+		maxDepth: maxDepth,
+		depth:    0,
+		next:     next,
+	}
+}
+
+func (d *depthExporter) export(v any) (string, error) {
+	d.depth++
+	defer func() {
+		d.depth--
+	}()
+
+	if d.depth > d.maxDepth {
+		return "", fmt.Errorf( //nolint:goerr113
+			"values are nested deeper than %d levels, the compiler may reject the generated code, see WithLiteralLimits",
+			d.maxDepth,
+		)
+	}
+
+	return d.next.export(v) //nolint:wrapcheck
+}
+
+func (d *depthExporter) supports(v any) bool {
+	return d.next.supports(v)
+}
+
+// nodesExporter fails when the number of exported values exceeds the given limit, see WithMaxNodes.
+type nodesExporter struct {
+	maxNodes int
+	nodes    int
+	next     exporter
+}
+
+func newNodesExporter(maxNodes int, next exporter) *nodesExporter {
+	return &nodesExporter{
+		maxNodes: maxNodes,
+		nodes:    0,
+		next:     next,
+	}
+}
+
+func (n *nodesExporter) export(v any) (string, error) {
+	n.nodes++
+	if n.nodes > n.maxNodes {
+		return "", fmt.Errorf("the value contains more than %d nodes, see WithMaxNodes", n.maxNodes) //nolint:goerr113
+	}
+
+	return n.next.export(v) //nolint:wrapcheck
+}
+
+func (n *nodesExporter) supports(v any) bool {
+	return n.next.supports(v)
+}
+
+func chunks(elements []string, size int) [][]string {
+	r := make([][]string, 0, (len(elements)+size-1)/size)
+
+	for len(elements) > size {
+		r = append(r, elements[:size])
+		elements = elements[size:]
+	}
+
+	return append(r, elements)
+}
+
+// flatten reverses chunks.
+func flatten(chunks [][]string) []string {
+	r := make([]string, 0)
+	for _, c := range chunks {
+		r = append(r, c...)
+	}
+
+	return r
+}
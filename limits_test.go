@@ -0,0 +1,166 @@
+// Copyright (c) 2023–present Bartłomiej Krukowski
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is furnished
+// to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package exporter_test
+
+import (
+	"testing"
+
+	"github.com/gontainer/exporter"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestWithLiteralLimits(t *testing.T) {
+	t.Parallel()
+
+	newExporter := func(maxElements, maxDepth int, opts ...exporter.Option) (*exporter.Exporter, *[]string) {
+		warnings := make([]string, 0)
+		opts = append(
+			opts,
+			exporter.WithLiteralLimits(maxElements, maxDepth),
+			exporter.WithWarnings(func(w string) {
+				warnings = append(warnings, w)
+			}),
+		)
+
+		return exporter.New(opts...), &warnings
+	}
+
+	t.Run("Slice", func(t *testing.T) {
+		t.Parallel()
+
+		e, warnings := newExporter(2, 0)
+		assert.Equal(
+			t,
+			"func() []int { v := make([]int, 0, 5); "+
+				"v = append(v, int(1), int(2)); v = append(v, int(3), int(4)); v = append(v, int(5)); return v }()",
+			e.MustExport([]int{1, 2, 3, 4, 5}),
+		)
+		assert.Equal(t, []string{"([]int) has 5 elements, it is built from chunks of 2 elements"}, *warnings)
+	})
+
+	t.Run("Array", func(t *testing.T) {
+		t.Parallel()
+
+		e, warnings := newExporter(2, 0)
+		assert.Equal(
+			t,
+			"func() [3]interface{} { var v [3]interface{}; "+
+				`copy(v[0:], []interface{}{int(1), "2"}); copy(v[2:], []interface{}{nil}); return v }()`,
+			e.MustExport([3]any{1, "2"}),
+		)
+		assert.Len(t, *warnings, 1)
+	})
+
+	t.Run("Within limits", func(t *testing.T) {
+		t.Parallel()
+
+		e, warnings := newExporter(3, 3)
+		assert.Equal(t, "[][]int{[]int{int(1), int(2), int(3)}}", e.MustExport([][]int{{1, 2, 3}}))
+		assert.Empty(t, *warnings)
+	})
+
+	t.Run("Depth", func(t *testing.T) {
+		t.Parallel()
+
+		e, warnings := newExporter(0, 2)
+		_, err := e.Export([][][]int{{{1}, {2}}})
+		assert.EqualError(
+			t,
+			err,
+			"cannot export ([][][]int)[0]: cannot export ([][]int)[0]: values are nested deeper than 2 levels, "+
+				"the compiler may reject the generated code, see WithLiteralLimits",
+		)
+		assert.Empty(t, *warnings)
+
+		assert.Equal(t, "[]int{int(1)}", e.MustExport([]int{1}))
+	})
+
+	t.Run("Map", func(t *testing.T) {
+		t.Parallel()
+
+		e, warnings := newExporter(2, 0)
+		assert.Equal(
+			t,
+			"func() map[string]int { v := make(map[string]int, 3); "+
+				`for k, e := range map[string]int{"a": int(1), "b": int(2)} { v[k] = e }; `+
+				`for k, e := range map[string]int{"c": int(3)} { v[k] = e }; return v }()`,
+			e.MustExport(map[string]int{"a": 1, "b": 2, "c": 3}),
+		)
+		assert.Equal(t, []string{"(map[string]int) has 3 entries, it is built from chunks of 2 entries"}, *warnings)
+
+		code, err := e.ExportFile("fixtures", "Counters", map[string]int{"a": 1, "b": 2, "c": 3})
+		require.NoError(t, err)
+		vetFile(t, code)
+	})
+
+	t.Run("JSON map", func(t *testing.T) {
+		t.Parallel()
+
+		e, warnings := newExporter(2, 0, exporter.WithBackend(exporter.JSONBackend()))
+		assert.Equal(t, `{"a":1,"b":2,"c":3}`, e.MustExport(map[string]int{"a": 1, "b": 2, "c": 3}))
+		assert.Len(t, *warnings, 1)
+	})
+
+	t.Run("JSON", func(t *testing.T) {
+		t.Parallel()
+
+		e, warnings := newExporter(2, 0, exporter.WithBackend(exporter.JSONBackend()))
+		assert.Equal(t, "[1,2,3]", e.MustExport([]int{1, 2, 3}))
+		assert.Len(t, *warnings, 1)
+	})
+}
+
+func TestWithMaxNodes(t *testing.T) {
+	t.Parallel()
+
+	// the slice and its 3 elements
+	input := []any{1, []int{2}}
+
+	t.Run("Within the limit", func(t *testing.T) {
+		t.Parallel()
+
+		code, err := exporter.New(exporter.WithMaxNodes(4)).Export(input)
+		assert.NoError(t, err)
+		assert.Equal(t, `[]interface{}{int(1), []int{int(2)}}`, code)
+	})
+
+	t.Run("Limit exceeded", func(t *testing.T) {
+		t.Parallel()
+
+		e := exporter.New(exporter.WithMaxNodes(3))
+
+		code, err := e.Export(input)
+		assert.EqualError(
+			t,
+			err,
+			`cannot export ([]interface{})[1]: cannot export ([]int)[0]: `+
+				`the value contains more than 3 nodes, see WithMaxNodes`,
+		)
+		assert.Empty(t, code)
+		assert.Equal(t, `[1][0]`, exporter.ErrorPath(err).String())
+
+		// the budget is not shared between calls
+		code, err = e.Export([]int{1, 2})
+		assert.NoError(t, err)
+		assert.Equal(t, `[]int{int(1), int(2)}`, code)
+	})
+}
@@ -0,0 +1,126 @@
+// Copyright (c) 2023–present Bartłomiej Krukowski
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is furnished
+// to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+// Package litter provides an API similar to github.com/sanity-io/litter, but its output is a valid GO code
+// with explicit types, so dumped values can be copied to tests as fixtures.
+package litter
+
+import (
+	"go/scanner"
+	"go/token"
+	"reflect"
+	"regexp"
+	"strings"
+
+	"github.com/gontainer/exporter"
+)
+
+// Options configures the function Sdump.
+type Options struct {
+	// HidePrivateFields omits unexported fields of structs.
+	// Otherwise, non-zero unexported fields cannot be dumped, since they cannot be set by a literal.
+	HidePrivateFields bool
+	// HomePackage is the name of the package whose name is omitted in names of types,
+	// e.g. "Config{}" instead of "mypkg.Config{}" for the value "mypkg".
+	HomePackage string
+	// FieldExclusions omits fields whose names match the given expression.
+	FieldExclusions *regexp.Regexp
+	// ExporterOptions configure the underlying exporter.
+	ExporterOptions []exporter.Option
+}
+
+// Config is the configuration used by the function Sdump.
+//
+//nolint:gochecknoglobals
+var Config = Options{
+	HidePrivateFields: true,
+	HomePackage:       "",
+	FieldExclusions:   nil,
+	ExporterOptions:   nil,
+}
+
+// Sdump dumps the given values to a string, see Options.Sdump.
+func Sdump(values ...interface{}) string {
+	return Config.Sdump(values...)
+}
+
+// Sdump dumps the given values to a string. Values are separated by new lines.
+// Values that cannot be exported are dumped as comments, see exporter.Sdump.
+func (o Options) Sdump(values ...interface{}) string {
+	opts := append([]exporter.Option{exporter.WithFieldFilter(o.includeField)}, o.ExporterOptions...)
+	e := exporter.New(opts...)
+
+	parts := make([]string, len(values))
+	for i, v := range values {
+		parts[i] = trimPackage(e.Sdump(v), o.HomePackage)
+	}
+
+	return strings.Join(parts, "\n")
+}
+
+func (o Options) includeField(f reflect.StructField) bool {
+	if o.HidePrivateFields && f.PkgPath != "" {
+		return false
+	}
+
+	return o.FieldExclusions == nil || !o.FieldExclusions.MatchString(f.Name)
+}
+
+// trimPackage removes qualifiers of the given package from the given code, e.g. "pkg.Type{}" => "Type{}".
+func trimPackage(code string, pkg string) string {
+	if pkg == "" {
+		return code
+	}
+
+	fset := token.NewFileSet()
+	file := fset.AddFile("", fset.Base(), len(code))
+
+	var s scanner.Scanner
+	s.Init(file, []byte(code), nil, scanner.ScanComments)
+
+	buf := strings.Builder{}
+	last := 0
+	qualifier := false
+
+	for {
+		pos, tok, lit := s.Scan()
+		if tok == token.EOF {
+			break
+		}
+
+		switch {
+		case tok == token.IDENT && lit == pkg:
+			qualifier = true
+
+			buf.WriteString(code[last:file.Offset(pos)])
+			last = file.Offset(pos)
+		case qualifier && tok == token.PERIOD:
+			// skip "pkg."
+			last = file.Offset(pos) + 1
+			qualifier = false
+		default:
+			qualifier = false
+		}
+	}
+
+	buf.WriteString(code[last:])
+
+	return buf.String()
+}
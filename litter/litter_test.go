@@ -0,0 +1,81 @@
+// Copyright (c) 2023–present Bartłomiej Krukowski
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is furnished
+// to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package litter_test
+
+import (
+	"regexp"
+	"testing"
+
+	"github.com/gontainer/exporter/litter"
+	"github.com/stretchr/testify/assert"
+)
+
+type litterUser struct {
+	Name     string
+	Password string
+	Friends  []litterUser
+	age      int
+}
+
+func TestSdump(t *testing.T) {
+	t.Parallel()
+
+	u := litterUser{
+		Name:     "Mary",
+		Password: "secret",
+		Friends:  []litterUser{{Name: "Jane", age: 30}},
+		age:      32,
+	}
+
+	t.Run("Default", func(t *testing.T) {
+		t.Parallel()
+
+		assert.Equal(
+			t,
+			`litter_test.litterUser{Name: "Mary", Password: "secret", `+
+				`Friends: []litter_test.litterUser{litter_test.litterUser{Name: "Jane"}}}`+"\n"+`"litter_test.x"`,
+			litter.Sdump(u, "litter_test.x"),
+		)
+	})
+
+	t.Run("Options", func(t *testing.T) {
+		t.Parallel()
+
+		o := litter.Options{ //nolint:exhaustruct
+			HomePackage:     "litter_test",
+			FieldExclusions: regexp.MustCompile(`^Password$`),
+		}
+		assert.Equal(
+			t,
+			`/* cannot export litter_test.litterUser: cannot export (litter_test.litterUser).Friends: `+
+				`cannot export ([]litter_test.litterUser)[0]: cannot export (litter_test.litterUser).age: `+
+				`unexported field is not zero */`,
+			o.Sdump(u),
+		)
+
+		o.HidePrivateFields = true
+		assert.Equal(
+			t,
+			`litterUser{Name: "Mary", Friends: []litterUser{litterUser{Name: "Jane"}}}`+"\n"+`"litter_test.x"`,
+			o.Sdump(u, "litter_test.x"),
+		)
+	})
+}
@@ -0,0 +1,275 @@
+// Copyright (c) 2023–present Bartłomiej Krukowski
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is furnished
+// to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package exporter
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"reflect"
+	"sort"
+)
+
+// WithNilMapsAsEmpty exports nil maps as empty maps, e.g.:
+//
+//	map[string]int{} // instead of (map[string]int)(nil)
+//
+// By default, nil maps and empty maps are distinguished the same way nil slices and empty slices are.
+func WithNilMapsAsEmpty() Option {
+	return func(c *config) {
+		c.nilMapsAsEmpty = true
+	}
+}
+
+// mapExporter exports maps as composite literals with sorted keys, e.g.:
+//
+//	map[string]int{"a": int(1), "b": int(2)}
+//
+// Keys that contain NaN cannot be exported, since such keys are not equal to anything, including themselves.
+// Distinct keys exported to the same code cannot be exported either, e.g. floats rounded by WithFloatPrecision,
+// since the literal would not compile, or it would lose entries.
+// Entries with zero values are omitted in the sparse mode, see WithSparse.
+// Nil maps are exported as conversions of nil, unless WithNilMapsAsEmpty is used.
+// Defined map types are exported by their names, e.g. pkg.Users{"mary": pkg.User{Name: "Mary"}}.
+// Pointers shared by many entries are assigned to variables, so the exported map preserves aliasing, e.g.:
+//
+//	func() map[string]*list.List { v1 := list.New(); return map[string]*list.List{"a": v1, "b": v1} }()
+type mapExporter struct {
+	exporter    exporter
+	backend     Backend
+	sparse      bool
+	annotations int
+	// integralFloats is the type of integers that replace integral floats, see WithIntegralFloats
+	integralFloats reflect.Type
+	elide          scalarElision
+	nilAsEmpty     bool
+	nils           nilStyles
+	// elideLiterals omits types of keys and values, see WithCompositeElision
+	elideLiterals bool
+	// enums contains names of constants that replace keys, see WithEnumKeys
+	enums enumNames
+	// maps with more entries are built from chunks, see WithLiteralLimits
+	maxElements int
+	warn        func(string)
+}
+
+func (m mapExporter) export(v any) (string, error) {
+	val := reflect.ValueOf(v)
+	t := val.Type()
+
+	if val.IsNil() && !m.nilAsEmpty {
+		return m.backend.renderNilMap(t), nil
+	}
+
+	type entry struct {
+		key        reflect.Value
+		code, elem string
+		target     cacheKey // the target of the pointer value, see pointerTarget
+	}
+
+	entries := make([]entry, 0, val.Len())
+	iter := val.MapRange()
+
+	for iter.Next() {
+		if m.sparse && isZero(iter.Value()) {
+			continue
+		}
+
+		k, err := m.key(iter.Key())
+		if err != nil {
+			return "", fmt.Errorf("cannot export key of (%s): %w", typeName(t), err)
+		}
+
+		if containsNaN(iter.Key()) {
+			return "", newPathError(
+				typeName(t),
+				KeyStep(k),
+				errors.New("NaN key cannot be reproduced in a map literal"), //nolint:goerr113
+			)
+		}
+
+		e, err := m.exporter.export(integralFloat(m.integralFloats, t.Elem(), iter.Value().Interface()))
+		if err != nil {
+			return "", newPathError(typeName(t), KeyStep(k), err)
+		}
+
+		k = elideComposite(m.elideLiterals, t.Key(), elideScalar(m.elide, t.Key(), k))
+		e = elideComposite(m.elideLiterals, t.Elem(), elideScalar(m.elide, t.Elem(), e))
+		e = m.nils.render(m.backend, t.Elem(), iter.Value(), e)
+		entries = append(entries, entry{key: iter.Key(), code: k, elem: e, target: pointerTarget(iter.Value())})
+	}
+
+	sort.SliceStable(entries, func(i, j int) bool {
+		return lessKey(entries[i].key, entries[j].key, entries[i].code, entries[j].code)
+	})
+
+	targets := make(map[cacheKey]int)
+	codes := make(map[string]struct{}, len(entries))
+
+	for _, e := range entries {
+		if _, ok := codes[e.code]; ok {
+			return "", newPathError(
+				typeName(t),
+				KeyStep(e.code),
+				errors.New("distinct keys are exported to the same code"), //nolint:goerr113
+			)
+		}
+
+		codes[e.code] = struct{}{}
+		targets[e.target]++
+	}
+
+	keys := make([]string, len(entries))
+	elems := make([]string, len(entries))
+	vars := make(map[cacheKey]string)
+	decls := ""
+
+	for i, e := range entries {
+		keys[i] = e.code
+		elems[i] = e.elem
+
+		if _, ok := m.backend.(goBackend); ok && e.target.ptr != 0 && targets[e.target] > 1 {
+			name, ok := vars[e.target]
+			if !ok {
+				name = fmt.Sprintf("v%d", len(vars)+1)
+				vars[e.target] = name
+				decls += name + " := " + e.elem + "; "
+			}
+
+			elems[i] = name
+		}
+
+		if annotate(m.annotations, len(entries)) {
+			elems[i] = m.backend.renderAnnotation(elems[i], "["+e.code+"]")
+		}
+	}
+
+	var (
+		code string
+		err  error
+	)
+
+	if m.maxElements > 0 && len(keys) > m.maxElements {
+		m.warn(fmt.Sprintf(
+			"(%s) has %d entries, it is built from chunks of %d entries",
+			typeName(t),
+			len(keys),
+			m.maxElements,
+		))
+
+		code, err = m.backend.renderChunkedMap(t, chunks(keys, m.maxElements), chunks(elems, m.maxElements))
+	} else {
+		code, err = m.backend.renderMap(t, keys, elems)
+	}
+
+	if err != nil || decls == "" {
+		return code, err //nolint:wrapcheck
+	}
+
+	return fmt.Sprintf("func() %s { %sreturn %s }()", typeName(t), decls, code), nil
+}
+
+// key exports the given key of a map, see WithEnumKeys.
+func (m mapExporter) key(k reflect.Value) (string, error) {
+	if _, ok := m.backend.(goBackend); ok {
+		if name, ok := m.enums.name(k); ok {
+			return name, nil
+		}
+	}
+
+	return m.exporter.export(k.Interface())
+}
+
+// pointerTarget returns the key of the target of the given pointer, or the zero key for other values.
+func pointerTarget(v reflect.Value) cacheKey {
+	if v.Kind() == reflect.Interface {
+		v = v.Elem()
+	}
+
+	if v.Kind() != reflect.Ptr || v.IsNil() {
+		return cacheKey{t: nil, ptr: 0, len: 0}
+	}
+
+	return cacheKey{t: v.Type(), ptr: v.Pointer(), len: 0}
+}
+
+func (m mapExporter) supports(v any) bool {
+	t := reflect.TypeOf(v)
+	if t == nil || t.Kind() != reflect.Map {
+		return false
+	}
+
+	return supportsType(m.exporter, t.Key()) && supportsType(m.exporter, t.Elem())
+}
+
+// lessKey compares keys of the same basic kinds by their values, and other keys by their codes.
+func lessKey(a, b reflect.Value, aCode, bCode string) bool {
+	if a.Kind() == reflect.Interface {
+		a = a.Elem()
+	}
+
+	if b.Kind() == reflect.Interface {
+		b = b.Elem()
+	}
+
+	if a.IsValid() && b.IsValid() && a.Kind() == b.Kind() {
+		switch a.Kind() { //nolint:exhaustive
+		case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+			return a.Int() < b.Int()
+		case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+			return a.Uint() < b.Uint()
+		case reflect.Float32, reflect.Float64:
+			return a.Float() < b.Float()
+		case reflect.String:
+			return a.String() < b.String()
+		}
+	}
+
+	return aCode < bCode
+}
+
+// containsNaN reports whether the given value is NaN, or it contains NaN.
+func containsNaN(v reflect.Value) bool {
+	switch v.Kind() { //nolint:exhaustive
+	case reflect.Float32, reflect.Float64:
+		return math.IsNaN(v.Float())
+	case reflect.Complex64, reflect.Complex128:
+		c := v.Complex()
+
+		return math.IsNaN(real(c)) || math.IsNaN(imag(c))
+	case reflect.Interface:
+		return !v.IsNil() && containsNaN(v.Elem())
+	case reflect.Array:
+		for i := 0; i < v.Len(); i++ {
+			if containsNaN(v.Index(i)) {
+				return true
+			}
+		}
+	case reflect.Struct:
+		for i := 0; i < v.NumField(); i++ {
+			if containsNaN(v.Field(i)) {
+				return true
+			}
+		}
+	}
+
+	return false
+}
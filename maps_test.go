@@ -0,0 +1,216 @@
+// Copyright (c) 2023–present Bartłomiej Krukowski
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is furnished
+// to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package exporter_test
+
+import (
+	"container/list"
+	"math"
+	"testing"
+
+	"github.com/gontainer/exporter"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+//nolint:testifylint
+func TestExport_map(t *testing.T) {
+	t.Parallel()
+
+	//nolint:exhaustruct
+	scenarios := []struct {
+		name   string
+		input  any
+		output string
+		json   string
+		error  string
+	}{
+		{
+			name:   "Nil",
+			input:  (map[string]int)(nil),
+			output: `(map[string]int)(nil)`,
+			json:   `null`,
+		},
+		{
+			name:   "Empty",
+			input:  map[string]int{},
+			output: `map[string]int{}`,
+			json:   `{}`,
+		},
+		{
+			name:   "Sorted keys",
+			input:  map[int]string{10: "ten", 2: "two", -1: "minus one"},
+			output: `map[int]string{int(-1): "minus one", int(2): "two", int(10): "ten"}`,
+			json:   `{"-1":"minus one","2":"two","10":"ten"}`,
+		},
+		{
+			name:   "Nested",
+			input:  map[string]any{"b": []map[string]int{{"x": 1}}, "a": nil},
+			output: `map[string]interface{}{"a": nil, "b": []map[string]int{map[string]int{"x": int(1)}}}`,
+			json:   `{"a":null,"b":[{"x":1}]}`,
+		},
+		{
+			name:  "Nested in arrays",
+			input: [2]map[string][1]map[int]bool{{"a": {{1: true}}}, {}},
+			output: `[2]map[string][1]map[int]bool{` +
+				`map[string][1]map[int]bool{"a": [1]map[int]bool{map[int]bool{int(1): true}}}, ` +
+				`map[string][1]map[int]bool{}}`,
+		},
+		{
+			name:   "Interface keys",
+			input:  map[any]bool{"a": true, 1: false},
+			output: `map[interface{}]bool{"a": true, int(1): false}`,
+		},
+		{
+			name:  "NaN key",
+			input: map[float64]string{1: "one", math.NaN(): "NaN"},
+			error: `cannot export (map[float64]string)[float64(NaN)]: NaN key cannot be reproduced in a map literal`,
+		},
+		{
+			name:  "Nested NaN key",
+			input: []any{map[any]int{[2]any{1.5, math.NaN()}: 1}},
+			error: `cannot export ([]interface{})[0]: ` +
+				`cannot export (map[interface{}]int)[[2]interface{}{float64(1.5), float64(NaN)}]: ` +
+				`NaN key cannot be reproduced in a map literal`,
+		},
+		{
+			name:  "Unsupported value",
+			input: map[string]any{"a": make(chan int)},
+			error: `cannot export (map[string]interface{})["a"]: type chan int is not supported`,
+		},
+		{
+			name:  "Unsupported key",
+			input: map[any]int{make(chan int): 1},
+			error: `cannot export key of (map[interface{}]int): type chan int is not supported`,
+		},
+		{
+			name:  "Unsupported type",
+			input: map[string]chan int{},
+			error: `type map[string]chan int is not supported`,
+		},
+	}
+
+	jsonExporter := exporter.New(exporter.WithBackend(exporter.JSONBackend()))
+
+	for _, s := range scenarios {
+		s := s
+
+		t.Run(s.name, func(t *testing.T) {
+			t.Parallel()
+
+			output, err := exporter.Export(s.input)
+			if s.error != "" {
+				assert.EqualError(t, err, s.error)
+				assert.Empty(t, output)
+
+				return
+			}
+
+			require.NoError(t, err)
+			assert.Equal(t, s.output, output)
+
+			if s.json == "" {
+				return
+			}
+
+			output, err = jsonExporter.Export(s.input)
+			require.NoError(t, err)
+			assert.Equal(t, s.json, output)
+		})
+	}
+
+	t.Run("JSON keys", func(t *testing.T) {
+		t.Parallel()
+
+		_, err := jsonExporter.Export(map[bool]int{true: 1})
+		assert.EqualError(t, err, "key true cannot be represented in JSON")
+	})
+}
+
+func TestWithNilMapsAsEmpty(t *testing.T) {
+	t.Parallel()
+
+	input := []map[string]int{nil, {}}
+
+	assert.Equal(
+		t,
+		`[]map[string]int{(map[string]int)(nil), map[string]int{}}`,
+		exporter.MustExport(input),
+	)
+	assert.Equal(
+		t,
+		`[]map[string]int{map[string]int{}, map[string]int{}}`,
+		exporter.New(exporter.WithNilMapsAsEmpty()).MustExport(input),
+	)
+	assert.Equal(
+		t,
+		`[{},{}]`,
+		exporter.New(exporter.WithNilMapsAsEmpty(), exporter.WithBackend(exporter.JSONBackend())).MustExport(input),
+	)
+}
+
+func TestExport_mapAliasing(t *testing.T) {
+	t.Parallel()
+
+	shared, other := list.New(), list.New()
+	shared.PushBack(1)
+
+	input := map[string]any{"a": shared, "b": other, "c": shared, "d": 1}
+	assert.Equal(
+		t,
+		`func() map[string]interface{} { v1 := func() *list.List { l := list.New(); l.PushBack(int(1)); return l }(); `+
+			`return map[string]interface{}{"a": v1, "b": func() *list.List { l := list.New(); return l }(), `+
+			`"c": v1, "d": int(1)} }()`,
+		exporter.MustExport(input),
+	)
+}
+
+type mapServers map[string][]StructServer
+
+func TestExport_definedMaps(t *testing.T) {
+	t.Parallel()
+
+	input := []mapServers{{"b": {{Port: 80}}, "a": nil}, nil}
+
+	assert.Equal(
+		t,
+		`[]exporter_test.mapServers{exporter_test.mapServers{"a": ([]exporter_test.StructServer)(nil), `+
+			`"b": []exporter_test.StructServer{exporter_test.StructServer{Port: int(80)}}}, (exporter_test.mapServers)(nil)}`,
+		exporter.MustExport(input),
+	)
+
+	code, err := exporter.ExportFile("fixtures", "Servers", [1]any{mapServers{}})
+	require.NoError(t, err)
+	assert.Equal(
+		t,
+		`// Code generated by github.com/gontainer/exporter. DO NOT EDIT.
+// Format: 2
+
+package fixtures
+
+import (
+	"github.com/gontainer/exporter_test"
+)
+
+var Servers = [1]interface{}{exporter_test.mapServers{}}
+`,
+		withoutChecksum(t, code),
+	)
+}
@@ -0,0 +1,134 @@
+// Copyright (c) 2023–present Bartłomiej Krukowski
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is furnished
+// to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package exporter
+
+import (
+	"fmt"
+	"net"
+	"reflect"
+	"strconv"
+)
+
+//nolint:gochecknoglobals
+var (
+	ipType           = reflect.TypeOf(net.IP(nil))
+	ipMaskType       = reflect.TypeOf(net.IPMask(nil))
+	hardwareAddrType = reflect.TypeOf(net.HardwareAddr(nil))
+)
+
+// netExporter exports types from the package net, e.g.:
+//
+//	net.ParseIP("192.168.0.1").To4()
+//	net.CIDRMask(24, 32)
+//	func() (v net.HardwareAddr) { v, _ = net.ParseMAC("00:1a:2b:3c:4d:5e"); return v }()
+//
+// The package net/netip has been added in GO 1.18, so its types are recognized by their names, e.g.:
+//
+//	netip.MustParseAddr("fe80::1%eth0")
+//	netip.MustParsePrefix("10.0.0.0/8")
+//
+// Values that cannot be parsed back are exported as conversions of byte slices, e.g.:
+//
+//	net.IP([]byte("\x01\x02"))
+//
+// The type net.IPNet is a struct, so it is exported by structExporter.
+type netExporter struct {
+	backend goBackend
+}
+
+func (n netExporter) export(v any) (string, error) {
+	val := reflect.ValueOf(v)
+	t := val.Type()
+
+	switch t {
+	case ipType:
+		return n.exportIP(v.(net.IP)), nil //nolint:forcetypeassert
+	case ipMaskType:
+		return n.exportIPMask(v.(net.IPMask)), nil //nolint:forcetypeassert
+	case hardwareAddrType:
+		return n.exportHardwareAddr(v.(net.HardwareAddr)), nil //nolint:forcetypeassert
+	}
+
+	// netip.Addr and netip.Prefix
+	if val.IsZero() {
+		return typeName(t) + "{}", nil
+	}
+
+	if !val.MethodByName("IsValid").Call(nil)[0].Bool() {
+		return "", fmt.Errorf("cannot export invalid %s", typeName(t)) //nolint:goerr113
+	}
+
+	s := v.(fmt.Stringer).String() //nolint:forcetypeassert
+
+	return "netip.MustParse" + t.Name() + "(" + strconv.Quote(s) + ")", nil
+}
+
+func (n netExporter) exportIP(ip net.IP) string {
+	switch {
+	case ip == nil:
+		return n.backend.renderNilSlice(ipType)
+	case len(ip) == net.IPv4len:
+		return "net.ParseIP(" + strconv.Quote(ip.String()) + ").To4()"
+	case len(ip) == net.IPv6len:
+		return "net.ParseIP(" + strconv.Quote(ip.String()) + ")"
+	}
+
+	return n.backend.renderConversion(ipType, n.backend.renderBytes(ip))
+}
+
+func (n netExporter) exportIPMask(m net.IPMask) string {
+	if m == nil {
+		return n.backend.renderNilSlice(ipMaskType)
+	}
+
+	// Size returns 0, 0 for non-canonical masks
+	if ones, bits := m.Size(); bits != 0 {
+		return fmt.Sprintf("net.CIDRMask(%d, %d)", ones, bits)
+	}
+
+	return n.backend.renderConversion(ipMaskType, n.backend.renderBytes(m))
+}
+
+func (n netExporter) exportHardwareAddr(a net.HardwareAddr) string {
+	if a == nil {
+		return n.backend.renderNilSlice(hardwareAddrType)
+	}
+
+	if parsed, err := net.ParseMAC(a.String()); err == nil && string(parsed) == string(a) {
+		return "func() (v net.HardwareAddr) { v, _ = net.ParseMAC(" + strconv.Quote(a.String()) + "); return v }()"
+	}
+
+	return n.backend.renderConversion(hardwareAddrType, n.backend.renderBytes(a))
+}
+
+func (netExporter) supports(v any) bool {
+	t := reflect.TypeOf(v)
+	if t == nil {
+		return false
+	}
+
+	switch t {
+	case ipType, ipMaskType, hardwareAddrType:
+		return true
+	}
+
+	return t.PkgPath() == "net/netip" && (t.Name() == "Addr" || t.Name() == "Prefix")
+}
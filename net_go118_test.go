@@ -0,0 +1,82 @@
+// Copyright (c) 2023–present Bartłomiej Krukowski
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is furnished
+// to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+//go:build go1.18
+// +build go1.18
+
+package exporter_test
+
+import (
+	"net/netip"
+	"testing"
+
+	"github.com/gontainer/exporter"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestExport_netip(t *testing.T) {
+	t.Parallel()
+
+	scenarios := []struct {
+		name   string
+		input  any
+		output string
+		error  string
+	}{
+		{
+			name:   "Address",
+			input:  netip.MustParseAddr("fe80::1%eth0"),
+			output: `netip.MustParseAddr("fe80::1%eth0")`,
+		},
+		{
+			name:   "Prefix",
+			input:  netip.MustParsePrefix("10.0.0.0/8"),
+			output: `netip.MustParsePrefix("10.0.0.0/8")`,
+		},
+		{
+			name:   "Zero values",
+			input:  []any{netip.Addr{}, netip.Prefix{}},
+			output: `[]interface{}{netip.Addr{}, netip.Prefix{}}`,
+		},
+		{
+			name:  "Invalid prefix",
+			input: netip.PrefixFrom(netip.MustParseAddr("10.0.0.0"), 64),
+			error: `cannot export invalid netip.Prefix`,
+		},
+	}
+
+	for _, s := range scenarios {
+		s := s
+
+		t.Run(s.name, func(t *testing.T) {
+			t.Parallel()
+
+			output, err := exporter.Export(s.input)
+			if s.error != "" {
+				assert.EqualError(t, err, s.error)
+
+				return
+			}
+
+			assert.NoError(t, err)
+			assert.Equal(t, s.output, output)
+		})
+	}
+}
@@ -0,0 +1,107 @@
+// Copyright (c) 2023–present Bartłomiej Krukowski
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is furnished
+// to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package exporter_test
+
+import (
+	"net"
+	"testing"
+
+	"github.com/gontainer/exporter"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestExport_net(t *testing.T) {
+	t.Parallel()
+
+	_, cidr, _ := net.ParseCIDR("10.0.0.0/8")
+
+	scenarios := []struct {
+		name   string
+		input  any
+		output string
+	}{
+		{
+			name:   "IPv4",
+			input:  net.IPv4(192, 168, 0, 1).To4(),
+			output: `net.ParseIP("192.168.0.1").To4()`,
+		},
+		{
+			name:   "IPv4 in the 16-byte form",
+			input:  net.IPv4(192, 168, 0, 1),
+			output: `net.ParseIP("192.168.0.1")`,
+		},
+		{
+			name:   "IPv6",
+			input:  net.ParseIP("fe80::1"),
+			output: `net.ParseIP("fe80::1")`,
+		},
+		{
+			name:   "Nil IP",
+			input:  net.IP(nil),
+			output: `(net.IP)(nil)`,
+		},
+		{
+			name:   "Invalid IP",
+			input:  net.IP{1, 2},
+			output: `net.IP([]byte("\x01\x02"))`,
+		},
+		{
+			name:   "Mask",
+			input:  net.CIDRMask(24, 32),
+			output: `net.CIDRMask(24, 32)`,
+		},
+		{
+			name:   "Non-canonical mask",
+			input:  net.IPv4Mask(255, 0, 255, 0),
+			output: `net.IPMask([]byte("\xff\x00\xff\x00"))`,
+		},
+		{
+			name:   "Network",
+			input:  *cidr,
+			output: `net.IPNet{IP: net.ParseIP("10.0.0.0").To4(), Mask: net.CIDRMask(8, 32)}`,
+		},
+		{
+			name:   "Hardware address",
+			input:  net.HardwareAddr{0x00, 0x1a, 0x2b, 0x3c, 0x4d, 0x5e},
+			output: `func() (v net.HardwareAddr) { v, _ = net.ParseMAC("00:1a:2b:3c:4d:5e"); return v }()`,
+		},
+		{
+			name:   "Invalid hardware address",
+			input:  net.HardwareAddr{0x00, 0x1a},
+			output: `net.HardwareAddr([]byte("\x00\x1a"))`,
+		},
+		{
+			name:   "Slice of addresses",
+			input:  []net.IP{net.IPv4(127, 0, 0, 1).To4()},
+			output: `[]net.IP{net.ParseIP("127.0.0.1").To4()}`,
+		},
+	}
+
+	for _, s := range scenarios {
+		s := s
+
+		t.Run(s.name, func(t *testing.T) {
+			t.Parallel()
+
+			assert.Equal(t, s.output, exporter.MustExport(s.input))
+		})
+	}
+}
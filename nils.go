@@ -0,0 +1,124 @@
+// Copyright (c) 2023–present Bartłomiej Krukowski
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is furnished
+// to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package exporter
+
+import (
+	"reflect"
+)
+
+// NilContext defines the kind of nil values, see WithNilStyle.
+type NilContext int
+
+const (
+	// NilSlice refers to nil slices.
+	NilSlice NilContext = iota
+	// NilMap refers to nil maps.
+	NilMap
+	// NilInterface refers to nil interfaces, e.g. nil elements of []interface{}.
+	NilInterface
+	nilContexts
+)
+
+// NilStyle defines how nil values are rendered, see WithNilStyle.
+type NilStyle int
+
+const (
+	// NilTyped renders nil values with their types, e.g. ([]int)(nil) and interface{}(nil).
+	// It is the default style of nil slices and nil maps.
+	NilTyped NilStyle = iota
+	// NilBare renders nil values as nil. It is the default style of nil interfaces.
+	NilBare
+	// NilComment renders nil values as nil followed by a comment with their types, e.g. nil /* []int */.
+	NilComment
+)
+
+// WithNilStyle sets how nil values of the given kind are rendered, e.g.:
+//
+//	[][]int{nil}                   // NilBare
+//	[][]int{([]int)(nil)}          // NilTyped
+//	[][]int{nil /* []int */}       // NilComment
+//	[]error{error(nil)}            // NilTyped
+//
+// Styles apply to elements of slices, arrays and maps, since the types of elements are known there.
+// Nil slices and maps in other contexts, e.g. in interfaces, are always rendered with their types,
+// otherwise the generated code would change the types of the values.
+func WithNilStyle(ctx NilContext, style NilStyle) Option {
+	return func(c *config) {
+		if ctx >= 0 && ctx < nilContexts {
+			c.nilStyles[ctx] = style
+		}
+	}
+}
+
+type nilStyles [nilContexts]NilStyle
+
+func defaultNilStyles() nilStyles {
+	var s nilStyles
+	s[NilSlice] = NilTyped
+	s[NilMap] = NilTyped
+	s[NilInterface] = NilBare
+
+	return s
+}
+
+// render renders the given element of the given static type according to the styles.
+// The code is the default rendering of the element.
+func (s nilStyles) render(b Backend, static reflect.Type, v reflect.Value, code string) string {
+	var ctx NilContext
+
+	//nolint:exhaustive
+	switch static.Kind() {
+	case reflect.Slice:
+		// only the default rendering can be replaced, e.g. nil maps may be rendered as empty maps
+		if !v.IsNil() || code != b.renderNilSlice(static) {
+			return code
+		}
+
+		ctx = NilSlice
+	case reflect.Map:
+		if !v.IsNil() || code != b.renderNilMap(static) {
+			return code
+		}
+
+		ctx = NilMap
+	case reflect.Interface:
+		if !v.IsNil() {
+			return code
+		}
+
+		ctx = NilInterface
+	default:
+		return code
+	}
+
+	switch s[ctx] {
+	case NilBare:
+		return b.renderNil()
+	case NilComment:
+		return b.renderAnnotation(b.renderNil(), typeName(static))
+	case NilTyped:
+		if ctx == NilInterface {
+			return b.renderConversion(static, b.renderNil())
+		}
+	}
+
+	return code
+}
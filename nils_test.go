@@ -0,0 +1,128 @@
+// Copyright (c) 2023–present Bartłomiej Krukowski
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is furnished
+// to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package exporter_test
+
+import (
+	"testing"
+
+	"github.com/gontainer/exporter"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestWithNilStyle(t *testing.T) {
+	t.Parallel()
+
+	input := struct {
+		Slices [][]int
+		Maps   map[string]map[string]int
+		Errors []error
+		Any    []any
+	}{
+		Slices: [][]int{nil, {}},
+		Maps:   map[string]map[string]int{"a": nil},
+		Errors: []error{nil},
+		Any:    []any{nil, []int(nil)},
+	}
+
+	scenarios := []struct {
+		name    string
+		options []exporter.Option
+		output  string
+	}{
+		{
+			name: "Default",
+			output: `struct { Slices [][]int; Maps map[string]map[string]int; Errors []error; Any []interface {} }{` +
+				`Slices: [][]int{([]int)(nil), make([]int, 0)}, ` +
+				`Maps: map[string]map[string]int{"a": (map[string]int)(nil)}, ` +
+				`Errors: []error{nil}, ` +
+				`Any: []interface{}{nil, ([]int)(nil)}}`,
+		},
+		{
+			name: "Bare",
+			options: []exporter.Option{
+				exporter.WithNilStyle(exporter.NilSlice, exporter.NilBare),
+				exporter.WithNilStyle(exporter.NilMap, exporter.NilBare),
+			},
+			output: `struct { Slices [][]int; Maps map[string]map[string]int; Errors []error; Any []interface {} }{` +
+				`Slices: [][]int{nil, make([]int, 0)}, ` +
+				`Maps: map[string]map[string]int{"a": nil}, ` +
+				`Errors: []error{nil}, ` +
+				`Any: []interface{}{nil, ([]int)(nil)}}`,
+		},
+		{
+			name: "Typed",
+			options: []exporter.Option{
+				exporter.WithNilStyle(exporter.NilInterface, exporter.NilTyped),
+			},
+			output: `struct { Slices [][]int; Maps map[string]map[string]int; Errors []error; Any []interface {} }{` +
+				`Slices: [][]int{([]int)(nil), make([]int, 0)}, ` +
+				`Maps: map[string]map[string]int{"a": (map[string]int)(nil)}, ` +
+				`Errors: []error{error(nil)}, ` +
+				`Any: []interface{}{interface{}(nil), ([]int)(nil)}}`,
+		},
+		{
+			name: "Comment",
+			options: []exporter.Option{
+				exporter.WithNilStyle(exporter.NilSlice, exporter.NilComment),
+				exporter.WithNilStyle(exporter.NilMap, exporter.NilComment),
+				exporter.WithNilStyle(exporter.NilInterface, exporter.NilComment),
+			},
+			output: `struct { Slices [][]int; Maps map[string]map[string]int; Errors []error; Any []interface {} }{` +
+				`Slices: [][]int{nil /* []int */, make([]int, 0)}, ` +
+				`Maps: map[string]map[string]int{"a": nil /* map[string]int */}, ` +
+				`Errors: []error{nil /* error */}, ` +
+				`Any: []interface{}{nil /* interface{} */, ([]int)(nil)}}`,
+		},
+		{
+			name: "Nil maps as empty maps",
+			options: []exporter.Option{
+				exporter.WithNilStyle(exporter.NilMap, exporter.NilBare),
+				exporter.WithNilMapsAsEmpty(),
+			},
+			output: `struct { Slices [][]int; Maps map[string]map[string]int; Errors []error; Any []interface {} }{` +
+				`Slices: [][]int{([]int)(nil), make([]int, 0)}, ` +
+				`Maps: map[string]map[string]int{"a": map[string]int{}}, ` +
+				`Errors: []error{nil}, ` +
+				`Any: []interface{}{nil, ([]int)(nil)}}`,
+		},
+	}
+
+	for _, s := range scenarios {
+		s := s
+
+		t.Run(s.name, func(t *testing.T) {
+			t.Parallel()
+
+			assert.Equal(t, s.output, exporter.New(s.options...).MustExport(input))
+		})
+	}
+
+	t.Run("JSON", func(t *testing.T) {
+		t.Parallel()
+
+		e := exporter.New(
+			exporter.WithBackend(exporter.JSONBackend()),
+			exporter.WithNilStyle(exporter.NilSlice, exporter.NilComment),
+			exporter.WithNilStyle(exporter.NilInterface, exporter.NilTyped),
+		)
+		assert.Equal(t, `[[null],[null]]`, e.MustExport([][]any{{nil}, {[]int(nil)}}))
+	})
+}
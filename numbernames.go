@@ -0,0 +1,55 @@
+// Copyright (c) 2023–present Bartłomiej Krukowski
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is furnished
+// to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package exporter
+
+import (
+	"reflect"
+)
+
+// WithNumberTypeNames overrides how conversions of numbers of the given kinds are spelled, e.g.:
+//
+//	exporter.WithNumberTypeNames(map[reflect.Kind]string{reflect.Uint8: "byte", reflect.Int32: "rune"})
+//
+// exports []interface{}{uint8(1)} as []interface{}{byte(1)}. Names of types in other places, e.g. []uint8,
+// are not changed. Aliases, e.g. byte and rune, do not change the generated values. Other names change types of
+// numbers stored in interfaces, e.g. int64 for reflect.Int, and they break contexts that fix types,
+// e.g. []int{int64(1)}, unless the conversions are omitted there, see WithScalarElision.
+// The JSON backend ignores this option.
+func WithNumberTypeNames(names map[reflect.Kind]string) Option {
+	return func(c *config) {
+		c.numberNames = make(numberNames, len(names))
+		for k, n := range names {
+			c.numberNames[k] = n
+		}
+	}
+}
+
+// numberNames maps kinds of numbers to the names of their types, see WithNumberTypeNames.
+type numberNames map[reflect.Kind]string
+
+// name returns the name of the type of numbers of the given kind.
+func (n numberNames) name(k reflect.Kind) string {
+	if name, ok := n[k]; ok {
+		return name
+	}
+
+	return k.String()
+}
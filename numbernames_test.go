@@ -0,0 +1,80 @@
+// Copyright (c) 2023–present Bartłomiej Krukowski
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is furnished
+// to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package exporter_test
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/gontainer/exporter"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestWithNumberTypeNames(t *testing.T) {
+	t.Parallel()
+
+	names := exporter.WithNumberTypeNames(map[reflect.Kind]string{
+		reflect.Uint8: "byte",
+		reflect.Int32: "rune",
+		reflect.Int:   "int64",
+	})
+
+	scenarios := []struct {
+		name    string
+		input   any
+		output  string
+		options []exporter.Option
+	}{
+		{
+			name:   "Interfaces",
+			input:  []any{uint8(1), int32('a'), 5, int8(1)},
+			output: `[]interface{}{byte(1), rune(97), int64(5), int8(1)}`,
+		},
+		{
+			name:   "Types of composite literals",
+			input:  [1]uint8{1},
+			output: `[1]uint8{byte(1)}`,
+		},
+		{
+			name:    "Scalar elision",
+			input:   map[int][]any{1: {2}},
+			output:  `map[int][]interface{}{1: []interface{}{int64(2)}}`,
+			options: []exporter.Option{exporter.WithScalarElision()},
+		},
+		{
+			name:    "JSON",
+			input:   []any{uint8(1), 5},
+			output:  `[1,5]`,
+			options: []exporter.Option{exporter.WithBackend(exporter.JSONBackend())},
+		},
+	}
+
+	for _, s := range scenarios {
+		s := s
+
+		t.Run(s.name, func(t *testing.T) {
+			t.Parallel()
+
+			e := exporter.New(append([]exporter.Option{names}, s.options...)...)
+			assert.Equal(t, s.output, e.MustExport(s.input))
+		})
+	}
+}
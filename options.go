@@ -0,0 +1,197 @@
+// Copyright (c) 2023–present Bartłomiej Krukowski
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is furnished
+// to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package exporter
+
+import (
+	"reflect"
+)
+
+// Option configures an Exporter, see New.
+type Option func(*config)
+
+type config struct {
+	backend          Backend
+	cache            *cache
+	maxElements      int
+	maxDepth         int
+	warn             func(string)
+	timeLocation     TimeLocation
+	atomicValues     bool
+	lenientCasting   bool
+	floatPrecision   int
+	splitPrefix      string
+	sparse           bool
+	lineEnding       LineEnding
+	spaceIndentation int
+	indexComments    int
+	integralFloats   reflect.Type
+	timestamps       *Timestamps
+	colors           bool
+	fieldFilter      func(reflect.StructField) bool
+	scalarElision    bool
+	sentinels        []sentinelError
+	nilMapsAsEmpty   bool
+	byteLists        bool
+	packagePath      string
+	importRewrite    func(string) string
+	iterators        bool
+	iteratorLimit    int
+	nilStyles        nilStyles
+	determinismCheck *DeterminismCheck
+	maxNodes         int
+	visitedSet       func() VisitedSet
+	hexLargeUints    bool
+	keyedArrays      bool
+	minimalNumbers   bool
+	disabledBuiltIns uint64
+	summary          *Summary
+	escapeInvisibles bool
+	tracing          func(string) func(Trace)
+	nanPolicy        NaNPolicy
+	compositeElision bool
+	pseudonyms       *pseudonymizer
+	numberNames      numberNames
+	declaration      Declaration
+	copySuffix       string
+	castUnderlying   bool
+	panicErrors      bool
+	sanitizeNames    bool
+	customExporters  []CustomExporter
+	floatRatios      int
+	indent           string
+	includedPaths    []string
+	excludedPaths    []string
+	gofmt            bool
+	typedSuffix      string
+	quoteStyle       QuoteStyle
+	anyAlias         bool
+	jsonNumbers      JSONNumbers
+	formatVersion    FormatVersion
+	yamlCoercion     YAMLCoercion
+	enumKeys         enumNames
+	// supportedTypes is shared by exports of many values, see Exporter.ExportAll
+	supportedTypes map[reflect.Type]bool
+}
+
+func newConfig() config {
+	return config{
+		backend:          goBackend{},
+		cache:            nil,
+		maxElements:      DefaultMaxLiteralElements,
+		maxDepth:         DefaultMaxLiteralDepth,
+		warn:             func(string) {},
+		timeLocation:     TimeLocationKeep,
+		atomicValues:     false,
+		lenientCasting:   false,
+		floatPrecision:   -1,
+		splitPrefix:      "",
+		sparse:           false,
+		lineEnding:       LineEndingLF,
+		spaceIndentation: 0,
+		indexComments:    0,
+		integralFloats:   nil,
+		timestamps:       nil,
+		colors:           false,
+		fieldFilter:      nil,
+		scalarElision:    false,
+		sentinels:        defaultSentinels,
+		nilMapsAsEmpty:   false,
+		byteLists:        false,
+		packagePath:      "",
+		importRewrite:    nil,
+		iterators:        false,
+		iteratorLimit:    0,
+		nilStyles:        defaultNilStyles(),
+		determinismCheck: nil,
+		maxNodes:         0,
+		visitedSet:       NewVisitedStack,
+		hexLargeUints:    false,
+		keyedArrays:      false,
+		minimalNumbers:   false,
+		disabledBuiltIns: 0,
+		summary:          nil,
+		escapeInvisibles: false,
+		tracing:          nil,
+		nanPolicy:        NaNCanonical,
+		compositeElision: false,
+		pseudonyms:       nil,
+		numberNames:      nil,
+		declaration:      DeclarationVar,
+		copySuffix:       "",
+		castUnderlying:   false,
+		panicErrors:      false,
+		sanitizeNames:    false,
+		customExporters:  nil,
+		floatRatios:      0,
+		indent:           "",
+		includedPaths:    nil,
+		excludedPaths:    nil,
+		gofmt:            false,
+		typedSuffix:      "",
+		quoteStyle:       QuoteASCII,
+		anyAlias:         false,
+		jsonNumbers:      JSONNumbersFloat64,
+		formatVersion:    LatestFormatVersion,
+		yamlCoercion:     YAMLCoercion{Bools: false},
+		enumKeys:         nil,
+		supportedTypes:   nil,
+	}
+}
+
+// WithBackend sets the backend that renders exported values, see GoBackend, JSONBackend and CUEBackend.
+func WithBackend(b Backend) Option {
+	return func(c *config) {
+		c.backend = b
+	}
+}
+
+// WithCache enables caching results of exporting slices, maps and pointers.
+// Cached values are identified by their addresses, so exporting the same large value repeatedly
+// does not walk it again. Values must not be modified after they have been exported,
+// otherwise the exporter returns outdated results. See Exporter.ResetCache.
+func WithCache() Option {
+	return func(c *config) {
+		c.cache = newCache()
+	}
+}
+
+// WithByteLists exports all byte slices as lists of bytes, e.g.:
+//
+//	[]uint8{uint8(239), uint8(187), uint8(191)} // instead of []byte("\ufeff")
+//
+// By default, byte slices that contain valid UTF-8 are exported as conversions of strings,
+// that may obscure invisible characters, e.g. byte order marks and non-breaking spaces.
+func WithByteLists() Option {
+	return func(c *config) {
+		c.byteLists = true
+	}
+}
+
+// WithHexLargeUints exports unsigned integers greater than math.MaxInt64 in the hexadecimal notation, e.g.:
+//
+//	uint64(0xffffffffffffffff) // instead of uint64(18446744073709551615)
+//
+// It makes values that overflow int64 visually obvious. The JSON backend ignores this option.
+func WithHexLargeUints() Option {
+	return func(c *config) {
+		c.hexLargeUints = true
+	}
+}
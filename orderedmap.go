@@ -0,0 +1,128 @@
+// Copyright (c) 2023–present Bartłomiej Krukowski
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is furnished
+// to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package exporter
+
+import (
+	"fmt"
+	"reflect"
+	"strings"
+)
+
+// orderedMap exports insertion-ordered maps, e.g. github.com/wk8/go-ordered-map,
+// as a function literal that rebuilds the map by calling Set in the original order:
+//
+//	func() *orderedmap.OrderedMap[string, int] { m := orderedmap.New[string, int](); m.Set("a", int(1)); return m }()
+//
+// The given type is supported when it is a pointer that provides the following methods:
+//
+//	Oldest() *Pair      // Pair is a struct with the fields Key and Value
+//	Set(key, value) ... // results are ignored
+//
+// and *Pair provides the method Next() *Pair. The package of the given type must provide the constructor New.
+type orderedMap struct {
+	exporter exporter
+}
+
+func (o orderedMap) export(v any) (string, error) {
+	val := reflect.ValueOf(v)
+	name, constructor := orderedMapNames(val.Type())
+
+	if val.IsNil() {
+		return fmt.Sprintf("(%s)(nil)", name), nil
+	}
+
+	calls := make([]string, 0)
+
+	for p := val.MethodByName("Oldest").Call(nil)[0]; !p.IsNil(); p = p.MethodByName("Next").Call(nil)[0] {
+		k, err := o.exporter.export(p.Elem().FieldByName("Key").Interface())
+		if err != nil {
+			return "", fmt.Errorf("cannot export key of (%s)[%d]: %w", name, len(calls), err)
+		}
+
+		v, err := o.exporter.export(p.Elem().FieldByName("Value").Interface())
+		if err != nil {
+			return "", newPathError(name, KeyStep(k), err)
+		}
+
+		calls = append(calls, fmt.Sprintf("m.Set(%s, %s); ", k, v))
+	}
+
+	return fmt.Sprintf("func() %s { m := %s(); %sreturn m }()", name, constructor, strings.Join(calls, "")), nil
+}
+
+func (orderedMap) supports(v any) bool {
+	t := reflect.TypeOf(v)
+	if t == nil || t.Kind() != reflect.Ptr || t.Elem().Kind() != reflect.Struct || t.Elem().PkgPath() == "" {
+		return false
+	}
+
+	oldest, ok := t.MethodByName("Oldest")
+	if !ok || oldest.Type.NumIn() != 1 || oldest.Type.NumOut() != 1 {
+		return false
+	}
+
+	pair := oldest.Type.Out(0)
+	if pair.Kind() != reflect.Ptr || pair.Elem().Kind() != reflect.Struct {
+		return false
+	}
+
+	if _, ok := pair.Elem().FieldByName("Key"); !ok {
+		return false
+	}
+
+	if _, ok := pair.Elem().FieldByName("Value"); !ok {
+		return false
+	}
+
+	next, ok := pair.MethodByName("Next")
+	if !ok || next.Type.NumIn() != 1 || next.Type.NumOut() != 1 || next.Type.Out(0) != pair {
+		return false
+	}
+
+	set, ok := t.MethodByName("Set")
+
+	return ok && set.Type.NumIn() == 3
+}
+
+// orderedMapNames returns the name of the given pointer type and the name of its constructor.
+// Type arguments of generic types are rendered using the types of the fields Pair.Key and Pair.Value,
+// since reflect prints them with full package paths.
+func orderedMapNames(t reflect.Type) (string, string) {
+	pkg := t.Elem().String()
+	pkg = pkg[:strings.Index(pkg, ".")]
+
+	base := t.Elem().Name()
+
+	i := strings.Index(base, "[")
+	if i == -1 {
+		return "*" + pkg + "." + base, pkg + ".New"
+	}
+
+	base = base[:i]
+
+	oldest, _ := t.MethodByName("Oldest")
+	pair := oldest.Type.Out(0).Elem()
+	k, _ := pair.FieldByName("Key")
+	v, _ := pair.FieldByName("Value")
+	args := "[" + typeName(k.Type) + ", " + typeName(v.Type) + "]"
+
+	return "*" + pkg + "." + base + args, pkg + ".New" + args
+}
@@ -0,0 +1,121 @@
+// Copyright (c) 2023–present Bartłomiej Krukowski
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is furnished
+// to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package exporter //nolint:testpackage
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type (
+	fakeOrderedMap struct {
+		oldest, newest *fakePair
+	}
+
+	fakePair struct {
+		Key   any
+		Value any
+		next  *fakePair
+	}
+
+	notOrderedMap struct{}
+)
+
+func (m *fakeOrderedMap) Oldest() *fakePair {
+	return m.oldest
+}
+
+func (m *fakeOrderedMap) Set(key any, value any) (any, bool) {
+	p := &fakePair{Key: key, Value: value, next: nil}
+	if m.newest == nil {
+		m.oldest = p
+	} else {
+		m.newest.next = p
+	}
+
+	m.newest = p
+
+	return nil, false
+}
+
+func (p *fakePair) Next() *fakePair {
+	return p.next
+}
+
+func (*notOrderedMap) Oldest() *fakePair {
+	return nil
+}
+
+//nolint:testifylint
+func TestOrderedMap(t *testing.T) {
+	t.Parallel()
+
+	t.Run("Export", func(t *testing.T) {
+		t.Parallel()
+
+		m := &fakeOrderedMap{} //nolint:exhaustruct
+		m.Set("z", 1)
+		m.Set("a", []any{true, nil})
+
+		s, err := Export(m)
+		assert.NoError(t, err)
+		assert.Equal(
+			t,
+			`func() *exporter.fakeOrderedMap { m := exporter.New(); `+
+				`m.Set("z", int(1)); m.Set("a", []interface{}{true, nil}); return m }()`,
+			s,
+		)
+	})
+
+	t.Run("Empty", func(t *testing.T) {
+		t.Parallel()
+
+		s, err := Export(&fakeOrderedMap{}) //nolint:exhaustruct
+		assert.NoError(t, err)
+		assert.Equal(t, `func() *exporter.fakeOrderedMap { m := exporter.New(); return m }()`, s)
+	})
+
+	t.Run("Nil", func(t *testing.T) {
+		t.Parallel()
+
+		s, err := Export((*fakeOrderedMap)(nil))
+		assert.NoError(t, err)
+		assert.Equal(t, `(*exporter.fakeOrderedMap)(nil)`, s)
+	})
+
+	t.Run("Unsupported value", func(t *testing.T) {
+		t.Parallel()
+
+		m := &fakeOrderedMap{} //nolint:exhaustruct
+		m.Set("a", make(chan int))
+
+		_, err := Export(m)
+		assert.EqualError(t, err, `cannot export (*exporter.fakeOrderedMap)["a"]: type chan int is not supported`)
+	})
+
+	t.Run("Not an ordered map", func(t *testing.T) {
+		t.Parallel()
+
+		// it is exported like any other pointer
+		assert.Equal(t, `&exporter.notOrderedMap{}`, MustExport(&notOrderedMap{}))
+	})
+}
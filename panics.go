@@ -0,0 +1,70 @@
+// Copyright (c) 2023–present Bartłomiej Krukowski
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is furnished
+// to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package exporter
+
+import (
+	"fmt"
+)
+
+// WithPanicErrors makes Exporter.MustExport and Exporter.MustCastToString panic with *PanicError
+// instead of a string, so the caller can inspect the failure after recovering, e.g.:
+//
+//	defer func() {
+//		if p, ok := recover().(*exporter.PanicError); ok {
+//			fmt.Println(exporter.ErrorPath(p)) // ["a"][1]
+//		}
+//	}()
+//
+//	e := exporter.New(exporter.WithPanicErrors())
+//	e.MustExport(map[string][]any{"a": {1, make(chan int)}})
+//
+// Messages of both panics are the same.
+func WithPanicErrors() Option {
+	return func(c *config) {
+		c.panicErrors = true
+	}
+}
+
+// PanicError is the value of panics of Must* functions, see WithPanicErrors.
+type PanicError struct {
+	// Operation is either "export" or "cast"
+	Operation string
+	Value     any
+	Err       error
+}
+
+func (e *PanicError) Error() string {
+	return fmt.Sprintf("cannot %s %T to string: %s", e.Operation, e.Value, e.Err.Error())
+}
+
+func (e *PanicError) Unwrap() error {
+	return e.Err
+}
+
+// panicWith panics with the given error according to WithPanicErrors.
+func (e *Exporter) panicWith(operation string, v any, err error) {
+	p := &PanicError{Operation: operation, Value: v, Err: err}
+	if e.config.panicErrors {
+		panic(p)
+	}
+
+	panic(p.Error())
+}
@@ -0,0 +1,73 @@
+// Copyright (c) 2023–present Bartłomiej Krukowski
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is furnished
+// to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package exporter_test
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/gontainer/exporter"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestWithPanicErrors(t *testing.T) {
+	t.Parallel()
+
+	recovered := func(fn func()) (r any) {
+		defer func() {
+			r = recover()
+		}()
+
+		fn()
+
+		return nil
+	}
+
+	input := map[string][]any{"a": {1, make(chan int)}}
+	msg := `cannot export map[string][]interface {} to string: ` +
+		`cannot export (map[string][]interface{})["a"]: cannot export ([]interface{})[1]: type chan int is not supported`
+
+	assert.PanicsWithValue(t, msg, func() {
+		exporter.MustExport(input)
+	})
+
+	e := exporter.New(exporter.WithPanicErrors())
+
+	r := recovered(func() {
+		e.MustExport(input)
+	})
+	p, ok := r.(*exporter.PanicError)
+	require.True(t, ok)
+	assert.Equal(t, "export", p.Operation)
+	assert.EqualError(t, p, msg)
+	assert.Equal(t, `["a"][1]`, exporter.ErrorPath(p).String())
+
+	r = recovered(func() {
+		e.MustCastToString([]int{1})
+	})
+	p, ok = r.(*exporter.PanicError)
+	require.True(t, ok)
+	assert.Equal(t, "cast", p.Operation)
+	assert.Equal(t, []int{1}, p.Value)
+	assert.EqualError(t, p, "cannot cast []int to string: type []int is not supported")
+	assert.True(t, errors.Is(p, p.Err))
+}
@@ -0,0 +1,146 @@
+// Copyright (c) 2023–present Bartłomiej Krukowski
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is furnished
+// to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package exporter
+
+import (
+	"bytes"
+	"fmt"
+	"go/format"
+	"go/parser"
+	"go/token"
+	"io/ioutil"
+	"os"
+)
+
+const (
+	patchBeginMarker = "// exporter:begin"
+	patchEndMarker   = "// exporter:end"
+)
+
+// Patch replaces the region between the markers "// exporter:begin name" and "// exporter:end"
+// with the declaration "var name = <exported v>". The rest of the source is preserved.
+//
+//	// exporter:begin fixture
+//	var fixture = []int{int(1), int(2)}
+//	// exporter:end
+//
+// The end marker may repeat the name of the region, e.g. "// exporter:end fixture".
+// Packages referenced by the exported code are added to the imports of the source.
+//
+// See Exporter.Patch.
+func Patch(src []byte, name string, v any) ([]byte, error) {
+	return defaultExporter.Patch(src, name, v)
+}
+
+// Patch replaces the region between the markers with the exported declaration, see the function Patch.
+func (e *Exporter) Patch(src []byte, name string, v any) ([]byte, error) {
+	code, pkgs, err := e.exportVarValue(name, v)
+	if err != nil {
+		return nil, err
+	}
+
+	fset := token.NewFileSet()
+
+	file, err := parser.ParseFile(fset, "", src, parser.ImportsOnly)
+	if err != nil {
+		return nil, fmt.Errorf("cannot parse source: %w", err)
+	}
+
+	imports, err := missingImports(file, pkgs)
+	if err != nil {
+		return nil, err
+	}
+
+	lines := bytes.SplitAfter(src, []byte("\n"))
+	begin, end := -1, -1
+
+	for i, l := range lines {
+		l = bytes.TrimSpace(l)
+
+		switch {
+		case string(l) == patchBeginMarker+" "+name:
+			if begin != -1 {
+				return nil, fmt.Errorf("marker %q is duplicated", patchBeginMarker+" "+name) //nolint:goerr113
+			}
+
+			begin = i
+		case begin != -1 && end == -1 && (string(l) == patchEndMarker || string(l) == patchEndMarker+" "+name):
+			end = i
+		}
+	}
+
+	if begin == -1 {
+		return nil, fmt.Errorf("marker %q not found", patchBeginMarker+" "+name) //nolint:goerr113
+	}
+
+	if end == -1 {
+		return nil, fmt.Errorf("marker %q not found after %q", patchEndMarker, patchBeginMarker+" "+name) //nolint:goerr113
+	}
+
+	indent := lines[begin][:len(lines[begin])-len(bytes.TrimLeft(lines[begin], " \t"))]
+
+	var out []byte
+	out = append(out, bytes.Join(lines[:begin+1], nil)...)
+	out = append(out, indent...)
+	out = append(out, fmt.Sprintf("var %s = %s\n", name, code)...)
+	out = append(out, bytes.Join(lines[end:], nil)...)
+
+	if begin < fset.Position(file.End()).Line {
+		return nil, fmt.Errorf("marker %q precedes imports", patchBeginMarker+" "+name) //nolint:goerr113
+	}
+
+	// imports precede the region, so the offset is valid in the patched source too
+	out = insertImports(out, fset, file, imports)
+
+	r, err := format.Source(out)
+	if err != nil {
+		return nil, fmt.Errorf("cannot format patched source: %w", err)
+	}
+
+	return r, nil
+}
+
+// PatchFile works like Patch, but it reads the source from the given path, and writes back the result.
+//
+// See Exporter.PatchFile.
+func PatchFile(path string, name string, v any) error {
+	return defaultExporter.PatchFile(path, name, v)
+}
+
+// PatchFile works like Exporter.Patch, but it reads the source from the given path, and writes back the result.
+func (e *Exporter) PatchFile(path string, name string, v any) error {
+	src, err := ioutil.ReadFile(path) //nolint:staticcheck
+	if err != nil {
+		return err //nolint:wrapcheck
+	}
+
+	info, err := os.Stat(path)
+	if err != nil {
+		return err //nolint:wrapcheck
+	}
+
+	out, err := e.Patch(src, name, v)
+	if err != nil {
+		return err
+	}
+
+	return ioutil.WriteFile(path, out, info.Mode()) //nolint:staticcheck,wrapcheck
+}
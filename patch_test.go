@@ -0,0 +1,199 @@
+// Copyright (c) 2023–present Bartłomiej Krukowski
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is furnished
+// to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package exporter_test
+
+import (
+	"io/ioutil"
+	"net"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/gontainer/exporter"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPatch(t *testing.T) {
+	t.Parallel()
+
+	//nolint:exhaustruct
+	scenarios := []struct {
+		name   string
+		input  string
+		value  any
+		output string
+		error  string
+	}{
+		{
+			name: "Package level",
+			input: `package fixtures
+
+// Hand-written code.
+func Foo() {}
+
+// exporter:begin numbers
+var numbers = []int{1}
+// exporter:end
+
+// More hand-written code.
+`,
+			value: []int{1, 2},
+			output: `package fixtures
+
+// Hand-written code.
+func Foo() {}
+
+// exporter:begin numbers
+var numbers = []int{int(1), int(2)}
+
+// exporter:end
+
+// More hand-written code.
+`,
+		},
+		{
+			name: "Function body",
+			input: `package fixtures
+
+func Foo() []int {
+	// exporter:begin numbers
+	// exporter:end numbers
+	return numbers
+}
+`,
+			value: []int{5},
+			output: `package fixtures
+
+func Foo() []int {
+	// exporter:begin numbers
+	var numbers = []int{int(5)}
+	// exporter:end numbers
+	return numbers
+}
+`,
+		},
+		{
+			name:  "Missing begin marker",
+			input: "package fixtures\n",
+			value: 5,
+			error: `marker "// exporter:begin numbers" not found`,
+		},
+		{
+			name:  "Missing end marker",
+			input: "package fixtures\n\n// exporter:begin numbers\n",
+			value: 5,
+			error: `marker "// exporter:end" not found after "// exporter:begin numbers"`,
+		},
+		{
+			name:  "Duplicated marker",
+			input: "package fixtures\n\n// exporter:begin numbers\n// exporter:end\n// exporter:begin numbers\n",
+			value: 5,
+			error: `marker "// exporter:begin numbers" is duplicated`,
+		},
+		{
+			name:  "Unsupported value",
+			input: "package fixtures\n",
+			value: make(chan int),
+			error: "type chan int is not supported",
+		},
+		{
+			name: "Qualified types",
+			input: `package fixtures
+
+import (
+	"time"
+)
+
+// exporter:begin numbers
+// exporter:end
+`,
+			value: map[time.Duration]net.IP{time.Second: net.IPv4(127, 0, 0, 1)},
+			output: `package fixtures
+
+import (
+	"net"
+	"time"
+)
+
+// exporter:begin numbers
+var numbers = map[time.Duration]net.IP{time.Duration(1000000000): net.ParseIP("127.0.0.1")}
+
+// exporter:end
+`,
+		},
+		{
+			name:  "Conflicting import",
+			input: "package fixtures\n\nimport \"example.com/net\"\n\n// exporter:begin numbers\n// exporter:end\n",
+			value: net.IPv4(127, 0, 0, 1),
+			error: `package "net" cannot be imported as "net", the name is used by the import of "example.com/net"`,
+		},
+		{
+			name:  "Marker before imports",
+			input: "package fixtures\n\n// exporter:begin numbers\n// exporter:end\n\nimport \"fmt\"\n",
+			value: time.Second,
+			error: `marker "// exporter:begin numbers" precedes imports`,
+		},
+	}
+
+	for _, s := range scenarios {
+		s := s
+
+		t.Run(s.name, func(t *testing.T) {
+			t.Parallel()
+
+			output, err := exporter.Patch([]byte(s.input), "numbers", s.value)
+			if s.error != "" {
+				assert.EqualError(t, err, s.error)
+				assert.Nil(t, output)
+
+				return
+			}
+
+			require.NoError(t, err)
+			assert.Equal(t, s.output, string(output))
+		})
+	}
+}
+
+func TestPatchFile(t *testing.T) {
+	t.Parallel()
+
+	f, err := ioutil.TempFile("", "fixtures*.go") //nolint:staticcheck
+	require.NoError(t, err)
+
+	defer os.Remove(f.Name())
+
+	_, err = f.WriteString("package fixtures\n\n// exporter:begin pi\n// exporter:end\n")
+	require.NoError(t, err)
+	require.NoError(t, f.Close())
+
+	require.NoError(t, exporter.PatchFile(f.Name(), "pi", 3.14))
+	require.NoError(t, exporter.PatchFile(f.Name(), "pi", 3.1416))
+
+	content, err := ioutil.ReadFile(f.Name()) //nolint:staticcheck
+	require.NoError(t, err)
+	assert.Equal(
+		t,
+		"package fixtures\n\n// exporter:begin pi\nvar pi = float64(3.1416)\n\n// exporter:end\n",
+		string(content),
+	)
+}
@@ -0,0 +1,240 @@
+// Copyright (c) 2023–present Bartłomiej Krukowski
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is furnished
+// to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package exporter
+
+import (
+	"errors"
+	"fmt"
+	"go/scanner"
+	"go/token"
+	"strconv"
+	"strings"
+)
+
+// StepKind defines the kind of a step of a Path.
+type StepKind int
+
+const (
+	// StepIndex refers to an element of a slice, an array or a sequential container, e.g. [0].
+	StepIndex StepKind = iota
+	// StepKey refers to an entry of a map, e.g. ["name"].
+	StepKey
+	// StepField refers to a field of a struct, e.g. .Name.
+	StepField
+)
+
+// Step is a single step of a Path, see IndexStep, KeyStep and FieldStep.
+type Step struct {
+	Kind  StepKind
+	Index int
+	// Key is the exported GO code of the key, e.g. "name" or int(1) including quotes.
+	Key   string
+	Field string
+}
+
+// IndexStep returns a step that refers to the element of the given index.
+func IndexStep(i int) Step {
+	return Step{Kind: StepIndex, Index: i, Key: "", Field: ""}
+}
+
+// KeyStep returns a step that refers to the entry of the given key.
+// The key must be the exported code of the key, e.g. `"name"`, see Export.
+func KeyStep(key string) Step {
+	return Step{Kind: StepKey, Index: 0, Key: key, Field: ""}
+}
+
+// FieldStep returns a step that refers to the field of the given name.
+func FieldStep(name string) Step {
+	return Step{Kind: StepField, Index: 0, Key: "", Field: name}
+}
+
+// String returns the step in the format used by Path.String.
+func (s Step) String() string {
+	switch s.Kind {
+	case StepIndex:
+		return "[" + strconv.Itoa(s.Index) + "]"
+	case StepKey:
+		return "[" + s.Key + "]"
+	case StepField:
+		return "." + s.Field
+	}
+
+	return fmt.Sprintf("<invalid step %d>", s.Kind)
+}
+
+// Path addresses a node of an exported value, e.g.:
+//
+//	.Users[0].Tags["admin"]
+//
+// Paths are relative to the exported value, the empty path refers to the value itself.
+// See ParsePath and ErrorPath.
+type Path []Step
+
+// String formats the path, ParsePath parses the result back.
+func (p Path) String() string {
+	buf := strings.Builder{}
+	for _, s := range p {
+		buf.WriteString(s.String())
+	}
+
+	return buf.String()
+}
+
+// ParsePath parses the given path, see Path.String. Keys are GO expressions in square brackets,
+// e.g. ["name"] or [int(1)], and indices are non-negative integers in square brackets, e.g. [0].
+func ParsePath(s string) (Path, error) {
+	fset := token.NewFileSet()
+	file := fset.AddFile("", fset.Base(), len(s))
+
+	var (
+		sc      scanner.Scanner
+		scanErr error
+	)
+
+	sc.Init(file, []byte(s), func(pos token.Position, msg string) {
+		if scanErr == nil {
+			scanErr = fmt.Errorf("invalid path %q: %s", s, msg) //nolint:goerr113
+		}
+	}, 0)
+
+	result := make(Path, 0)
+
+	next := func() (int, token.Token, string) {
+		pos, tok, lit := sc.Scan()
+		// the scanner inserts semicolons at the end of lines
+		if tok == token.SEMICOLON && lit == "\n" {
+			tok = token.EOF
+		}
+
+		return file.Offset(pos), tok, lit
+	}
+
+	for {
+		offset, tok, _ := next()
+
+		switch tok {
+		case token.EOF:
+			if scanErr != nil {
+				return nil, scanErr
+			}
+
+			return result, nil
+
+		case token.PERIOD:
+			_, tok, lit := next()
+			if tok != token.IDENT {
+				return nil, fmt.Errorf( //nolint:goerr113
+					"invalid path %q: field name expected at the position %d",
+					s,
+					offset+1,
+				)
+			}
+
+			result = append(result, FieldStep(lit))
+
+		case token.LBRACK:
+			end, err := closingBracket(next, s, offset)
+			if scanErr != nil {
+				// e.g. an unterminated string literal causes an unclosed bracket
+				return nil, scanErr
+			}
+
+			if err != nil {
+				return nil, err
+			}
+
+			inner := strings.TrimSpace(s[offset+1 : end])
+			if i, err := strconv.Atoi(inner); err == nil && i >= 0 && inner[0] != '+' {
+				result = append(result, IndexStep(i))
+			} else {
+				result = append(result, KeyStep(inner))
+			}
+
+		default:
+			return nil, fmt.Errorf( //nolint:goerr113
+				"invalid path %q: unexpected %q at the position %d",
+				s,
+				tok.String(),
+				offset,
+			)
+		}
+	}
+}
+
+// closingBracket returns the offset of the bracket that closes the one at the given offset.
+func closingBracket(next func() (int, token.Token, string), s string, offset int) (int, error) {
+	depth := 1
+
+	for {
+		end, tok, _ := next()
+
+		switch tok {
+		case token.EOF:
+			return 0, fmt.Errorf("invalid path %q: unclosed bracket at the position %d", s, offset) //nolint:goerr113
+		case token.LBRACK:
+			depth++
+		case token.RBRACK:
+			depth--
+			if depth == 0 {
+				if strings.TrimSpace(s[offset+1:end]) == "" {
+					return 0, fmt.Errorf("invalid path %q: empty brackets at the position %d", s, offset) //nolint:goerr113
+				}
+
+				return end, nil
+			}
+		}
+	}
+}
+
+// ErrorPath returns the path of the node that could not be exported, e.g.:
+//
+//	_, err := exporter.Export(map[string][]any{"a": {1, make(chan int)}})
+//	fmt.Println(exporter.ErrorPath(err)) // ["a"][1]
+func ErrorPath(err error) Path {
+	result := make(Path, 0)
+
+	var pe pathError
+	for errors.As(err, &pe) {
+		result = append(result, pe.step)
+		err = pe.err
+	}
+
+	return result
+}
+
+// pathError is returned when a node of a value cannot be exported, see ErrorPath.
+type pathError struct {
+	typ  string
+	step Step
+	err  error
+}
+
+func newPathError(typ string, step Step, err error) error {
+	return pathError{typ: typ, step: step, err: err}
+}
+
+func (e pathError) Error() string {
+	return "cannot export (" + e.typ + ")" + e.step.String() + ": " + e.err.Error()
+}
+
+func (e pathError) Unwrap() error {
+	return e.err
+}
@@ -0,0 +1,150 @@
+// Copyright (c) 2023–present Bartłomiej Krukowski
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is furnished
+// to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package exporter_test
+
+import (
+	"math"
+	"testing"
+
+	"github.com/gontainer/exporter"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParsePath(t *testing.T) {
+	t.Parallel()
+
+	//nolint:exhaustruct
+	scenarios := []struct {
+		input  string
+		output exporter.Path
+		error  string
+	}{
+		{
+			input:  ``,
+			output: exporter.Path{},
+		},
+		{
+			input: `.Users[0].Tags["admin"]`,
+			output: exporter.Path{
+				exporter.FieldStep("Users"),
+				exporter.IndexStep(0),
+				exporter.FieldStep("Tags"),
+				exporter.KeyStep(`"admin"`),
+			},
+		},
+		{
+			input: `[int(-1)]["a]"][[2]int{int(1), int(2)}]`,
+			output: exporter.Path{
+				exporter.KeyStep(`int(-1)`),
+				exporter.KeyStep(`"a]"`),
+				exporter.KeyStep(`[2]int{int(1), int(2)}`),
+			},
+		},
+		{
+			input: `.`,
+			error: `invalid path ".": field name expected at the position 1`,
+		},
+		{
+			input: `[0`,
+			error: `invalid path "[0": unclosed bracket at the position 0`,
+		},
+		{
+			input: `.A[]`,
+			error: `invalid path ".A[]": empty brackets at the position 2`,
+		},
+		{
+			input: `A`,
+			error: `invalid path "A": unexpected "IDENT" at the position 0`,
+		},
+		{
+			input: `["a`,
+			error: `invalid path "[\"a": string literal not terminated`,
+		},
+	}
+
+	for _, s := range scenarios {
+		s := s
+
+		t.Run(s.input, func(t *testing.T) {
+			t.Parallel()
+
+			p, err := exporter.ParsePath(s.input)
+			if s.error != "" {
+				assert.EqualError(t, err, s.error)
+				assert.Nil(t, p)
+
+				return
+			}
+
+			require.NoError(t, err)
+			assert.Equal(t, s.output, p)
+			assert.Equal(t, s.input, p.String())
+		})
+	}
+}
+
+func TestErrorPath(t *testing.T) {
+	t.Parallel()
+
+	scenarios := []struct {
+		name  string
+		input any
+		path  string
+	}{
+		{
+			name:  "Supported value",
+			input: 5,
+			path:  ``,
+		},
+		{
+			name:  "Unsupported value",
+			input: make(chan int),
+			path:  ``,
+		},
+		{
+			name:  "Nested value",
+			input: map[string][]any{"a": {1, make(chan int)}},
+			path:  `["a"][1]`,
+		},
+		{
+			name:  "Struct",
+			input: []structConfig{{}, {Extra: []any{math.NaN(), map[float64]int{math.NaN(): 1}}}},
+			path:  `[1].Extra[1][float64(NaN)]`,
+		},
+		{
+			name:  "Unexported field",
+			input: structConfig{timeout: 1},
+			path:  `.timeout`,
+		},
+	}
+
+	for _, s := range scenarios {
+		s := s
+
+		t.Run(s.name, func(t *testing.T) {
+			t.Parallel()
+
+			_, err := exporter.Export(s.input)
+			assert.Equal(t, s.path, exporter.ErrorPath(err).String())
+		})
+	}
+}
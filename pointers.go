@@ -0,0 +1,88 @@
+// Copyright (c) 2023–present Bartłomiej Krukowski
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is furnished
+// to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package exporter
+
+import (
+	"fmt"
+	"reflect"
+	"strings"
+)
+
+// pointerExporter exports pointers, e.g.:
+//
+//	&pkg.Config{Host: "localhost"}             // pointers to composite literals
+//	func(v int) *int { return &v }(int(5))      // pointers to other values
+//	(*pkg.Config)(nil)                          // nil pointers
+//
+// The JSON and CUE backends export the values the pointers point to, and nil pointers as null.
+// Cycles cannot be exported, and pointers shared by many values are exported as separate copies,
+// except values of maps, see mapExporter.
+type pointerExporter struct {
+	exporter exporter
+	backend  Backend
+}
+
+func (p pointerExporter) export(v any) (string, error) {
+	val := reflect.ValueOf(v)
+	if val.IsNil() {
+		return p.backend.renderNilPointer(val.Type()), nil
+	}
+
+	code, err := p.exporter.export(val.Elem().Interface())
+	if err != nil {
+		// paths do not contain dereferences, since GO dereferences pointers to structs implicitly
+		return "", err //nolint:wrapcheck
+	}
+
+	return p.backend.renderPointer(val.Type(), code), nil
+}
+
+func (pointerExporter) supports(v any) bool {
+	t := reflect.TypeOf(v)
+
+	// types of the values the pointers point to are verified while exporting, since types may be recursive
+	return t != nil && t.Kind() == reflect.Ptr
+}
+
+// renderGoPointer renders the pointer of the given type to the value of the given code.
+// Atomic values must not be copied, so pointers to them store the value in a new variable, e.g.:
+//
+//	func() *atomic.Int64 { v := new(atomic.Int64); v.Store(int64(5)); return v }()
+func renderGoPointer(t reflect.Type, code string) string {
+	elem := typeName(t.Elem())
+	if strings.HasPrefix(code, elem+"{") && strings.HasSuffix(code, "}") && isSingleBlock(code[len(elem):]) {
+		return "&" + code
+	}
+
+	if isAtomic(t.Elem()) {
+		// values of atomic types that are not zero are rendered by goBackend.renderAtomic
+		store := "*v = " + code
+
+		prefix := fmt.Sprintf(goAtomicPrefix, elem)
+		if strings.HasPrefix(code, prefix) && strings.HasSuffix(code, goAtomicSuffix) {
+			store = "v.Store(" + code[len(prefix):len(code)-len(goAtomicSuffix)] + ")"
+		}
+
+		return fmt.Sprintf("func() %s { v := new(%s); %s; return v }()", typeName(t), elem, store)
+	}
+
+	return fmt.Sprintf("func(v %s) %s { return &v }(%s)", elem, typeName(t), code)
+}
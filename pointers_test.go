@@ -0,0 +1,140 @@
+// Copyright (c) 2023–present Bartłomiej Krukowski
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is furnished
+// to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package exporter_test
+
+import (
+	"testing"
+
+	"github.com/gontainer/exporter"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type pointerNode struct {
+	Value int
+	Next  *pointerNode
+}
+
+//nolint:testifylint
+func TestExport_pointer(t *testing.T) {
+	t.Parallel()
+
+	i := 5
+	s := "hello"
+	var nilInt *int
+
+	//nolint:exhaustruct
+	scenarios := []struct {
+		name   string
+		input  any
+		output string
+		json   string
+		error  string
+	}{
+		{
+			name:   "Number",
+			input:  &i,
+			output: `func(v int) *int { return &v }(int(5))`,
+			json:   `5`,
+		},
+		{
+			name:   "String",
+			input:  &s,
+			output: `func(v string) *string { return &v }("hello")`,
+			json:   `"hello"`,
+		},
+		{
+			name:   "Nil",
+			input:  nilInt,
+			output: `(*int)(nil)`,
+			json:   `null`,
+		},
+		{
+			name:   "Struct",
+			input:  &StructServer{Port: 80},
+			output: `&exporter_test.StructServer{Port: int(80)}`,
+			json:   `{"Port":80}`,
+		},
+		{
+			name:   "Slice",
+			input:  &[]int{1},
+			output: `&[]int{int(1)}`,
+			json:   `[1]`,
+		},
+		{
+			name:   "Empty slice",
+			input:  &[]int{},
+			output: `func(v []int) *[]int { return &v }(make([]int, 0))`,
+			json:   `[]`,
+		},
+		{
+			name:   "Pointer to pointer",
+			input:  &nilInt,
+			output: `func(v *int) **int { return &v }((*int)(nil))`,
+			json:   `null`,
+		},
+		{
+			name:  "Nested",
+			input: []*pointerNode{{Value: 1, Next: &pointerNode{Value: 2}}, nil},
+			output: `[]*exporter_test.pointerNode{&exporter_test.pointerNode{Value: int(1), ` +
+				`Next: &exporter_test.pointerNode{Value: int(2)}}, (*exporter_test.pointerNode)(nil)}`,
+			json: `[{"Value":1,"Next":{"Value":2}},null]`,
+		},
+		{
+			name:  "Unsupported value",
+			input: []any{&[]chan int{nil}},
+			error: `cannot export ([]interface{})[0]: type []chan int is not supported`,
+		},
+	}
+
+	for _, s := range scenarios {
+		s := s
+
+		t.Run(s.name, func(t *testing.T) {
+			t.Parallel()
+
+			output, err := exporter.Export(s.input)
+			if s.error != "" {
+				assert.EqualError(t, err, s.error)
+				assert.Empty(t, output)
+
+				return
+			}
+
+			require.NoError(t, err)
+			assert.Equal(t, s.output, output)
+
+			output, err = exporter.New(exporter.WithBackend(exporter.JSONBackend())).Export(s.input)
+			require.NoError(t, err)
+			assert.Equal(t, s.json, output)
+		})
+	}
+
+	t.Run("Cycle", func(t *testing.T) {
+		t.Parallel()
+
+		n := &pointerNode{Value: 1} //nolint:exhaustruct
+		n.Next = n
+
+		_, err := exporter.Export(n)
+		assert.EqualError(t, err, `cannot export (exporter_test.pointerNode).Next: unexpected infinite loop`)
+	})
+}
@@ -0,0 +1,215 @@
+// Copyright (c) 2023–present Bartłomiej Krukowski
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is furnished
+// to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package exporter
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"go/ast"
+	"go/parser"
+	"go/scanner"
+	"go/token"
+	"sort"
+	"strings"
+)
+
+// ExportCompact exports the given value to a single line of a GO code, e.g.:
+//
+//	map[string][]int{"a": []int{int(1), int(2)}}
+//
+// It is an alias of Export, that makes the choice between the compact and the pretty format explicit.
+// See ExportPretty.
+func ExportCompact(v any) (string, error) {
+	return defaultExporter.Export(v)
+}
+
+// ExportPretty exports the given value to a multi-line GO code formatted by gofmt,
+// every element of a composite literal is placed in a separate line, e.g.:
+//
+//	map[string][]int{
+//		"a": []int{
+//			int(1),
+//			int(2),
+//		},
+//	}
+//
+// See Exporter.ExportPretty.
+func ExportPretty(v any) (string, error) {
+	return defaultExporter.ExportPretty(v)
+}
+
+// ExportPretty exports the given value to a multi-line code, see the function ExportPretty.
+// The output of the JSON and CUE backends is indented with tabs.
+// The indentation can be changed by WithIndent.
+func (e *Exporter) ExportPretty(v any) (string, error) {
+	code, err := e.exportCode(v)
+	if err != nil {
+		return "", err
+	}
+
+	indent := e.config.indent
+	if indent == "" {
+		indent = "\t"
+	}
+
+	return e.pretty(code, indent)
+}
+
+// WithIndent makes Exporter.Export render composite literals across multiple lines,
+// with one element per line and trailing commas, the way gofmt does.
+// Nested lines are indented with the given string, e.g. "\t" or "  ",
+// it should consist of whitespace characters only. An empty string restores the single-line output.
+//
+// See ExportPretty.
+func WithIndent(indent string) Option {
+	return func(c *config) {
+		c.indent = indent
+	}
+}
+
+// pretty breaks the lines of the given code according to the backend and indents them with the given string.
+func (e *Exporter) pretty(code string, indent string) (string, error) {
+	if _, ok := e.config.backend.(jsonBackend); ok {
+		buf := bytes.NewBuffer(nil)
+		if err := json.Indent(buf, []byte(code), "", indent); err != nil {
+			return "", fmt.Errorf("cannot indent exported JSON: %w", err)
+		}
+
+		return buf.String(), nil
+	}
+
+	if _, ok := e.config.backend.(cueBackend); ok {
+		return indentCUE(code, indent), nil
+	}
+
+	result, err := prettify(code)
+	if err != nil {
+		return "", err
+	}
+
+	return reindent(result, indent), nil
+}
+
+// prettify breaks the lines of the given expression after the opening braces and the elements of
+// composite literals, and formats the result by gofmt.
+func prettify(code string) (string, error) {
+	src := expressionPrefix + code
+	fset := token.NewFileSet()
+
+	file, err := parser.ParseFile(fset, "", src, parser.ParseComments)
+	if err != nil {
+		return "", fmt.Errorf("cannot parse exported code: %w", err)
+	}
+
+	type insertion struct {
+		offset int
+		text   string
+	}
+
+	var insertions []insertion
+
+	ast.Inspect(file, func(n ast.Node) bool {
+		lit, ok := n.(*ast.CompositeLit)
+		if !ok || len(lit.Elts) == 0 {
+			return true
+		}
+
+		for _, elt := range lit.Elts {
+			insertions = append(insertions, insertion{offset: fset.Position(elt.Pos()).Offset, text: "\n"})
+		}
+
+		insertions = append(insertions, insertion{offset: fset.Position(lit.Rbrace).Offset, text: ",\n"})
+
+		return true
+	})
+
+	sort.SliceStable(insertions, func(i, j int) bool {
+		return insertions[i].offset < insertions[j].offset
+	})
+
+	buf := strings.Builder{}
+	last := 0
+
+	for _, i := range insertions {
+		buf.WriteString(src[last:i.offset])
+		buf.WriteString(i.text)
+		last = i.offset
+	}
+
+	buf.WriteString(src[last:])
+
+	return formatExpression(strings.TrimPrefix(buf.String(), expressionPrefix))
+}
+
+// reindent replaces the leading tabs of the lines of the given code formatted by gofmt by the given indentation.
+// Lines inside multi-line raw strings are left untouched.
+func reindent(code string, indent string) string {
+	if indent == "\t" {
+		return code
+	}
+
+	type span struct {
+		from, to int
+	}
+
+	var raw []span
+
+	fset := token.NewFileSet()
+	var s scanner.Scanner
+	s.Init(fset.AddFile("", -1, len(code)), []byte(code), nil, 0)
+
+	for {
+		pos, tok, lit := s.Scan()
+		if tok == token.EOF {
+			break
+		}
+
+		if tok == token.STRING && strings.HasPrefix(lit, "`") && strings.Contains(lit, "\n") {
+			from := fset.Position(pos).Offset
+			raw = append(raw, span{from: from, to: from + len(lit)})
+		}
+	}
+
+	lines := strings.Split(code, "\n")
+	offset := 0
+
+	for i, line := range lines {
+		protected := false
+
+		for _, r := range raw {
+			if offset > r.from && offset < r.to {
+				protected = true
+
+				break
+			}
+		}
+
+		if !protected {
+			trimmed := strings.TrimLeft(line, "\t")
+			lines[i] = strings.Repeat(indent, len(line)-len(trimmed)) + trimmed
+		}
+
+		offset += len(line) + 1
+	}
+
+	return strings.Join(lines, "\n")
+}
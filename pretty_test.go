@@ -0,0 +1,194 @@
+// Copyright (c) 2023–present Bartłomiej Krukowski
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is furnished
+// to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package exporter_test
+
+import (
+	"testing"
+
+	"github.com/gontainer/exporter"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestExportCompact(t *testing.T) {
+	t.Parallel()
+
+	code, err := exporter.ExportCompact(map[string][]int{"a": {1, 2}})
+	require.NoError(t, err)
+	assert.Equal(t, `map[string][]int{"a": []int{int(1), int(2)}}`, code)
+}
+
+//nolint:testifylint
+func TestExportPretty(t *testing.T) {
+	t.Parallel()
+
+	//nolint:exhaustruct
+	scenarios := []struct {
+		name    string
+		input   any
+		output  string
+		error   string
+		options []exporter.Option
+	}{
+		{
+			name:   "Scalar",
+			input:  5,
+			output: `int(5)`,
+		},
+		{
+			name:   "Empty composite literals",
+			input:  []any{[]int{}, map[string]int{}, struct{}{}},
+			output: "[]interface{}{\n\tmake([]int, 0),\n\tmap[string]int{},\n\tstruct{}{},\n}",
+		},
+		{
+			name:  "Nested composite literals",
+			input: map[string][]int{"a": {1, 2}, "b": nil},
+			output: `map[string][]int{
+	"a": []int{
+		int(1),
+		int(2),
+	},
+	"b": ([]int)(nil),
+}`,
+		},
+		{
+			name:  "Struct",
+			input: StructServer{Host: "localhost", Port: 80},
+			output: `exporter_test.StructServer{
+	Host: "localhost",
+	Port: int(80),
+}`,
+		},
+		{
+			name:    "Comments",
+			input:   []string{"a", "b"},
+			output:  "[]string{\n\t\"a\", /* [0] */\n\t\"b\", /* [1] */\n}",
+			options: []exporter.Option{exporter.WithIndexComments(1)},
+		},
+		{
+			name:    "JSON",
+			input:   map[string][]int{"a": {1}},
+			output:  "{\n\t\"a\": [\n\t\t1\n\t]\n}",
+			options: []exporter.Option{exporter.WithBackend(exporter.JSONBackend())},
+		},
+		{
+			name:  "Error",
+			input: make(chan int),
+			error: `type chan int is not supported`,
+		},
+	}
+
+	for _, s := range scenarios {
+		s := s
+
+		t.Run(s.name, func(t *testing.T) {
+			t.Parallel()
+
+			output, err := exporter.New(s.options...).ExportPretty(s.input)
+			if s.error != "" {
+				assert.EqualError(t, err, s.error)
+				assert.Empty(t, output)
+
+				return
+			}
+
+			require.NoError(t, err)
+			assert.Equal(t, s.output, output)
+		})
+	}
+}
+
+type rawQuery struct {
+	Query string `export:"raw"`
+}
+
+func TestWithIndent(t *testing.T) {
+	t.Parallel()
+
+	//nolint:exhaustruct
+	scenarios := []struct {
+		name    string
+		input   any
+		output  string
+		options []exporter.Option
+	}{
+		{
+			name:    "Tabs",
+			input:   []int{1, 2},
+			output:  "[]int{\n\tint(1),\n\tint(2),\n}",
+			options: []exporter.Option{exporter.WithIndent("\t")},
+		},
+		{
+			name:    "Spaces",
+			input:   map[string][]int{"a": {1}},
+			output:  "map[string][]int{\n  \"a\": []int{\n    int(1),\n  },\n}",
+			options: []exporter.Option{exporter.WithIndent("  ")},
+		},
+		{
+			name:    "Scalar",
+			input:   "a\tb",
+			output:  `"a\tb"`,
+			options: []exporter.Option{exporter.WithIndent("  ")},
+		},
+		{
+			name:    "Multi-line raw strings",
+			input:   rawQuery{Query: "SELECT *\n\tFROM t"},
+			output:  "exporter_test.rawQuery{\n  Query: `SELECT *\n\tFROM t`,\n}",
+			options: []exporter.Option{exporter.WithIndent("  ")},
+		},
+		{
+			name:    "JSON",
+			input:   []int{1},
+			output:  "[\n  1\n]",
+			options: []exporter.Option{exporter.WithIndent("  "), exporter.WithBackend(exporter.JSONBackend())},
+		},
+		{
+			name:    "CUE",
+			input:   []int{1},
+			output:  "[\n  1,\n]",
+			options: []exporter.Option{exporter.WithIndent("  "), exporter.WithBackend(exporter.CUEBackend())},
+		},
+		{
+			name:    "Disabled",
+			input:   []int{1},
+			output:  "[]int{int(1)}",
+			options: []exporter.Option{exporter.WithIndent("  "), exporter.WithIndent("")},
+		},
+	}
+
+	for _, s := range scenarios {
+		s := s
+
+		t.Run(s.name, func(t *testing.T) {
+			t.Parallel()
+
+			e := exporter.New(s.options...)
+			assert.Equal(t, s.output, e.MustExport(s.input))
+
+			pretty, err := e.ExportPretty(s.input)
+			require.NoError(t, err)
+
+			if s.name != "Disabled" {
+				assert.Equal(t, s.output, pretty)
+			}
+		})
+	}
+}
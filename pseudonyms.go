@@ -0,0 +1,95 @@
+// Copyright (c) 2023–present Bartłomiej Krukowski
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is furnished
+// to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package exporter
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"regexp"
+	"strings"
+)
+
+// EmailPattern matches email addresses, see PseudonymRule.
+//
+//nolint:gochecknoglobals
+var EmailPattern = regexp.MustCompile(`[a-zA-Z0-9._%+\-]+@[a-zA-Z0-9.\-]+\.[a-zA-Z]{2,}`)
+
+// PseudonymRule defines which parts of strings are replaced by pseudonyms, see WithPseudonyms.
+type PseudonymRule struct {
+	// Pattern matches the parts of strings that are replaced.
+	Pattern *regexp.Regexp
+	// Format is the format of pseudonyms, the verb %s is replaced by the hash of the original text,
+	// e.g. "user-%s@example.com". An empty format means "%s".
+	Format string
+}
+
+// WithPseudonyms replaces parts of strings that match the given rules by pseudonyms, e.g.:
+//
+//	key := []byte("secret")
+//	exporter.WithPseudonyms(key, exporter.PseudonymRule{Pattern: exporter.EmailPattern, Format: "user-%s@example.com"})
+//
+// exports "Mary <mary@company.com>" as "Mary <user-45e734f38fb4cedc@example.com>".
+// Pseudonyms are derived from HMAC-SHA256 of the original text with the given key, so the same text always gets
+// the same pseudonym, and exported production snapshots stay stable across regenerations and keep their references.
+// The key must be secret, otherwise the original texts can be guessed by hashing candidates.
+//
+// Rules are applied in the given order to strings, keys of maps, and fields with the directive "raw".
+func WithPseudonyms(key []byte, rules ...PseudonymRule) Option {
+	return func(c *config) {
+		c.pseudonyms = &pseudonymizer{key: key, rules: rules}
+	}
+}
+
+type pseudonymizer struct {
+	key   []byte
+	rules []PseudonymRule
+}
+
+// replace returns the given string with the parts that match rules replaced by pseudonyms.
+// The nil pseudonymizer returns the given string as it is.
+func (p *pseudonymizer) replace(s string) string {
+	if p == nil {
+		return s
+	}
+
+	for _, r := range p.rules {
+		format := r.Format
+		if format == "" {
+			format = "%s"
+		}
+
+		s = r.Pattern.ReplaceAllStringFunc(s, func(match string) string {
+			return strings.ReplaceAll(format, "%s", p.hash(match))
+		})
+	}
+
+	return s
+}
+
+func (p *pseudonymizer) hash(s string) string {
+	mac := hmac.New(sha256.New, p.key)
+	_, _ = mac.Write([]byte(s)) // writing to a hash never fails
+
+	const size = 8
+
+	return hex.EncodeToString(mac.Sum(nil)[:size])
+}
@@ -0,0 +1,71 @@
+// Copyright (c) 2023–present Bartłomiej Krukowski
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is furnished
+// to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package exporter_test
+
+import (
+	"regexp"
+	"testing"
+
+	"github.com/gontainer/exporter"
+	"github.com/stretchr/testify/assert"
+)
+
+type pseudonymContact struct {
+	Name  string
+	Email string
+	Note  string `export:"raw"`
+}
+
+func TestWithPseudonyms(t *testing.T) {
+	t.Parallel()
+
+	e := exporter.New(exporter.WithPseudonyms(
+		[]byte("secret"),
+		exporter.PseudonymRule{Pattern: exporter.EmailPattern, Format: "user-%s@example.com"},
+		exporter.PseudonymRule{Pattern: regexp.MustCompile(`\+48 \d{9}`), Format: ""},
+	))
+
+	input := map[string]pseudonymContact{
+		"mary@company.com": {Name: "Mary", Email: "Mary <mary@company.com>", Note: "call +48 123456789\n"},
+	}
+
+	output := e.MustExport(input)
+	assert.Regexp(
+		t,
+		`^map\[string\]exporter_test.pseudonymContact{"user-([0-9a-f]{16})@example.com": exporter_test.pseudonymContact{`+
+			`Name: "Mary", Email: "Mary <user-([0-9a-f]{16})@example.com>", Note: `+"`call [0-9a-f]{16}\n`"+`}}$`,
+		output,
+	)
+	assert.NotContains(t, output, "mary@company.com")
+
+	// the same text always gets the same pseudonym
+	m := regexp.MustCompile(`user-([0-9a-f]{16})@`).FindAllStringSubmatch(output, -1)
+	assert.Equal(t, m[0][1], m[1][1])
+	assert.Equal(t, output, e.MustExport(input))
+
+	// pseudonyms depend on the key
+	other := exporter.New(exporter.WithPseudonyms(
+		[]byte("other"),
+		exporter.PseudonymRule{Pattern: exporter.EmailPattern, Format: "user-%s@example.com"},
+	))
+	assert.NotEqual(t, e.MustExport("mary@company.com"), other.MustExport("mary@company.com"))
+	assert.Equal(t, `"John"`, other.MustExport("John"))
+}
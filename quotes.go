@@ -0,0 +1,97 @@
+// Copyright (c) 2023–present Bartłomiej Krukowski
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is furnished
+// to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package exporter
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"unicode/utf8"
+)
+
+// QuoteStyle defines how the GO backend escapes strings, byte slices and keys of maps, see WithQuoteStyle.
+type QuoteStyle int
+
+const (
+	// QuoteASCII escapes all non-ASCII characters like the verb %+q, it is the default value, e.g.:
+	//
+	//	"café \U0001f600"
+	QuoteASCII QuoteStyle = iota
+	// QuoteUnicode escapes non-printable characters like the verb %q, e.g.:
+	//
+	//	"café 😀"
+	QuoteUnicode
+	// QuoteMinimal escapes only quotes, backslashes, control characters except tabs, byte order marks,
+	// and bytes that are not valid UTF-8, e.g. zero-width joiners of emoji sequences are kept as they are.
+	QuoteMinimal
+)
+
+// WithQuoteStyle sets how the GO backend escapes strings, byte slices and keys of maps.
+// It does not affect the JSON and CUE backends, that follow their own rules.
+func WithQuoteStyle(s QuoteStyle) Option {
+	return func(c *config) {
+		c.quoteStyle = s
+	}
+}
+
+// quote returns the GO literal of the given string escaped according to the given style.
+func quote(s string, style QuoteStyle) string {
+	switch style {
+	case QuoteASCII:
+		return fmt.Sprintf("%+q", s)
+	case QuoteUnicode:
+		return strconv.Quote(s)
+	case QuoteMinimal:
+		return quoteMinimal(s)
+	}
+
+	return fmt.Sprintf("%+q", s)
+}
+
+func quoteMinimal(s string) string {
+	buf := strings.Builder{}
+	buf.WriteByte('"')
+
+	for i := 0; i < len(s); {
+		r, size := utf8.DecodeRuneInString(s[i:])
+
+		switch {
+		case r == utf8.RuneError && size == 1:
+			buf.WriteString(fmt.Sprintf(`\x%02x`, s[i]))
+		case r == '"' || r == '\\':
+			buf.WriteByte('\\')
+			buf.WriteRune(r)
+		case r == '\t':
+			buf.WriteRune(r)
+		case r < ' ' || r == 0x7f || r == '\ufeff':
+			// strconv.Quote uses the shortest escape sequences, e.g. \n
+			buf.WriteString(strings.Trim(strconv.QuoteRune(r), "'"))
+		default:
+			buf.WriteString(s[i : i+size])
+		}
+
+		i += size
+	}
+
+	buf.WriteByte('"')
+
+	return buf.String()
+}
@@ -0,0 +1,95 @@
+// Copyright (c) 2023–present Bartłomiej Krukowski
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is furnished
+// to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package exporter_test
+
+import (
+	"strconv"
+	"strings"
+	"testing"
+
+	"github.com/gontainer/exporter"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestWithQuoteStyle(t *testing.T) {
+	t.Parallel()
+
+	const input = "caf\u00e9 \"\U0001f600\"\t\\\n\x00\x7f\ufeff\xff\u200d"
+
+	scenarios := []struct {
+		name   string
+		style  exporter.QuoteStyle
+		output string
+	}{
+		{
+			name:   "ASCII",
+			style:  exporter.QuoteASCII,
+			output: `"caf\u00e9 \"\U0001f600\"\t\\\n\x00\x7f\ufeff\xff\u200d"`,
+		},
+		{
+			name:   "Unicode",
+			style:  exporter.QuoteUnicode,
+			output: "\"caf\u00e9 \\\"\U0001f600\\\"\\t\\\\\\n\\x00\\x7f\\ufeff\\xff\\u200d\"",
+		},
+		{
+			name:   "Minimal",
+			style:  exporter.QuoteMinimal,
+			output: "\"caf\u00e9 \\\"\U0001f600\\\"\t\\\\\\n\\x00\\x7f\\ufeff\\xff\u200d\"",
+		},
+	}
+
+	for _, s := range scenarios {
+		s := s
+
+		t.Run(s.name, func(t *testing.T) {
+			t.Parallel()
+
+			unquoted, err := strconv.Unquote(s.output)
+			require.NoError(t, err)
+			assert.Equal(t, input, unquoted)
+
+			e := exporter.New(exporter.WithQuoteStyle(s.style))
+			assert.Equal(t, s.output, e.MustExport(input))
+			// byte slices that are not valid UTF-8 are exported as lists of numbers
+			assert.Equal(
+				t,
+				"[]byte("+strings.Replace(s.output, `\xff`, "", 1)+")",
+				e.MustExport([]byte(strings.Replace(input, "\xff", "", 1))),
+			)
+			assert.Equal(t, "map[string]bool{"+s.output+": true}", e.MustExport(map[string]bool{input: true}))
+		})
+	}
+
+	t.Run("Order of options", func(t *testing.T) {
+		t.Parallel()
+
+		e := exporter.New(exporter.WithQuoteStyle(exporter.QuoteUnicode), exporter.WithBackend(exporter.GoBackend()))
+		assert.Equal(t, "\"\u00e9\"", e.MustExport("\u00e9"))
+	})
+
+	t.Run("JSON", func(t *testing.T) {
+		t.Parallel()
+
+		e := exporter.New(exporter.WithQuoteStyle(exporter.QuoteMinimal), exporter.WithBackend(exporter.JSONBackend()))
+		assert.Equal(t, `"\n"`, e.MustExport("\n"))
+	})
+}
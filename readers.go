@@ -0,0 +1,96 @@
+// Copyright (c) 2023–present Bartłomiej Krukowski
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is furnished
+// to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package exporter
+
+import (
+	"bytes"
+	"errors"
+	"reflect"
+	"strings"
+)
+
+//nolint:gochecknoglobals
+var (
+	stringsReaderType = reflect.TypeOf((*strings.Reader)(nil))
+	bytesReaderType   = reflect.TypeOf((*bytes.Reader)(nil))
+)
+
+// readerExporter exports readers of strings and byte slices that have not been read yet, e.g.:
+//
+//	strings.NewReader("hello")
+//	bytes.NewReader([]byte("hello"))
+//
+// Readers stored in fields of interface types are converted explicitly, e.g. io.Reader(strings.NewReader("hello")).
+// Readers that have been read, even partially, cannot be exported, since the exported code would not reproduce
+// their positions.
+type readerExporter struct {
+	exporter exporter
+}
+
+func (e readerExporter) export(v any) (string, error) {
+	if r, ok := v.(*strings.Reader); ok {
+		if r == nil {
+			return "(*strings.Reader)(nil)", nil
+		}
+
+		if r.Len() != int(r.Size()) {
+			return "", errors.New("*strings.Reader has been read and cannot be reproduced") //nolint:goerr113
+		}
+
+		// the copy is read, so the original reader is not changed
+		var buf strings.Builder
+		c := *r
+		_, _ = c.WriteTo(&buf) // writing to strings.Builder cannot fail
+
+		return e.render("strings.NewReader", buf.String())
+	}
+
+	r := v.(*bytes.Reader) //nolint:forcetypeassert
+	if r == nil {
+		return "(*bytes.Reader)(nil)", nil
+	}
+
+	if r.Len() != int(r.Size()) {
+		return "", errors.New("*bytes.Reader has been read and cannot be reproduced") //nolint:goerr113
+	}
+
+	var buf bytes.Buffer
+	c := *r
+	_, _ = c.WriteTo(&buf) // writing to bytes.Buffer cannot fail
+
+	return e.render("bytes.NewReader", buf.Bytes())
+}
+
+// render returns the call of the given constructor with the given exported argument.
+func (e readerExporter) render(ctor string, arg any) (string, error) {
+	code, err := e.exporter.export(arg)
+	if err != nil {
+		return "", err //nolint:wrapcheck
+	}
+
+	return ctor + "(" + code + ")", nil
+}
+
+func (readerExporter) supports(v any) bool {
+	t := reflect.TypeOf(v)
+
+	return t == stringsReaderType || t == bytesReaderType
+}
@@ -0,0 +1,113 @@
+// Copyright (c) 2023–present Bartłomiej Krukowski
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is furnished
+// to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package exporter_test
+
+import (
+	"bytes"
+	"io"
+	"strings"
+	"testing"
+
+	"github.com/gontainer/exporter"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type readersRequest struct {
+	Body io.Reader
+}
+
+func TestExport_readers(t *testing.T) {
+	t.Parallel()
+
+	read := strings.NewReader("hello")
+	_, _ = read.ReadByte()
+
+	//nolint:exhaustruct
+	scenarios := []struct {
+		name   string
+		input  any
+		output string
+		error  string
+	}{
+		{
+			name:   "strings.Reader",
+			input:  strings.NewReader(`say "hello"`),
+			output: `strings.NewReader("say \"hello\"")`,
+		},
+		{
+			name:   "bytes.Reader",
+			input:  bytes.NewReader([]byte("hello")),
+			output: `bytes.NewReader([]byte("hello"))`,
+		},
+		{
+			name:   "Interface field",
+			input:  readersRequest{Body: strings.NewReader("x")},
+			output: `exporter_test.readersRequest{Body: io.Reader(strings.NewReader("x"))}`,
+		},
+		{
+			name:   "Nil",
+			input:  []*strings.Reader{nil},
+			output: `[]*strings.Reader{(*strings.Reader)(nil)}`,
+		},
+		{
+			name:  "Read reader",
+			input: []io.Reader{read},
+			error: `cannot export ([]io.Reader)[0]: *strings.Reader has been read and cannot be reproduced`,
+		},
+	}
+
+	for _, s := range scenarios {
+		s := s
+
+		t.Run(s.name, func(t *testing.T) {
+			t.Parallel()
+
+			output, err := exporter.Export(s.input)
+			if s.error != "" {
+				assert.EqualError(t, err, s.error)
+				assert.Empty(t, output)
+
+				return
+			}
+
+			require.NoError(t, err)
+			assert.Equal(t, s.output, output)
+		})
+	}
+
+	t.Run("Unchanged reader", func(t *testing.T) {
+		t.Parallel()
+
+		r := strings.NewReader("hello")
+		_ = exporter.MustExport(r)
+		assert.Equal(t, 5, r.Len())
+	})
+
+	t.Run("File", func(t *testing.T) {
+		t.Parallel()
+
+		code, err := exporter.ExportFile("fixtures", "Body", struct{ Body io.Reader }{Body: strings.NewReader("x")})
+		require.NoError(t, err)
+		assert.Contains(t, string(code), "import (\n\t\"io\"\n\t\"strings\"\n)\n")
+		vetFile(t, code)
+	})
+}
@@ -0,0 +1,164 @@
+// Copyright (c) 2023–present Bartłomiej Krukowski
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is furnished
+// to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package exporter
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// DefaultRecoveredMaxNodes is the default limit of nodes of each value exported by ExportRecovered, see WithMaxNodes.
+const DefaultRecoveredMaxNodes = 10000
+
+// ExportRecovered exports the value recovered from a panic and the given local variables to a GO file,
+// so the input that caused the crash can be reproduced by a regression test, e.g.:
+//
+//	defer func() {
+//		if r := recover(); r != nil {
+//			code, _ := exporter.ExportRecovered("crashes", r, map[string]any{"input": input})
+//			_ = os.WriteFile("crashes/crash.go", code, 0o644)
+//			panic(r)
+//		}
+//	}()
+//
+// The file declares the variable Recovered, and one variable per local, sorted by name:
+//
+//	var Recovered = errors.New("runtime error: index out of range [3] with length 3")
+//
+//	var input = []int{int(1), int(2), int(3)}
+//
+// It works leniently: values that cannot be exported, e.g. channels, or values that exceed limits,
+// are declared as strings formatted by fmt.Sprintf("%v", v), and the reason is written in a comment.
+// Such errors, e.g. runtime errors, are declared as errors.New(v.Error()).
+// Each value may consist of at most DefaultRecoveredMaxNodes nodes, unless WithMaxNodes sets another limit.
+// Names of locals are sanitized, see WithIdentifierSanitizing.
+// An error is returned only when the file cannot be rendered, e.g. the name of the package is invalid.
+//
+// See Exporter.ExportRecovered.
+func ExportRecovered(pkg string, recovered any, locals map[string]any) ([]byte, error) {
+	return defaultExporter.ExportRecovered(pkg, recovered, locals)
+}
+
+// ExportRecovered exports the value recovered from a panic and the given local variables to a GO file,
+// see the function ExportRecovered.
+func (e *Exporter) ExportRecovered(pkg string, recovered any, locals map[string]any) ([]byte, error) {
+	if _, ok := e.config.backend.(goBackend); !ok {
+		return nil, errors.New("ExportRecovered requires the GO backend") //nolint:goerr113
+	}
+
+	cfg := e.config
+	cfg.sanitizeNames = true
+	cfg.declaration = DeclarationVar
+	cfg.copySuffix = ""
+	cfg.typedSuffix = ""
+
+	if cfg.maxNodes <= 0 {
+		cfg.maxNodes = DefaultRecoveredMaxNodes
+	}
+
+	r := &Exporter{config: cfg, exporter: newRootExporter(cfg), caster: e.caster}
+
+	names := make([]string, 0, len(locals))
+	for n := range locals {
+		names = append(names, n)
+	}
+
+	sort.Strings(names)
+
+	values := make([]any, 0, len(locals)+1)
+	values = append(values, recovered)
+
+	for _, n := range names {
+		values = append(values, locals[n])
+	}
+
+	pkg, err := r.identifier(pkg, func(name string) (string, string) {
+		return name, identifierReason(name, nil)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	reserved := reservedIdentifiers(values)
+	declared := make(map[string]struct{})
+	check := func(name string) (string, string) {
+		if _, ok := declared[name]; ok {
+			return name, "it is declared twice"
+		}
+
+		return name, identifierReason(name, reserved)
+	}
+
+	var (
+		decls   strings.Builder
+		exprs   []string
+		imports []string
+	)
+
+	for i, v := range values {
+		name := "Recovered"
+		if i > 0 {
+			if name, err = r.identifier(names[i-1], check); err != nil {
+				name = fmt.Sprintf("local%d", i)
+			}
+		}
+
+		declared[name] = struct{}{}
+
+		code, err := r.exportRecovered(v)
+		if err != nil {
+			reason := strings.ReplaceAll(err.Error(), "\n", " ")
+			decls.WriteString(fmt.Sprintf("// %s cannot be exported: %s\n", name, reason))
+			// fmt recovers from panics of methods String and Error
+			code = quote(fmt.Sprintf("%v", v), cfg.quoteStyle)
+
+			// e.g. runtime errors
+			if _, ok := v.(error); ok {
+				code = "errors.New(" + code + ")"
+				imports = append(imports, "errors")
+			}
+		} else {
+			exprs = append(exprs, code)
+		}
+
+		decls.WriteString("var " + name + " = " + code + "\n\n")
+	}
+
+	found, err := findImports(values, r.config, exprs...)
+	if err != nil {
+		return nil, err
+	}
+
+	return r.renderFile(pkg, mergeImports(found, imports...), decls.String())
+}
+
+// exportRecovered exports the given value, and turns panics into errors.
+func (e *Exporter) exportRecovered(v any) (code string, err error) {
+	defer func() {
+		if r := recover(); r != nil {
+			code, err = "", fmt.Errorf("panic: %v", r) //nolint:goerr113
+		}
+	}()
+
+	return e.Export(v)
+}
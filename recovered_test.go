@@ -0,0 +1,115 @@
+// Copyright (c) 2023–present Bartłomiej Krukowski
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is furnished
+// to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package exporter_test
+
+import (
+	"testing"
+
+	"github.com/gontainer/exporter"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type panickingStringer struct {
+	ch chan int
+}
+
+func (panickingStringer) String() string {
+	panic("boom")
+}
+
+func TestExportRecovered(t *testing.T) {
+	t.Parallel()
+
+	t.Run("Crash", func(t *testing.T) {
+		t.Parallel()
+
+		input := []int{1, 2, 3}
+
+		var code []byte
+
+		func() {
+			defer func() {
+				if r := recover(); r != nil {
+					var err error
+					code, err = exporter.ExportRecovered("crashes", r, map[string]any{
+						"input":   input,
+						"index":   3,
+						"chan":    panickingStringer{ch: make(chan int)},
+						"my-name": "Mary",
+					})
+					require.NoError(t, err)
+				}
+			}()
+
+			i := 3
+			_ = input[i]
+		}()
+
+		assert.Equal(
+			t,
+			`// Code generated by github.com/gontainer/exporter. DO NOT EDIT.
+// Format: 2
+
+package crashes
+
+import (
+	"errors"
+)
+
+// Recovered cannot be exported: cannot export (runtime.boundsError).x: unexported field is not zero
+var Recovered = errors.New("runtime error: index out of range [3] with length 3")
+
+// chan_ cannot be exported: cannot export (exporter_test.panickingStringer).ch: unexported field is not zero
+var chan_ = "%!v(PANIC=String method: boom)"
+
+var index = int(3)
+
+var input = []int{int(1), int(2), int(3)}
+
+var my_name = "Mary"
+`,
+			withoutChecksum(t, code),
+		)
+	})
+	t.Run("Limits", func(t *testing.T) {
+		t.Parallel()
+
+		code, err := exporter.ExportRecovered("my-crashes", "boom", map[string]any{"big": make([]int, 20000)})
+		require.NoError(t, err)
+		assert.Contains(t, string(code), "package my_crashes\n")
+		assert.Contains(t, string(code), "// big cannot be exported: ")
+		assert.Contains(t, string(code), "var Recovered = \"boom\"\n")
+
+		e := exporter.New(exporter.WithMaxNodes(30000))
+		code, err = e.ExportRecovered("crashes", "boom", map[string]any{"big": make([]int, 20000)})
+		require.NoError(t, err)
+		assert.NotContains(t, string(code), "cannot be exported")
+	})
+
+	t.Run("Errors", func(t *testing.T) {
+		t.Parallel()
+
+		code, err := exporter.New(exporter.WithBackend(exporter.JSONBackend())).ExportRecovered("crashes", 1, nil)
+		assert.EqualError(t, err, "ExportRecovered requires the GO backend")
+		assert.Nil(t, code)
+	})
+}
@@ -0,0 +1,71 @@
+// Copyright (c) 2023–present Bartłomiej Krukowski
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is furnished
+// to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package exporter
+
+// CustomExporter exports values of types that the built-in exporters do not support, or exports them differently,
+// see Exporter.Register.
+type CustomExporter interface {
+	// Supports reports whether the exporter supports the given value. It is also called with zero values
+	// of element types of slices, arrays and maps, to verify whether the exporter supports their elements.
+	Supports(v any) bool
+	// Export returns the code of the given value in the language of the backend, see WithBackend.
+	Export(v any) (string, error)
+}
+
+// Register adds the given exporter. Registered exporters precede the built-in ones, and the ones registered
+// earlier precede the ones registered later. They are used for values nested in slices, arrays, maps
+// and structs too, e.g.:
+//
+//	type uuidExporter struct{}
+//
+//	func (uuidExporter) Supports(v any) bool {
+//		_, ok := v.(uuid.UUID)
+//		return ok
+//	}
+//
+//	func (uuidExporter) Export(v any) (string, error) {
+//		return fmt.Sprintf("uuid.MustParse(%q)", v.(uuid.UUID).String()), nil
+//	}
+//
+//	e := exporter.New()
+//	e.Register(uuidExporter{})
+//	e.MustExport([]uuid.UUID{...}) // []uuid.UUID{uuid.MustParse("..."), ...}
+//
+// ExportFile imports packages referenced by the code, if they are packages of types reachable from the value.
+// Register must not be called concurrently with other methods of the Exporter.
+func (e *Exporter) Register(c CustomExporter) {
+	custom := make([]CustomExporter, 0, len(e.config.customExporters)+1)
+	e.config.customExporters = append(append(custom, e.config.customExporters...), c)
+	e.exporter = newRootExporter(e.config)
+}
+
+// customExporter adapts CustomExporter to the internal interface.
+type customExporter struct {
+	custom CustomExporter
+}
+
+func (c customExporter) export(v any) (string, error) {
+	return c.custom.Export(v) //nolint:wrapcheck
+}
+
+func (c customExporter) supports(v any) bool {
+	return c.custom.Supports(v)
+}
@@ -0,0 +1,97 @@
+// Copyright (c) 2023–present Bartłomiej Krukowski
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is furnished
+// to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package exporter_test
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/gontainer/exporter"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type (
+	registerID struct {
+		value string
+	}
+
+	registerIDExporter struct {
+		prefix string
+	}
+
+	registerHolder struct {
+		ID registerID
+	}
+)
+
+func (r registerIDExporter) Supports(v any) bool {
+	_, ok := v.(registerID)
+
+	return ok
+}
+
+func (r registerIDExporter) Export(v any) (string, error) {
+	return fmt.Sprintf("%s(%q)", r.prefix, v.(registerID).value), nil //nolint:forcetypeassert
+}
+
+func TestExporter_Register(t *testing.T) {
+	t.Parallel()
+
+	input := map[string][]registerHolder{"a": {{ID: registerID{value: "1"}}}}
+
+	_, err := exporter.Export(input)
+	require.EqualError(
+		t,
+		err,
+		`cannot export (map[string][]exporter_test.registerHolder)["a"]: `+
+			`cannot export ([]exporter_test.registerHolder)[0]: cannot export (exporter_test.registerHolder).ID: `+
+			`cannot export (exporter_test.registerID).value: unexported field is not zero`,
+	)
+
+	e := exporter.New(exporter.WithCompositeElision())
+	e.Register(registerIDExporter{prefix: "exporter_test.ParseID"})
+	e.Register(registerIDExporter{prefix: "ignored"})
+
+	assert.Equal(
+		t,
+		`map[string][]exporter_test.registerHolder{"a": {{ID: exporter_test.ParseID("1")}}}`,
+		e.MustExport(input),
+	)
+	assert.Equal(
+		t,
+		`[1]exporter_test.registerID{exporter_test.ParseID("")}`,
+		e.MustExport([1]registerID{}),
+	)
+
+	code, err := e.ExportFile("fixtures", "IDs", []registerID{{value: "2"}})
+	require.NoError(t, err)
+	assert.Contains(
+		t,
+		string(code),
+		"import (\n\t\"github.com/gontainer/exporter_test\"\n)\n\n"+
+			"var IDs = []exporter_test.registerID{exporter_test.ParseID(\"2\")}\n",
+	)
+
+	// other exporters are not affected
+	_, err = exporter.New().Export(registerID{value: "1"})
+	require.Error(t, err)
+}
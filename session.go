@@ -0,0 +1,287 @@
+// Copyright (c) 2023–present Bartłomiej Krukowski
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is furnished
+// to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package exporter
+
+import (
+	"errors"
+	"fmt"
+	"go/ast"
+	"go/parser"
+	"go/token"
+	"reflect"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// sessionConstMinLength is the minimal length of strings declared as constants by Session.
+const sessionConstMinLength = 16
+
+// Session exports many values to a single GO file, e.g.:
+//
+//	s := exporter.NewSession("fixtures")
+//	_ = s.Add("Users", users)
+//	_ = s.Add("Orders", orders)
+//	code, _ := s.File()
+//
+// The file imports each package once, and Add rejects values whose packages have the same name.
+// Helper functions, see WithMapSplitting, get names unique within the file.
+// Strings of at least 16 bytes that occur more than once are declared as constants, e.g.:
+//
+//	const (
+//		str1 = "https://example.com/avatars/"
+//	)
+//
+// A Session is not safe for concurrent use.
+type Session struct {
+	exporter *Exporter
+	pkg      string
+	values   []any
+	decls    []string
+	exprs    []string
+	declared map[string]struct{}
+}
+
+// NewSession creates a new Session that generates a file of the package pkg.
+//
+// See Exporter.NewSession.
+func NewSession(pkg string) *Session {
+	return defaultExporter.NewSession(pkg)
+}
+
+// NewSession creates a new Session that generates a file of the package pkg using the configuration of the Exporter.
+func (e *Exporter) NewSession(pkg string) *Session {
+	return &Session{
+		exporter: e,
+		pkg:      pkg,
+		values:   nil,
+		decls:    nil,
+		exprs:    nil,
+		declared: make(map[string]struct{}),
+	}
+}
+
+// Add exports the given value to the variable varName, or the function varName, see WithDeclaration.
+// The name must not be declared by the previous values, see WithIdentifierSanitizing.
+// Values that cannot be exported do not change the Session.
+func (s *Session) Add(varName string, v any) error {
+	e := s.exporter
+	if _, ok := e.config.backend.(goBackend); !ok {
+		return errors.New("Session requires the GO backend") //nolint:goerr113
+	}
+
+	// packages of the new value must not collide with names declared by the previous values
+	pkgs := make([]string, 0)
+	for name := range reservedIdentifiers(v) {
+		pkgs = append(pkgs, name)
+	}
+
+	sort.Strings(pkgs)
+
+	for _, name := range pkgs {
+		if _, ok := s.declared[name]; ok {
+			return newIdentifierError(name, "it collides with the name of an imported package")
+		}
+	}
+
+	values := append(s.values[:len(s.values):len(s.values)], v)
+	t := reflect.TypeOf(v)
+	varCheck := e.varCheck(t, reservedIdentifiers(values))
+	check := func(name string) (string, string) {
+		for _, n := range []string{name, e.copyAccessor(name), e.typedAccessor(name, t)} {
+			if _, ok := s.declared[n]; ok && n != "" {
+				return n, "it is declared twice"
+			}
+		}
+
+		return varCheck(name)
+	}
+
+	varName, err := e.identifier(varName, check)
+	if err != nil {
+		return err
+	}
+
+	names := make(map[string]struct{}, len(s.declared))
+	for n := range s.declared {
+		names[n] = struct{}{}
+	}
+
+	for _, n := range []string{varName, e.copyAccessor(varName), e.typedAccessor(varName, t)} {
+		if n != "" {
+			names[n] = struct{}{}
+		}
+	}
+
+	decls, exprs, err := e.exportDecls(varName, v, names)
+	if err != nil {
+		return err
+	}
+
+	s.values = values
+	s.decls = append(s.decls, decls)
+	s.exprs = append(s.exprs, exprs...)
+	s.declared = names
+
+	return nil
+}
+
+// Declarations returns the sorted names of top-level declarations of the added values: their variables
+// or functions, and helper functions, see WithMapSplitting, WithCopyAccessors and WithTypedAccessors.
+// Callers that compose generated code with other declarations can use them to avoid duplicates.
+// Constants declared by File are not included, since their names are chosen when the file is generated,
+// and they never collide with the returned names.
+func (s *Session) Declarations() []string {
+	names := make([]string, 0, len(s.declared))
+	for name := range s.declared {
+		names = append(names, name)
+	}
+
+	sort.Strings(names)
+
+	return names
+}
+
+// File returns the complete GO file that declares all added values, see ExportFile.
+func (s *Session) File() ([]byte, error) {
+	e := s.exporter
+	if _, ok := e.config.backend.(goBackend); !ok {
+		return nil, errors.New("Session requires the GO backend") //nolint:goerr113
+	}
+
+	pkg, err := e.identifier(s.pkg, pkgCheck)
+	if err != nil {
+		return nil, err
+	}
+
+	imports, err := findImports(s.values, e.config, s.exprs...)
+	if err != nil {
+		return nil, err
+	}
+
+	decls, err := s.intern(strings.Join(s.decls, "\n"))
+	if err != nil {
+		return nil, err
+	}
+
+	return e.renderFile(pkg, imports, decls)
+}
+
+// intern declares constants of long strings that occur more than once in the given declarations,
+// and replaces these strings by the constants.
+func (s *Session) intern(decls string) (string, error) {
+	const prefix = "package p\n\n"
+
+	fset := token.NewFileSet()
+
+	file, err := parser.ParseFile(fset, "", prefix+decls, 0)
+	if err != nil {
+		return "", fmt.Errorf("cannot parse generated file: %w", err)
+	}
+
+	var (
+		tags  = make(map[*ast.BasicLit]struct{})
+		lits  = make(map[string][]*ast.BasicLit)
+		order []string
+	)
+
+	ast.Inspect(file, func(n ast.Node) bool {
+		switch n := n.(type) {
+		case *ast.Field:
+			// tags of struct fields must be literals
+			if n.Tag != nil {
+				tags[n.Tag] = struct{}{}
+			}
+		case *ast.BasicLit:
+			if _, ok := tags[n]; ok || n.Kind != token.STRING {
+				return true
+			}
+
+			val, err := strconv.Unquote(n.Value)
+			if err != nil || len(val) < sessionConstMinLength {
+				return true
+			}
+
+			if _, ok := lits[val]; !ok {
+				order = append(order, val)
+			}
+
+			lits[val] = append(lits[val], n)
+		}
+
+		return true
+	})
+
+	var (
+		consts   strings.Builder
+		replaced = make(map[*ast.BasicLit]string)
+		reserved = reservedIdentifiers(s.values)
+		i        = 0
+	)
+
+	for _, val := range order {
+		if len(lits[val]) < 2 { //nolint:gomnd
+			continue
+		}
+
+		name := ""
+		for name == "" || s.isDeclared(name) || identifierReason(name, reserved) != "" {
+			i++
+			name = "str" + strconv.Itoa(i)
+		}
+
+		consts.WriteString(name + " = " + lits[val][0].Value + "\n")
+
+		for _, l := range lits[val] {
+			replaced[l] = name
+		}
+	}
+
+	if len(replaced) == 0 {
+		return decls, nil
+	}
+
+	// literals are replaced from the end, so offsets of the remaining ones do not change
+	ordered := make([]*ast.BasicLit, 0, len(replaced))
+	for l := range replaced {
+		ordered = append(ordered, l)
+	}
+
+	sort.Slice(ordered, func(i, j int) bool {
+		return ordered[i].Pos() > ordered[j].Pos()
+	})
+
+	src := decls
+
+	for _, l := range ordered {
+		start := fset.Position(l.Pos()).Offset - len(prefix)
+		end := fset.Position(l.End()).Offset - len(prefix)
+		src = src[:start] + replaced[l] + src[end:]
+	}
+
+	return "const (\n" + consts.String() + ")\n\n" + src, nil
+}
+
+func (s *Session) isDeclared(name string) bool {
+	_, ok := s.declared[name]
+
+	return ok
+}
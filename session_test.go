@@ -0,0 +1,178 @@
+// Copyright (c) 2023–present Bartłomiej Krukowski
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is furnished
+// to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package exporter_test
+
+import (
+	goscanner "go/scanner"
+	"testing"
+	textscanner "text/scanner"
+	"time"
+
+	"github.com/gontainer/exporter"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSession(t *testing.T) {
+	t.Parallel()
+
+	t.Run("Shared imports and helpers", func(t *testing.T) {
+		t.Parallel()
+
+		s := exporter.New(exporter.WithMapSplitting("fixture")).NewSession("fixtures")
+		require.NoError(t, s.Add("Timeouts", map[string]time.Duration{"a": time.Second}))
+		require.NoError(t, s.Add("Deadlines", map[string]time.Duration{"a": time.Minute}))
+
+		code, err := s.File()
+		require.NoError(t, err)
+		assert.Equal(
+			t,
+			`// Code generated by github.com/gontainer/exporter. DO NOT EDIT.
+// Format: 2
+
+package fixtures
+
+import (
+	"time"
+)
+
+func fixtureA() time.Duration {
+	return time.Duration(1000000000)
+}
+
+var Timeouts = map[string]time.Duration{"a": fixtureA()}
+
+func fixtureA2() time.Duration {
+	return time.Duration(60000000000)
+}
+
+var Deadlines = map[string]time.Duration{"a": fixtureA2()}
+`,
+			withoutChecksum(t, code),
+		)
+		assert.Equal(t, []string{"Deadlines", "Timeouts", "fixtureA", "fixtureA2"}, s.Declarations())
+	})
+
+	t.Run("Declarations", func(t *testing.T) {
+		t.Parallel()
+
+		s := exporter.New(exporter.WithCopyAccessors("Copy"), exporter.WithTypedAccessors("At")).NewSession("fixtures")
+		assert.Empty(t, s.Declarations())
+
+		require.NoError(t, s.Add("Events", []any{1}))
+		require.NoError(t, s.Add("Users", []string{"mary"}))
+		assert.Equal(t, []string{"Events", "EventsAt", "EventsCopy", "Users", "UsersCopy"}, s.Declarations())
+
+		// values that cannot be exported do not declare anything
+		require.Error(t, s.Add("Channel", make(chan int)))
+		assert.Equal(t, []string{"Events", "EventsAt", "EventsCopy", "Users", "UsersCopy"}, s.Declarations())
+	})
+
+	t.Run("Constants", func(t *testing.T) {
+		t.Parallel()
+
+		s := exporter.NewSession("fixtures")
+		require.NoError(t, s.Add("str1", "short"))
+		require.NoError(t, s.Add("URLs", []string{"https://example.com/avatars/", "short"}))
+		require.NoError(t, s.Add("Avatars", []any{"short", struct {
+			URL string `json:"url"`
+		}{URL: "https://example.com/avatars/"}}))
+
+		code, err := s.File()
+		require.NoError(t, err)
+		assert.Equal(
+			t,
+			`// Code generated by github.com/gontainer/exporter. DO NOT EDIT.
+// Format: 2
+
+package fixtures
+
+const (
+	str2 = "https://example.com/avatars/"
+)
+
+var str1 = "short"
+
+var URLs = []string{str2, "short"}
+
+var Avatars = []interface{}{"short", struct {
+	URL string "json:\"url\""
+}{URL: str2}}
+`,
+			withoutChecksum(t, code),
+		)
+	})
+
+	t.Run("Errors", func(t *testing.T) {
+		t.Parallel()
+
+		s := exporter.NewSession("fixtures")
+		require.NoError(t, s.Add("Timeout", time.Second))
+
+		assert.EqualError(
+			t,
+			s.Add("Timeout", time.Minute),
+			`"Timeout" is not a valid identifier: it is declared twice`,
+		)
+		assert.EqualError(
+			t,
+			s.Add("time", 5),
+			`"time" is not a valid identifier: it collides with the name of an imported package`,
+		)
+		require.NoError(t, s.Add("scanner", 5))
+		assert.EqualError(
+			t,
+			s.Add("Position", textscanner.Position{Line: 1}),
+			`"scanner" is not a valid identifier: it collides with the name of an imported package`,
+		)
+		assert.EqualError(
+			t,
+			s.Add("Function", func() {}),
+			"type func() is not supported",
+		)
+
+		code, err := s.File()
+		require.NoError(t, err)
+		assert.Contains(t, string(code), "var Timeout = time.Duration(1000000000)\n\nvar scanner = int(5)\n")
+	})
+
+	t.Run("Conflicting packages", func(t *testing.T) {
+		t.Parallel()
+
+		s := exporter.NewSession("fixtures")
+		require.NoError(t, s.Add("Error", goscanner.Error{Msg: "unexpected EOF"}))
+		require.NoError(t, s.Add("Position", textscanner.Position{Line: 1}))
+
+		_, err := s.File()
+		assert.EqualError(t, err, `packages ["go/scanner" "text/scanner"] have the same name "scanner"`)
+	})
+
+	t.Run("Sanitizing", func(t *testing.T) {
+		t.Parallel()
+
+		s := exporter.New(exporter.WithIdentifierSanitizing()).NewSession("my-fixtures")
+		require.NoError(t, s.Add("user-ids", []int{1}))
+
+		code, err := s.File()
+		require.NoError(t, err)
+		assert.Contains(t, string(code), "package my_fixtures\n\nvar user_ids = []int{int(1)}\n")
+	})
+}
@@ -0,0 +1,194 @@
+// Copyright (c) 2023–present Bartłomiej Krukowski
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is furnished
+// to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package exporter
+
+import (
+	"fmt"
+	"reflect"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// ExportShapeTest generates a test file that verifies the shape of the variable varName generated by ExportFile,
+// i.e. lengths of slices and maps, and non-nilness of pointers, slices, maps and interfaces, e.g.:
+//
+//	func TestShape_Users(t *testing.T) {
+//		if len(Users) != 2 {
+//			t.Errorf("len(Users) = %d, want 2", len(Users))
+//		}
+//		if Users[0].Address == nil {
+//			t.Errorf("Users[0].Address is nil")
+//		}
+//	}
+//
+// Unlike ExportEqualityTest, the test does not repeat the whole value, so it stays small,
+// and it catches manual edits of the fixture that break assumptions of the code that uses it.
+// Unexported fields and values stored in interfaces are not inspected.
+//
+// See Exporter.ExportShapeTest.
+func ExportShapeTest(pkg string, varName string, v any) ([]byte, error) {
+	return defaultExporter.ExportShapeTest(pkg, varName, v)
+}
+
+// ExportShapeTest generates a test file for the variable generated by Exporter.ExportFile,
+// see the function ExportShapeTest.
+func (e *Exporter) ExportShapeTest(pkg string, varName string, v any) ([]byte, error) {
+	pkg, varName, err := e.validateFile("ExportShapeTest", pkg, varName, v)
+	if err != nil {
+		return nil, err
+	}
+
+	s := shapeCollector{exporter: e, visited: make(map[cacheKey]struct{}), buf: &strings.Builder{}}
+	if err := s.collect(e.reference(varName), reflect.ValueOf(v)); err != nil {
+		return nil, err
+	}
+
+	decl := fmt.Sprintf("func TestShape_%s(t *testing.T) {\n%s}\n", varName, s.buf.String())
+
+	return e.renderFile(pkg, []string{"testing"}, decl)
+}
+
+// shapeCollector writes assertions of the shape of the given value.
+type shapeCollector struct {
+	exporter *Exporter
+	// visited contains pointers that have been inspected already, values may contain cycles
+	visited map[cacheKey]struct{}
+	buf     *strings.Builder
+}
+
+func (s shapeCollector) assertNotNil(expr string) {
+	s.buf.WriteString(fmt.Sprintf("if %s == nil {\nt.Errorf(%q)\n}\n", expr, expr+" is nil"))
+}
+
+func (s shapeCollector) assertLen(expr string, l int) {
+	s.buf.WriteString(fmt.Sprintf(
+		"if len(%s) != %d {\nt.Errorf(%q, len(%s))\n}\n",
+		expr,
+		l,
+		"len("+expr+") = %d, want "+strconv.Itoa(l),
+		expr,
+	))
+}
+
+func (s shapeCollector) collect(expr string, v reflect.Value) error {
+	if !v.IsValid() {
+		return nil
+	}
+
+	//nolint:exhaustive
+	switch v.Kind() {
+	case reflect.Interface:
+		if !v.IsNil() {
+			s.assertNotNil(expr)
+		}
+	case reflect.Ptr:
+		if v.IsNil() {
+			return nil
+		}
+
+		s.assertNotNil(expr)
+
+		k := cacheKey{t: v.Type(), ptr: v.Pointer(), len: 0}
+		if _, ok := s.visited[k]; ok {
+			return nil
+		}
+
+		s.visited[k] = struct{}{}
+
+		if v.Elem().Kind() == reflect.Struct {
+			return s.collect(expr, v.Elem())
+		}
+
+		return s.collect("(*"+expr+")", v.Elem())
+	case reflect.Slice, reflect.Map:
+		if v.IsNil() {
+			return nil
+		}
+
+		if v.Len() == 0 {
+			s.assertNotNil(expr)
+
+			return nil
+		}
+
+		s.assertLen(expr, v.Len())
+
+		if v.Kind() == reflect.Map {
+			return s.collectMap(expr, v)
+		}
+
+		return s.collectElems(expr, v)
+	case reflect.Array:
+		return s.collectElems(expr, v)
+	case reflect.Struct:
+		for i := 0; i < v.NumField(); i++ {
+			if f := v.Type().Field(i); f.PkgPath == "" {
+				if err := s.collect(expr+"."+f.Name, v.Field(i)); err != nil {
+					return err
+				}
+			}
+		}
+	}
+
+	return nil
+}
+
+func (s shapeCollector) collectElems(expr string, v reflect.Value) error {
+	for i := 0; i < v.Len(); i++ {
+		if err := s.collect(expr+"["+strconv.Itoa(i)+"]", v.Index(i)); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (s shapeCollector) collectMap(expr string, v reflect.Value) error {
+	type entry struct {
+		key   string
+		value reflect.Value
+	}
+
+	entries := make([]entry, 0, v.Len())
+	iter := v.MapRange()
+
+	for iter.Next() {
+		key, err := s.exporter.Export(iter.Key().Interface())
+		if err != nil {
+			return newPathError(typeName(v.Type()), KeyStep(fmt.Sprintf("%v", iter.Key())), err)
+		}
+
+		entries = append(entries, entry{key: key, value: iter.Value()})
+	}
+
+	sort.Slice(entries, func(i, j int) bool {
+		return entries[i].key < entries[j].key
+	})
+
+	for _, en := range entries {
+		if err := s.collect(expr+"["+en.key+"]", en.value); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,132 @@
+// Copyright (c) 2023–present Bartłomiej Krukowski
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is furnished
+// to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package exporter_test
+
+import (
+	"testing"
+
+	"github.com/gontainer/exporter"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type shapeUser struct {
+	Name    string
+	Address *StructServer
+	Tags    []string
+	Meta    map[string][]int
+	Extra   any
+	friends []string
+}
+
+func TestExportShapeTest(t *testing.T) {
+	t.Parallel()
+
+	t.Run("OK", func(t *testing.T) {
+		t.Parallel()
+
+		port := 80
+		input := []shapeUser{
+			{
+				Name:    "Mary",
+				Address: &StructServer{Host: "localhost"},
+				Tags:    []string{},
+				Meta:    map[string][]int{"b": {1}, "a": nil},
+				Extra:   &port,
+				friends: []string{"John"},
+			},
+			{Name: "John"},
+		}
+
+		code, err := exporter.ExportShapeTest("fixtures", "Users", input)
+		require.NoError(t, err)
+		assert.Equal(
+			t,
+			`// Code generated by github.com/gontainer/exporter. DO NOT EDIT.
+// Format: 2
+
+package fixtures
+
+import (
+	"testing"
+)
+
+func TestShape_Users(t *testing.T) {
+	if len(Users) != 2 {
+		t.Errorf("len(Users) = %d, want 2", len(Users))
+	}
+	if Users[0].Address == nil {
+		t.Errorf("Users[0].Address is nil")
+	}
+	if Users[0].Tags == nil {
+		t.Errorf("Users[0].Tags is nil")
+	}
+	if len(Users[0].Meta) != 2 {
+		t.Errorf("len(Users[0].Meta) = %d, want 2", len(Users[0].Meta))
+	}
+	if len(Users[0].Meta["b"]) != 1 {
+		t.Errorf("len(Users[0].Meta[\"b\"]) = %d, want 1", len(Users[0].Meta["b"]))
+	}
+	if Users[0].Extra == nil {
+		t.Errorf("Users[0].Extra is nil")
+	}
+}
+`,
+			withoutChecksum(t, code),
+		)
+	})
+
+	t.Run("Pointers", func(t *testing.T) {
+		t.Parallel()
+
+		type node struct {
+			Next *node
+		}
+
+		n := &node{}
+		n.Next = n
+		s := &[]int{1}
+
+		code, err := exporter.ExportShapeTest("fixtures", "Node", n)
+		require.NoError(t, err)
+		assert.Contains(t, string(code), "if Node == nil {")
+		assert.Contains(t, string(code), "if Node.Next == nil {")
+		assert.NotContains(t, string(code), "Node.Next.Next")
+
+		code, err = exporter.ExportShapeTest("fixtures", "Numbers", s)
+		require.NoError(t, err)
+		assert.Contains(t, string(code), "if len((*Numbers)) != 1 {")
+	})
+
+	t.Run("Errors", func(t *testing.T) {
+		t.Parallel()
+
+		_, err := exporter.ExportShapeTest("fixtures", "my-var", 5)
+		assert.EqualError(t, err, `"my-var" is not a valid identifier`)
+
+		_, err = exporter.New(exporter.WithBackend(exporter.JSONBackend())).ExportShapeTest("fixtures", "Var", 5)
+		assert.EqualError(t, err, `ExportShapeTest requires the GO backend`)
+
+		_, err = exporter.ExportShapeTest("fixtures", "Var", map[chan int]int{make(chan int): 1})
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), `type chan int is not supported`)
+	})
+}
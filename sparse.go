@@ -0,0 +1,67 @@
+// Copyright (c) 2023–present Bartłomiej Krukowski
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is furnished
+// to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package exporter
+
+import (
+	"reflect"
+)
+
+// WithSparse drops map entries with zero values (including zero values stored in interfaces)
+// and nil elements at the end of slices, e.g.:
+//
+//	map[string]int{"a": 0, "b": 1} // map[string]int{"b": int(1)}
+//	[]any{nil, 1, nil, nil}         // []interface{}{nil, int(1)}
+//
+// It is useful for configuration overlays, where zero values mean "use the default value".
+// Nil elements followed by non-nil elements cannot be dropped, since that would change indices of the latter.
+func WithSparse() Option {
+	return func(c *config) {
+		c.sparse = true
+	}
+}
+
+// trimNilTail removes nil elements at the end of the given slice.
+func trimNilTail(v reflect.Value) reflect.Value {
+	n := v.Len()
+	for n > 0 && isNil(v.Index(n-1)) {
+		n--
+	}
+
+	return v.Slice(0, n)
+}
+
+// isZero works like reflect.Value.IsZero, but it checks dynamic values of interfaces.
+func isZero(v reflect.Value) bool {
+	if v.Kind() == reflect.Interface && !v.IsNil() {
+		return v.Elem().IsZero()
+	}
+
+	return v.IsZero()
+}
+
+func isNil(v reflect.Value) bool {
+	switch v.Kind() { //nolint:exhaustive
+	case reflect.Ptr, reflect.Interface, reflect.Map, reflect.Slice, reflect.Func, reflect.Chan:
+		return v.IsNil()
+	}
+
+	return false
+}
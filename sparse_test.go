@@ -0,0 +1,90 @@
+// Copyright (c) 2023–present Bartłomiej Krukowski
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is furnished
+// to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package exporter_test
+
+import (
+	"testing"
+
+	"github.com/gontainer/exporter"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestWithSparse(t *testing.T) {
+	t.Parallel()
+
+	scenarios := []struct {
+		name   string
+		input  any
+		output string
+	}{
+		{
+			name:   "Map",
+			input:  map[string]int{"a": 0, "b": 1},
+			output: `map[string]int{"b": int(1)}`,
+		},
+		{
+			name:   "Map of zero values",
+			input:  map[string]any{"a": nil, "b": "", "c": []int(nil)},
+			output: `map[string]interface{}{}`,
+		},
+		{
+			name:   "Slice",
+			input:  []any{nil, 1, nil, nil},
+			output: `[]interface{}{nil, int(1)}`,
+		},
+		{
+			name:   "Slice of nils",
+			input:  [][]int{nil, nil},
+			output: `make([][]int, 0)`,
+		},
+		{
+			name:   "Zero values that are not nil",
+			input:  []int{1, 0, 0},
+			output: `[]int{int(1), int(0), int(0)}`,
+		},
+		{
+			name:   "Nil slice",
+			input:  []any(nil),
+			output: `([]interface{})(nil)`,
+		},
+		{
+			name:   "Array",
+			input:  [2]any{1, nil},
+			output: `[2]interface{}{int(1), nil}`,
+		},
+		{
+			name:   "Nested",
+			input:  []map[string]any{{"a": 1, "b": []any{2, nil}, "c": nil}, nil},
+			output: `[]map[string]interface{}{map[string]interface{}{"a": int(1), "b": []interface{}{int(2)}}}`,
+		},
+	}
+
+	for _, s := range scenarios {
+		s := s
+
+		t.Run(s.name, func(t *testing.T) {
+			t.Parallel()
+
+			e := exporter.New(exporter.WithSparse())
+			assert.Equal(t, s.output, e.MustExport(s.input))
+		})
+	}
+}
@@ -0,0 +1,98 @@
+// Copyright (c) 2023–present Bartłomiej Krukowski
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is furnished
+// to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+// Package spew provides an API similar to github.com/davecgh/go-spew, but its output is a valid GO code,
+// so dumped values can be copied to tests as fixtures. Each value is exported in a separate line:
+//
+//	spew.Dump([]int{1, 2}, "hello")
+//	// []int{int(1), int(2)}
+//	// "hello"
+//
+// Values that cannot be exported are dumped as comments, see exporter.Sdump.
+package spew
+
+import (
+	"io"
+	"os"
+	"strings"
+
+	"github.com/gontainer/exporter"
+)
+
+// ConfigState configures the functions Dump, Fdump and Sdump.
+//
+// Fields SortKeys, DisablePointerAddresses and DisableCapacities exist for compatibility with go-spew only.
+// Keys of maps are always sorted, and the output never contains addresses nor capacities,
+// since they cannot be reproduced.
+type ConfigState struct {
+	SortKeys                bool
+	DisablePointerAddresses bool
+	DisableCapacities       bool
+
+	// Options configure the underlying exporter, e.g. exporter.WithColors.
+	Options []exporter.Option
+}
+
+// Config is the configuration used by the package-level functions.
+//
+//nolint:gochecknoglobals
+var Config = ConfigState{
+	SortKeys:                true,
+	DisablePointerAddresses: true,
+	DisableCapacities:       true,
+	Options:                 nil,
+}
+
+// Dump writes the given values to the standard output.
+func (c *ConfigState) Dump(a ...interface{}) {
+	c.Fdump(os.Stdout, a...)
+}
+
+// Fdump writes the given values to the given writer.
+func (c *ConfigState) Fdump(w io.Writer, a ...interface{}) {
+	_, _ = io.WriteString(w, c.Sdump(a...))
+}
+
+// Sdump returns the given values as a string, each value is followed by a new line.
+func (c *ConfigState) Sdump(a ...interface{}) string {
+	e := exporter.New(c.Options...)
+	buf := strings.Builder{}
+
+	for _, v := range a {
+		buf.WriteString(e.Sdump(v) + "\n")
+	}
+
+	return buf.String()
+}
+
+// Dump writes the given values to the standard output, see ConfigState.Dump.
+func Dump(a ...interface{}) {
+	Config.Dump(a...)
+}
+
+// Fdump writes the given values to the given writer, see ConfigState.Fdump.
+func Fdump(w io.Writer, a ...interface{}) {
+	Config.Fdump(w, a...)
+}
+
+// Sdump returns the given values as a string, see ConfigState.Sdump.
+func Sdump(a ...interface{}) string {
+	return Config.Sdump(a...)
+}
@@ -0,0 +1,51 @@
+// Copyright (c) 2023–present Bartłomiej Krukowski
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is furnished
+// to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package spew_test
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/gontainer/exporter"
+	"github.com/gontainer/exporter/spew"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSdump(t *testing.T) {
+	t.Parallel()
+
+	assert.Equal(
+		t,
+		"map[string]int{\"a\": int(1), \"b\": int(2)}\n\"hello\"\n/* cannot export chan int: type chan int is not supported */\n",
+		spew.Sdump(map[string]int{"b": 2, "a": 1}, "hello", make(chan int)),
+	)
+	assert.Empty(t, spew.Sdump())
+}
+
+func TestConfigState_Fdump(t *testing.T) {
+	t.Parallel()
+
+	cfg := spew.ConfigState{Options: []exporter.Option{exporter.WithFloatPrecision(1)}} //nolint:exhaustruct
+	buf := bytes.NewBuffer(nil)
+	cfg.Fdump(buf, 3.14159, []float32{2.71})
+	spew.Fdump(buf, 3.14159)
+	assert.Equal(t, "float64(3.1)\n[]float32{float32(2.7)}\nfloat64(3.14159)\n", buf.String())
+}
@@ -0,0 +1,141 @@
+// Copyright (c) 2023–present Bartłomiej Krukowski
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is furnished
+// to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package exporter
+
+import (
+	"fmt"
+	"go/token"
+	"reflect"
+	"sort"
+	"strconv"
+	"strings"
+	"unicode"
+)
+
+// WithMapSplitting makes ExportFile split top-level maps with string keys into small functions,
+// one function per key, e.g.:
+//
+//	func fixtureOrders() []Order { return []Order{...} }
+//
+//	func fixtureUsers() []User { return []User{...} }
+//
+//	var Fixtures = map[string]interface{}{"orders": fixtureOrders(), "users": fixtureUsers()}
+//
+// Names of functions consist of the given prefix and the key. It keeps functions within the limits of the compiler
+// and makes huge fixtures readable. An empty prefix disables splitting, it is the default behavior.
+func WithMapSplitting(prefix string) Option {
+	return func(c *config) {
+		c.splitPrefix = prefix
+	}
+}
+
+func isSplittable(v reflect.Value) bool {
+	return v.Kind() == reflect.Map && v.Type().Key().Kind() == reflect.String && !v.IsNil()
+}
+
+// exportSplitMap exports the given map to the variable of the given name as a set of declarations,
+// see WithMapSplitting. It returns the declarations, and the exported expressions.
+// Names of functions differ from the given names, and they are added to them.
+func (e *Exporter) exportSplitMap(name string, v reflect.Value, names map[string]struct{}) (string, []string, error) {
+	if !token.IsIdentifier(e.config.splitPrefix) {
+		return "", nil, fmt.Errorf("%q is not a valid identifier", e.config.splitPrefix) //nolint:goerr113
+	}
+
+	t := v.Type()
+
+	keys := make([]reflect.Value, 0, v.Len())
+	iter := v.MapRange()
+
+	for iter.Next() {
+		keys = append(keys, iter.Key())
+	}
+
+	sort.Slice(keys, func(i, j int) bool {
+		return keys[i].String() < keys[j].String()
+	})
+
+	var (
+		decls = strings.Builder{}
+		exprs = make([]string, 0, len(keys)+1)
+		parts = make([]string, 0, len(keys))
+	)
+
+	for _, k := range keys {
+		code, err := e.Export(v.MapIndex(k).Interface())
+		if err != nil {
+			return "", nil, newPathError(typeName(t), KeyStep(strconv.Quote(k.String())), err)
+		}
+
+		key, err := e.Export(k.Interface())
+		if err != nil {
+			return "", nil, fmt.Errorf("cannot export key of (%s): %w", typeName(t), err)
+		}
+
+		fn := splitFuncName(e.config.splitPrefix, k.String(), names)
+		exprs = append(exprs, code)
+		parts = append(parts, key+": "+fn+"()")
+
+		decls.WriteString(fmt.Sprintf("func %s() %s {\nreturn %s\n}\n\n", fn, typeName(t.Elem()), code))
+	}
+
+	aggregate := typeName(t) + "{" + strings.Join(parts, ", ") + "}"
+	exprs = append(exprs, aggregate)
+	decls.WriteString(e.declare(name, t, aggregate))
+
+	return decls.String(), exprs, nil
+}
+
+// splitFuncName returns a unique name of the function that returns the value of the given key,
+// e.g. "fixtureUserOrders" for the prefix "fixture" and the key "user-orders".
+func splitFuncName(prefix string, key string, names map[string]struct{}) string {
+	buf := strings.Builder{}
+	buf.WriteString(prefix)
+
+	upper := true
+
+	for _, r := range key {
+		if !unicode.IsLetter(r) && !unicode.IsDigit(r) {
+			upper = true
+
+			continue
+		}
+
+		if upper {
+			r = unicode.ToUpper(r)
+			upper = false
+		}
+
+		buf.WriteRune(r)
+	}
+
+	name := buf.String()
+	for i := 2; ; i++ {
+		if _, ok := names[name]; !ok {
+			break
+		}
+
+		name = buf.String() + strconv.Itoa(i)
+	}
+
+	names[name] = struct{}{}
+
+	return name
+}
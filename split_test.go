@@ -0,0 +1,94 @@
+// Copyright (c) 2023–present Bartłomiej Krukowski
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is furnished
+// to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package exporter_test
+
+import (
+	"testing"
+
+	"github.com/gontainer/exporter"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestWithMapSplitting(t *testing.T) {
+	t.Parallel()
+
+	t.Run("OK", func(t *testing.T) {
+		t.Parallel()
+
+		e := exporter.New(exporter.WithMapSplitting("fixture"))
+		code, err := e.ExportFile("fixtures", "Fixtures", map[string]any{
+			"users":       []StructServer{{Host: "localhost"}},
+			"user-orders": []int{1, 2},
+			"User orders": nil,
+		})
+		require.NoError(t, err)
+		assert.Equal(
+			t,
+			`// Code generated by github.com/gontainer/exporter. DO NOT EDIT.
+// Format: 2
+
+package fixtures
+
+import (
+	"github.com/gontainer/exporter_test"
+)
+
+func fixtureUserOrders() interface{} {
+	return nil
+}
+
+func fixtureUserOrders2() interface{} {
+	return []int{int(1), int(2)}
+}
+
+func fixtureUsers() interface{} {
+	return []exporter_test.StructServer{exporter_test.StructServer{Host: "localhost"}}
+}
+
+var Fixtures = map[string]interface{}{"User orders": fixtureUserOrders(), "user-orders": fixtureUserOrders2(), `+
+				`"users": fixtureUsers()}
+`,
+			withoutChecksum(t, code),
+		)
+	})
+
+	t.Run("Other values", func(t *testing.T) {
+		t.Parallel()
+
+		e := exporter.New(exporter.WithMapSplitting("fixture"))
+		code, err := e.ExportFile("fixtures", "Fixtures", map[int]string{1: "one"})
+		require.NoError(t, err)
+		assert.Contains(t, string(code), `var Fixtures = map[int]string{int(1): "one"}`)
+	})
+
+	t.Run("Errors", func(t *testing.T) {
+		t.Parallel()
+
+		_, err := exporter.New(exporter.WithMapSplitting("my-fixture")).
+			ExportFile("fixtures", "Fixtures", map[string]int{"a": 1})
+		assert.EqualError(t, err, `"my-fixture" is not a valid identifier`)
+
+		_, err = exporter.New(exporter.WithMapSplitting("fixture")).
+			ExportFile("fixtures", "Fixtures", map[string]any{"a": make(chan int)})
+		assert.EqualError(t, err, `cannot export (map[string]interface{})["a"]: type chan int is not supported`)
+	})
+}
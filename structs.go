@@ -0,0 +1,217 @@
+// Copyright (c) 2023–present Bartłomiej Krukowski
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is furnished
+// to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package exporter
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+	"strings"
+	"sync"
+)
+
+// WithFieldFilter sets the function that decides whether the given field of a struct is exported.
+// Fields for which the function returns false are omitted, e.g. the following filter omits unexported fields:
+//
+//	exporter.WithFieldFilter(func(f reflect.StructField) bool {
+//		return f.PkgPath == ""
+//	})
+func WithFieldFilter(fn func(field reflect.StructField) bool) Option {
+	return func(c *config) {
+		c.fieldFilter = fn
+	}
+}
+
+// WithKeyedArrays adds index keys to elements of arrays of structs, e.g.:
+//
+//	[2]pkg.User{0: pkg.User{Name: "Mary"}, 1: pkg.User{Name: "John"}}
+//
+// Keys make it easier to navigate large fixed-size fixtures, and to reorder their elements safely.
+// Arrays built from chunks are not keyed, see WithLiteralLimits. The JSON backend ignores this option.
+func WithKeyedArrays() Option {
+	return func(c *config) {
+		c.keyedArrays = true
+	}
+}
+
+// ErrUndeclarableType is returned when the exported value has an unnamed struct type with unexported fields
+// of a package other than the package of the generated code, see WithPackagePath. Such a type cannot be declared
+// by a type literal outside its package, e.g. a type created by reflect.StructOf with the field PkgPath set.
+//
+//nolint:gochecknoglobals
+var ErrUndeclarableType = errors.New("type cannot be declared in the package of the generated code")
+
+// syncTypes contains types that must not be copied after first use.
+// Zero-value fields of those types are omitted like any other zero field,
+// non-zero ones cannot be reproduced by a literal.
+//
+//nolint:gochecknoglobals
+var syncTypes = map[reflect.Type]struct{}{
+	reflect.TypeOf(sync.Mutex{}):     {}, //nolint:exhaustruct
+	reflect.TypeOf(sync.RWMutex{}):   {}, //nolint:exhaustruct
+	reflect.TypeOf(sync.Once{}):      {}, //nolint:exhaustruct
+	reflect.TypeOf(sync.WaitGroup{}): {}, //nolint:exhaustruct
+}
+
+// structExporter exports structs as keyed composite literals, e.g.:
+//
+//	pkg.Config{Host: "localhost", Port: int(80)}
+//
+// Fields with zero values are omitted. Non-zero unexported fields cannot be exported.
+// Non-zero fields of types from the package sync (e.g. [sync.Mutex]) cannot be exported either.
+// Values of fields of interface types with methods are converted explicitly to the type of the field, e.g.:
+//
+//	pkg.Config{Reader: io.Reader(...)}
+//
+// Tags "export" of fields control how the fields are exported, see the type fieldTag.
+// Fields rejected by the filter are omitted, see WithFieldFilter.
+//
+// Unnamed struct types, including types created by reflect.StructOf, are exported as type literals, e.g.:
+//
+//	struct { ID int "json:\"id\"" }{ID: int(1)}
+//
+// Unexported fields of such types must belong to the package of the generated code, see ErrUndeclarableType.
+type structExporter struct {
+	exporter exporter
+	backend  Backend
+	// integralFloats is the type of integers that replace integral floats, see WithIntegralFloats
+	integralFloats reflect.Type
+	filter         func(reflect.StructField) bool
+	elide          scalarElision
+	// packagePath is the import path of the package of the generated code, see WithPackagePath
+	packagePath string
+	pseudonyms  *pseudonymizer
+}
+
+func (s structExporter) export(v any) (string, error) {
+	val := reflect.ValueOf(v)
+	t := val.Type()
+
+	if err := s.checkDeclarable(t); err != nil {
+		return "", err
+	}
+
+	names := make([]string, 0, t.NumField())
+	values := make([]string, 0, t.NumField())
+
+	for i := 0; i < t.NumField(); i++ {
+		f := t.Field(i)
+		fv := val.Field(i)
+
+		if fv.IsZero() || (s.filter != nil && !s.filter(f)) {
+			continue
+		}
+
+		ft, err := parseFieldTag(f.Tag)
+		if err != nil {
+			return "", newPathError(typeName(t), FieldStep(f.Name), err)
+		}
+
+		if ft.skip || (ft.redact && fv.Kind() != reflect.String) {
+			continue
+		}
+
+		name := f.Name
+		if _, ok := s.backend.(jsonBackend); ok {
+			if name = jsonFieldName(f); name == "" {
+				continue
+			}
+		}
+
+		if _, ok := syncTypes[f.Type]; ok {
+			return "", newPathError(
+				typeName(t),
+				FieldStep(f.Name),
+				fmt.Errorf("%s is in use and cannot be copied", typeName(f.Type)), //nolint:goerr113
+			)
+		}
+
+		if f.PkgPath != "" {
+			return "", newPathError(typeName(t), FieldStep(f.Name), errors.New("unexported field is not zero")) //nolint:goerr113
+		}
+
+		code, ok, err := s.exportField(ft, fv)
+		if err != nil {
+			return "", newPathError(typeName(t), FieldStep(f.Name), err)
+		}
+
+		if !ok {
+			code, err = s.exporter.export(integralFloat(s.integralFloats, f.Type, fv.Interface()))
+			if err != nil {
+				return "", newPathError(typeName(t), FieldStep(f.Name), err)
+			}
+
+			if f.Type.Kind() == reflect.Interface && f.Type.NumMethod() > 0 {
+				code = s.backend.renderConversion(f.Type, code)
+			}
+		}
+
+		code = elideScalar(s.elide, f.Type, code)
+
+		names = append(names, name)
+		values = append(values, code)
+	}
+
+	return s.backend.renderStruct(t, names, values), nil
+}
+
+// jsonFieldName returns the name of the given field in JSON objects, or an empty string
+// if the field is skipped, like encoding/json does.
+func jsonFieldName(f reflect.StructField) string {
+	tag := f.Tag.Get("json")
+	if tag == "-" {
+		return ""
+	}
+
+	if name := strings.Split(tag, ",")[0]; name != "" {
+		return name
+	}
+
+	return f.Name
+}
+
+func (structExporter) supports(v any) bool {
+	t := reflect.TypeOf(v)
+
+	return t != nil && t.Kind() == reflect.Struct
+}
+
+// checkDeclarable verifies whether the given unnamed struct type can be declared in the package of
+// the generated code. It is possible to verify only when the package is known, see WithPackagePath.
+func (s structExporter) checkDeclarable(t reflect.Type) error {
+	if _, ok := s.backend.(goBackend); !ok || t.Name() != "" || s.packagePath == "" {
+		return nil
+	}
+
+	for i := 0; i < t.NumField(); i++ {
+		if f := t.Field(i); f.PkgPath != "" && f.PkgPath != s.packagePath {
+			return fmt.Errorf(
+				"%w: the unexported field %s of (%s) belongs to the package %q",
+				ErrUndeclarableType,
+				f.Name,
+				typeName(t),
+				f.PkgPath,
+			)
+		}
+	}
+
+	return nil
+}
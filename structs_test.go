@@ -0,0 +1,310 @@
+// Copyright (c) 2023–present Bartłomiej Krukowski
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is furnished
+// to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package exporter_test
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+	"sync"
+	"testing"
+
+	"github.com/gontainer/exporter"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type (
+	// StructServer is exported, since it is embedded in structConfig.
+	StructServer struct {
+		Host string
+		Port int
+	}
+
+	structConfig struct {
+		StructServer
+		Name     string
+		Backup   StructServer
+		Tags     []string
+		Extra    any
+		Stringer fmt.Stringer
+		timeout  int
+	}
+
+	structGreeter struct {
+		Name string
+	}
+)
+
+func (g structGreeter) String() string {
+	return "hello " + g.Name
+}
+
+//nolint:testifylint
+func TestExport_struct(t *testing.T) {
+	t.Parallel()
+
+	//nolint:exhaustruct
+	scenarios := []struct {
+		name    string
+		input   any
+		output  string
+		json    string
+		error   string
+		options []exporter.Option
+	}{
+		{
+			name:   "Zero value",
+			input:  StructServer{},
+			output: `exporter_test.StructServer{}`,
+			json:   `{}`,
+		},
+		{
+			name:   "Fields",
+			input:  StructServer{Host: "localhost", Port: 8080},
+			output: `exporter_test.StructServer{Host: "localhost", Port: int(8080)}`,
+			json:   `{"Host":"localhost","Port":8080}`,
+		},
+		{
+			name: "Nested structs",
+			input: structConfig{
+				StructServer: StructServer{Host: "localhost"},
+				Backup:       StructServer{Port: 8081},
+				Tags:         []string{},
+			},
+			output: `exporter_test.structConfig{StructServer: exporter_test.StructServer{Host: "localhost"}, ` +
+				`Backup: exporter_test.StructServer{Port: int(8081)}, Tags: make([]string, 0)}`,
+			json: `{"StructServer":{"Host":"localhost"},"Backup":{"Port":8081},"Tags":[]}`,
+		},
+		{
+			name:  "Interface fields",
+			input: structConfig{Extra: []any{1}, Stringer: structGreeter{Name: "Mary"}},
+			output: `exporter_test.structConfig{Extra: []interface{}{int(1)}, ` +
+				`Stringer: fmt.Stringer(exporter_test.structGreeter{Name: "Mary"})}`,
+			json: `{"Extra":[1],"Stringer":{"Name":"Mary"}}`,
+		},
+		{
+			name:   "Anonymous struct",
+			input:  []struct{ A, B int }{{A: 1}},
+			output: `[]struct { A int; B int }{struct { A int; B int }{A: int(1)}}`,
+			json:   `[{"A":1}]`,
+		},
+		{
+			name:   "Slice of named structs",
+			input:  []StructServer{{Port: 80}},
+			output: `[]exporter_test.StructServer{exporter_test.StructServer{Port: int(80)}}`,
+			json:   `[{"Port":80}]`,
+		},
+		{
+			name:  "Array of structs in interface",
+			input: structConfig{Extra: [2]StructServer{{Host: "a"}}},
+			output: `exporter_test.structConfig{Extra: [2]exporter_test.StructServer{exporter_test.StructServer{Host: "a"}, ` +
+				`exporter_test.StructServer{}}}`,
+			json: `{"Extra":[{"Host":"a"},{}]}`,
+		},
+		{
+			name:  "Unexported field",
+			input: structConfig{timeout: 5},
+			error: `cannot export (exporter_test.structConfig).timeout: unexported field is not zero`,
+		},
+		{
+			name:  "Unsupported field",
+			input: structConfig{Extra: make(chan int)},
+			error: `cannot export (exporter_test.structConfig).Extra: type chan int is not supported`,
+		},
+	}
+
+	for _, s := range scenarios {
+		s := s
+
+		t.Run(s.name, func(t *testing.T) {
+			t.Parallel()
+
+			output, err := exporter.Export(s.input)
+			if s.error != "" {
+				assert.EqualError(t, err, s.error)
+				assert.Empty(t, output)
+
+				return
+			}
+
+			require.NoError(t, err)
+			assert.Equal(t, s.output, output)
+
+			output, err = exporter.New(exporter.WithBackend(exporter.JSONBackend())).Export(s.input)
+			require.NoError(t, err)
+			assert.Equal(t, s.json, output)
+		})
+	}
+}
+
+type structCounter struct {
+	Mu    sync.Mutex
+	once  sync.Once
+	Value int
+}
+
+// structCounterValue copies the given struct without triggering "copylocks" warnings.
+func structCounterValue(c *structCounter) any {
+	return reflect.ValueOf(c).Elem().Interface()
+}
+
+//nolint:testifylint
+func TestExport_structSync(t *testing.T) {
+	t.Parallel()
+
+	t.Run("Zero values", func(t *testing.T) {
+		t.Parallel()
+
+		c := &structCounter{Value: 5} //nolint:exhaustruct
+		assert.Equal(t, `exporter_test.structCounter{Value: int(5)}`, exporter.MustExport(structCounterValue(c)))
+	})
+
+	t.Run("Locked mutex", func(t *testing.T) {
+		t.Parallel()
+
+		c := &structCounter{} //nolint:exhaustruct
+		c.Mu.Lock()
+		defer c.Mu.Unlock()
+
+		_, err := exporter.Export(structCounterValue(c))
+		assert.EqualError(
+			t,
+			err,
+			`cannot export (exporter_test.structCounter).Mu: sync.Mutex is in use and cannot be copied`,
+		)
+	})
+
+	t.Run("Used once", func(t *testing.T) {
+		t.Parallel()
+
+		c := &structCounter{} //nolint:exhaustruct
+		c.once.Do(func() {})
+
+		_, err := exporter.Export(structCounterValue(c))
+		assert.EqualError(
+			t,
+			err,
+			`cannot export (exporter_test.structCounter).once: sync.Once is in use and cannot be copied`,
+		)
+	})
+}
+
+func TestWithFieldFilter(t *testing.T) {
+	t.Parallel()
+
+	e := exporter.New(exporter.WithFieldFilter(func(f reflect.StructField) bool {
+		return f.PkgPath == "" && f.Name != "Port"
+	}))
+	assert.Equal(
+		t,
+		`exporter_test.structConfig{StructServer: exporter_test.StructServer{Host: "localhost"}, Name: "config"}`,
+		e.MustExport(structConfig{
+			StructServer: StructServer{Host: "localhost", Port: 80},
+			Name:         "config",
+			timeout:      5,
+		}),
+	)
+}
+
+func TestWithKeyedArrays(t *testing.T) {
+	t.Parallel()
+
+	input := [2]StructServer{{Host: "a"}, {}}
+
+	assert.Equal(
+		t,
+		`[2]exporter_test.StructServer{exporter_test.StructServer{Host: "a"}, exporter_test.StructServer{}}`,
+		exporter.MustExport(input),
+	)
+
+	e := exporter.New(exporter.WithKeyedArrays(), exporter.WithIndexComments(2))
+	assert.Equal(
+		t,
+		`[2]exporter_test.StructServer{0: exporter_test.StructServer{Host: "a"} /* [0] */, `+
+			`1: exporter_test.StructServer{} /* [1] */}`,
+		e.MustExport(input),
+	)
+
+	// slices and arrays of other types are not keyed
+	assert.Equal(
+		t,
+		`[]interface{}{[]exporter_test.StructServer{exporter_test.StructServer{}}, [1]int{int(1)}}`,
+		exporter.New(exporter.WithKeyedArrays()).MustExport([]any{[]StructServer{{}}, [1]int{1}}),
+	)
+
+	// chunks are not keyed
+	e = exporter.New(exporter.WithKeyedArrays(), exporter.WithLiteralLimits(1, 0))
+	assert.Equal(
+		t,
+		`func() [2]exporter_test.StructServer { var v [2]exporter_test.StructServer; `+
+			`copy(v[0:], []exporter_test.StructServer{exporter_test.StructServer{Host: "a"}}); `+
+			`copy(v[1:], []exporter_test.StructServer{exporter_test.StructServer{}}); return v }()`,
+		e.MustExport(input),
+	)
+}
+
+func TestExport_structOf(t *testing.T) {
+	t.Parallel()
+
+	st := reflect.StructOf([]reflect.StructField{
+		{Name: "ID", Type: reflect.TypeOf(0), Tag: `json:"id"`},
+		{Name: "Server", Type: reflect.TypeOf(StructServer{}), Anonymous: true},
+	})
+	v := reflect.New(st).Elem()
+	v.Field(0).SetInt(1)
+
+	items := reflect.New(reflect.ArrayOf(2, st)).Elem()
+	items.Index(0).Set(v)
+
+	assert.Equal(
+		t,
+		`[2]struct { ID int "json:\"id\""; exporter_test.StructServer }{`+
+			`struct { ID int "json:\"id\""; exporter_test.StructServer }{ID: int(1)}, `+
+			`struct { ID int "json:\"id\""; exporter_test.StructServer }{}}`,
+		exporter.MustExport(items.Interface()),
+	)
+
+	foreign := reflect.StructOf([]reflect.StructField{
+		{Name: "Name", Type: reflect.TypeOf("")},
+		{Name: "id", Type: reflect.TypeOf(0), PkgPath: "example.com/model"},
+	})
+
+	// the package of the generated code is unknown
+	assert.Equal(
+		t,
+		`struct { Name string; id int }{}`,
+		exporter.MustExport(reflect.Zero(foreign).Interface()),
+	)
+
+	e := exporter.New(exporter.WithPackagePath("example.com/fixtures"))
+	_, err := e.Export([]any{reflect.Zero(foreign).Interface()})
+	require.True(t, errors.Is(err, exporter.ErrUndeclarableType))
+	assert.EqualError(
+		t,
+		err,
+		`cannot export ([]interface{})[0]: type cannot be declared in the package of the generated code: `+
+			`the unexported field id of (struct { Name string; id int }) belongs to the package "example.com/model"`,
+	)
+
+	e = exporter.New(exporter.WithPackagePath("example.com/model"))
+	assert.Equal(t, `struct { Name string; id int }{}`, e.MustExport(reflect.Zero(foreign).Interface()))
+}
@@ -0,0 +1,51 @@
+// Copyright (c) 2023–present Bartłomiej Krukowski
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is furnished
+// to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package exporter
+
+import (
+	"reflect"
+	"strconv"
+)
+
+//nolint:gochecknoglobals
+var structTagType = reflect.TypeOf(reflect.StructTag(""))
+
+// structTagExporter exports reflect.StructTag as a backquoted string, e.g.:
+//
+//	reflect.StructTag(`json:"name,omitempty"`)
+//
+// Tags that cannot be backquoted are quoted as usual.
+type structTagExporter struct{}
+
+func (structTagExporter) export(v any) (string, error) {
+	tag := string(v.(reflect.StructTag)) //nolint:forcetypeassert
+
+	code := strconv.Quote(tag)
+	if canBackquote(tag) {
+		code = "`" + tag + "`"
+	}
+
+	return "reflect.StructTag(" + code + ")", nil
+}
+
+func (structTagExporter) supports(v any) bool {
+	return reflect.TypeOf(v) == structTagType
+}
@@ -0,0 +1,77 @@
+// Copyright (c) 2023–present Bartłomiej Krukowski
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is furnished
+// to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package exporter_test
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/gontainer/exporter"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestExport_structTag(t *testing.T) {
+	t.Parallel()
+
+	scenarios := []struct {
+		name   string
+		input  any
+		output string
+	}{
+		{
+			name:   "Empty tag",
+			input:  reflect.StructTag(""),
+			output: "reflect.StructTag(``)",
+		},
+		{
+			name:   "Tag",
+			input:  reflect.StructTag(`json:"name,omitempty" yaml:"name"`),
+			output: "reflect.StructTag(`json:\"name,omitempty\" yaml:\"name\"`)",
+		},
+		{
+			name:   "Backquote",
+			input:  reflect.StructTag("doc:\"`code`\""),
+			output: "reflect.StructTag(\"doc:\\\"`code`\\\"\")",
+		},
+		{
+			name: "Tags of fields",
+			input: []reflect.StructTag{reflect.TypeOf(struct {
+				A int `json:"a"`
+			}{}).Field(0).Tag},
+			output: "[]reflect.StructTag{reflect.StructTag(`json:\"a\"`)}",
+		},
+		{
+			name:   "Empty slice",
+			input:  []reflect.StructTag{},
+			output: `make([]reflect.StructTag, 0)`,
+		},
+	}
+
+	for _, s := range scenarios {
+		s := s
+
+		t.Run(s.name, func(t *testing.T) {
+			t.Parallel()
+
+			assert.Equal(t, s.output, exporter.MustExport(s.input))
+		})
+	}
+}
@@ -0,0 +1,154 @@
+// Copyright (c) 2023–present Bartłomiej Krukowski
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is furnished
+// to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package exporter
+
+import (
+	"fmt"
+	"reflect"
+	"strings"
+	"time"
+)
+
+// Summary configures the comment that ExportFile prepends to the exported declarations, see WithSummary.
+type Summary struct {
+	// Source is the time the exported value was captured at, the zero value omits it.
+	Source time.Time
+}
+
+// WithSummary makes ExportFile prepend a comment that summarizes the exported value, e.g.:
+//
+//	// Summary:
+//	//   - level 1: 2 elements
+//	//   - level 2: 1200 elements
+//	//   - total size: 48213 bytes
+//	//   - source: 2023-03-01T12:30:00Z
+//	var Fixtures = map[string]interface{}{...}
+//
+// Levels count elements of slices, arrays and maps, and non-zero fields of structs, at the given depth.
+// The total size is the size of the exported code. It helps to review giant generated fixtures
+// without reading them line by line.
+func WithSummary(s Summary) Option {
+	return func(c *config) {
+		c.summary = &s
+	}
+}
+
+// render returns the summary comment of the given value, and the given exported expressions.
+func (s Summary) render(v any, exprs []string) string {
+	size := 0
+	for _, code := range exprs {
+		size += len(code)
+	}
+
+	buf := strings.Builder{}
+	buf.WriteString("// Summary:\n")
+
+	for i, n := range countLevels(reflect.ValueOf(v)) {
+		buf.WriteString(fmt.Sprintf("//   - level %d: %d elements\n", i+1, n))
+	}
+
+	buf.WriteString(fmt.Sprintf("//   - total size: %d bytes\n", size))
+
+	if !s.Source.IsZero() {
+		buf.WriteString("//   - source: " + s.Source.Format(time.RFC3339Nano) + "\n")
+	}
+
+	return buf.String()
+}
+
+// countLevels returns the number of elements at each nesting level of the given value.
+// Pointers and interfaces do not create new levels. Values are counted once, even if they are referenced many times.
+func countLevels(v reflect.Value) []int {
+	var (
+		levels  []int
+		visited = make(map[cacheKey]struct{})
+		walk    func(reflect.Value, int)
+	)
+
+	add := func(level int, n int) {
+		for len(levels) <= level {
+			levels = append(levels, 0)
+		}
+
+		levels[level] += n
+	}
+
+	walk = func(v reflect.Value, level int) {
+		if !v.IsValid() {
+			return
+		}
+
+		//nolint:exhaustive
+		switch v.Kind() {
+		case reflect.Ptr, reflect.Map, reflect.Slice:
+			if v.IsNil() {
+				return
+			}
+
+			k := cacheKey{t: v.Type(), ptr: v.Pointer(), len: 0}
+			if v.Kind() == reflect.Slice {
+				k.len = v.Len()
+			}
+
+			if _, ok := visited[k]; ok {
+				return
+			}
+
+			visited[k] = struct{}{}
+		}
+
+		//nolint:exhaustive
+		switch v.Kind() {
+		case reflect.Interface, reflect.Ptr:
+			if !v.IsNil() {
+				walk(v.Elem(), level)
+			}
+		case reflect.Slice, reflect.Array:
+			if v.Len() > 0 {
+				add(level, v.Len())
+			}
+
+			for i := 0; i < v.Len(); i++ {
+				walk(v.Index(i), level+1)
+			}
+		case reflect.Map:
+			if v.Len() > 0 {
+				add(level, v.Len())
+			}
+
+			iter := v.MapRange()
+			for iter.Next() {
+				walk(iter.Value(), level+1)
+			}
+		case reflect.Struct:
+			for i := 0; i < v.NumField(); i++ {
+				if f := v.Field(i); !f.IsZero() {
+					add(level, 1)
+					walk(f, level+1)
+				}
+			}
+		}
+	}
+
+	walk(v, 0)
+
+	return levels
+}
@@ -0,0 +1,89 @@
+// Copyright (c) 2023–present Bartłomiej Krukowski
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is furnished
+// to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package exporter_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/gontainer/exporter"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestWithSummary(t *testing.T) {
+	t.Parallel()
+
+	t.Run("Nested values", func(t *testing.T) {
+		t.Parallel()
+
+		e := exporter.New(exporter.WithSummary(exporter.Summary{
+			Source: time.Date(2023, time.March, 1, 12, 30, 0, 0, time.UTC),
+		}))
+		code, err := e.ExportFile("fixtures", "Servers", map[string][]StructServer{
+			"a": {{Host: "a", Port: 80}, {}},
+			"b": nil,
+		})
+		require.NoError(t, err)
+		assert.Equal(
+			t,
+			`// Code generated by github.com/gontainer/exporter. DO NOT EDIT.
+// Format: 2
+
+package fixtures
+
+import (
+	"github.com/gontainer/exporter_test"
+)
+
+// Summary:
+//   - level 1: 2 elements
+//   - level 2: 2 elements
+//   - level 3: 2 elements
+//   - total size: 200 bytes
+//   - source: 2023-03-01T12:30:00Z
+var Servers = map[string][]exporter_test.StructServer{"a": []exporter_test.StructServer{`+
+				`exporter_test.StructServer{Host: "a", Port: int(80)}, exporter_test.StructServer{}}, `+
+				`"b": ([]exporter_test.StructServer)(nil)}
+`,
+			withoutChecksum(t, code),
+		)
+	})
+
+	t.Run("Scalars", func(t *testing.T) {
+		t.Parallel()
+
+		code, err := exporter.New(exporter.WithSummary(exporter.Summary{})).ExportFile("fixtures", "Answer", 42)
+		require.NoError(t, err)
+		assert.Contains(t, string(code), "// Summary:\n//   - total size: 7 bytes\nvar Answer = int(42)\n")
+	})
+
+	t.Run("Cycles", func(t *testing.T) {
+		t.Parallel()
+
+		v := []any{nil, 1}
+		v[0] = v
+
+		code, err := exporter.New(exporter.WithSummary(exporter.Summary{})).ExportFile("fixtures", "Cycle", v)
+		assert.EqualError(t, err, "cannot export ([]interface{})[0]: unexpected infinite loop")
+		assert.Empty(t, code)
+	})
+}
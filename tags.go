@@ -0,0 +1,146 @@
+// Copyright (c) 2023–present Bartłomiej Krukowski
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is furnished
+// to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package exporter
+
+import (
+	"fmt"
+	"reflect"
+	"strconv"
+	"strings"
+)
+
+// RedactedValue replaces strings in fields with the directive "redact", see the package documentation.
+const RedactedValue = "REDACTED"
+
+// fieldTag contains directives from the tag "export" of a struct field, see the package documentation.
+type fieldTag struct {
+	skip     bool
+	redact   bool
+	hex      bool
+	raw      bool
+	template string
+}
+
+func parseFieldTag(tag reflect.StructTag) (fieldTag, error) {
+	var result fieldTag
+
+	s, ok := tag.Lookup("export")
+	if !ok {
+		return result, nil
+	}
+
+	for s != "" {
+		if strings.HasPrefix(s, "template=") {
+			result.template = strings.TrimPrefix(s, "template=")
+
+			break
+		}
+
+		var d string
+
+		d, s = s, ""
+		if i := strings.IndexByte(d, ','); i >= 0 {
+			d, s = d[:i], d[i+1:]
+		}
+
+		switch strings.TrimSpace(d) {
+		case "-":
+			result.skip = true
+		case "redact":
+			result.redact = true
+		case "hex":
+			result.hex = true
+		case "raw":
+			result.raw = true
+		default:
+			return result, fmt.Errorf("unknown directive %q in the tag `%s`", d, tag) //nolint:goerr113
+		}
+	}
+
+	return result, nil
+}
+
+// exportField exports the value of a struct field according to the directives from its tag.
+// It returns false, when the directives do not apply.
+func (s structExporter) exportField(ft fieldTag, v reflect.Value) (string, bool, error) {
+	if ft.redact && v.Kind() == reflect.String {
+		return s.backend.renderString(RedactedValue), true, nil
+	}
+
+	if _, ok := s.backend.(goBackend); !ok {
+		return "", false, nil
+	}
+
+	if ft.template != "" {
+		var code string
+
+		// the value is exported only when it is needed, so the template may support values of unsupported types
+		if strings.Contains(ft.template, "%s") {
+			var err error
+			if code, err = s.exporter.export(v.Interface()); err != nil {
+				return "", false, err //nolint:wrapcheck
+			}
+		}
+
+		r := strings.NewReplacer("%s", code, "%q", strconv.Quote(fmt.Sprint(v.Interface())))
+
+		return r.Replace(ft.template), true, nil
+	}
+
+	if ft.hex {
+		if v.Type().PkgPath() != "" {
+			return "", false, fmt.Errorf("directive hex requires an integer, %s given", typeName(v.Type())) //nolint:goerr113
+		}
+
+		//nolint:exhaustive
+		switch v.Kind() {
+		case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+			code, err := s.backend.renderNumber(v.Type(), fmt.Sprintf("%#x", v.Int()))
+
+			return code, true, err //nolint:wrapcheck
+		case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+			code, err := s.backend.renderNumber(v.Type(), fmt.Sprintf("%#x", v.Uint()))
+
+			return code, true, err //nolint:wrapcheck
+		}
+
+		return "", false, fmt.Errorf("directive hex requires an integer, %s given", typeName(v.Type())) //nolint:goerr113
+	}
+
+	if ft.raw && v.Kind() == reflect.String && v.Type().PkgPath() == "" {
+		if str := s.pseudonyms.replace(v.String()); canBackquote(str) {
+			return "`" + str + "`", true, nil
+		}
+	}
+
+	return "", false, nil
+}
+
+// canBackquote works like strconv.CanBackquote, but it accepts line feeds.
+func canBackquote(s string) bool {
+	for _, l := range strings.Split(s, "\n") {
+		if !strconv.CanBackquote(l) {
+			return false
+		}
+	}
+
+	return true
+}
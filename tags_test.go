@@ -0,0 +1,111 @@
+// Copyright (c) 2023–present Bartłomiej Krukowski
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is furnished
+// to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package exporter_test
+
+import (
+	"net"
+	"testing"
+
+	"github.com/gontainer/exporter"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type tagsUser struct {
+	Name     string
+	Password string  `export:"-"`
+	Token    string  `export:"redact"`
+	PIN      int     `export:"redact"`
+	Mode     uint32  `export:"hex"`
+	Offset   int     `export:"hex"`
+	Query    string  `export:"raw"`
+	Addr     net.IP  `export:"template=net.ParseIP(%q)"`
+	Weight   float64 `export:"template=%s * 1000"`
+	internal int     `export:"-"`
+}
+
+//nolint:testifylint
+func TestExport_tags(t *testing.T) {
+	t.Parallel()
+
+	u := tagsUser{
+		Name:     "Mary",
+		Password: "secret",
+		Token:    "token",
+		PIN:      1234,
+		Mode:     0o755,
+		Offset:   -255,
+		Query:    "SELECT *\nFROM users",
+		Addr:     net.ParseIP("127.0.0.1"),
+		Weight:   1.5,
+		internal: 5,
+	}
+
+	t.Run("GO", func(t *testing.T) {
+		t.Parallel()
+
+		code, err := exporter.Export(u)
+		require.NoError(t, err)
+		assert.Equal(
+			t,
+			"exporter_test.tagsUser{Name: \"Mary\", Token: \"REDACTED\", Mode: uint32(0x1ed), Offset: int(-0xff), "+
+				"Query: `SELECT *\nFROM users`, Addr: net.ParseIP(\"127.0.0.1\"), Weight: float64(1.5) * 1000}",
+			code,
+		)
+	})
+
+	t.Run("JSON", func(t *testing.T) {
+		t.Parallel()
+
+		code, err := exporter.New(exporter.WithBackend(exporter.JSONBackend())).Export(tagsUser{
+			Name:  "Mary",
+			Token: "token",
+			Mode:  0o755,
+			Query: "SELECT *",
+		})
+		require.NoError(t, err)
+		assert.Equal(t, `{"Name":"Mary","Token":"REDACTED","Mode":493,"Query":"SELECT *"}`, code)
+	})
+
+	t.Run("Raw string fallback", func(t *testing.T) {
+		t.Parallel()
+
+		code, err := exporter.Export(tagsUser{Query: "`id`"})
+		require.NoError(t, err)
+		assert.Equal(t, "exporter_test.tagsUser{Query: \"`id`\"}", code)
+	})
+
+	t.Run("Errors", func(t *testing.T) {
+		t.Parallel()
+
+		_, err := exporter.Export(struct {
+			Name string `export:"upper"`
+		}{Name: "Mary"})
+		assert.EqualError(t, err, "cannot export (struct { Name string \"export:\\\"upper\\\"\" }).Name: "+
+			"unknown directive \"upper\" in the tag `export:\"upper\"`")
+
+		_, err = exporter.Export(struct {
+			Name string `export:"hex"`
+		}{Name: "Mary"})
+		assert.EqualError(t, err, "cannot export (struct { Name string \"export:\\\"hex\\\"\" }).Name: "+
+			"directive hex requires an integer, string given")
+	})
+}
@@ -0,0 +1,116 @@
+// Copyright (c) 2023–present Bartłomiej Krukowski
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is furnished
+// to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package exporter
+
+import (
+	"fmt"
+	"reflect"
+	"time"
+)
+
+// TimeLocation defines how the locations of exported times are reproduced, see WithTimeLocation.
+type TimeLocation int
+
+const (
+	// TimeLocationKeep reproduces time.UTC and time.Local as they are, other locations are reproduced using
+	// time.FixedZone with the name and the offset of the zone in effect at the exported time.
+	TimeLocationKeep TimeLocation = iota
+	// TimeLocationUTC converts all times to UTC.
+	TimeLocationUTC
+	// TimeLocationFixed works like TimeLocationKeep, but it reproduces time.Local using time.FixedZone as well,
+	// so the generated code does not depend on the local time zone of the machine that runs it.
+	TimeLocationFixed
+)
+
+// WithTimeLocation sets how the locations of exported times are reproduced. The default value is TimeLocationKeep.
+//
+// Exported times never contain monotonic clock readings, since they are built by time.Date.
+func WithTimeLocation(l TimeLocation) Option {
+	return func(c *config) {
+		c.timeLocation = l
+	}
+}
+
+//nolint:gochecknoglobals
+var timeType = reflect.TypeOf(time.Time{})
+
+// timeExporter exports time.Time as a call to time.Date, e.g.:
+//
+//	time.Date(2023, time.March, 1, 12, 30, 0, 0, time.UTC)
+type timeExporter struct {
+	location TimeLocation
+}
+
+func (e timeExporter) export(v any) (string, error) {
+	t := v.(time.Time) //nolint:forcetypeassert
+
+	if e.location == TimeLocationUTC {
+		t = t.UTC()
+	}
+
+	var loc string
+
+	switch {
+	case t.Location() == time.UTC:
+		loc = "time.UTC"
+	case t.Location() == time.Local && e.location != TimeLocationFixed:
+		loc = "time.Local"
+	default:
+		name, offset := t.Zone()
+		loc = fmt.Sprintf("time.FixedZone(%+q, %d)", name, offset)
+	}
+
+	return fmt.Sprintf(
+		"time.Date(%d, time.%s, %d, %d, %d, %d, %d, %s)",
+		t.Year(),
+		t.Month().String(),
+		t.Day(),
+		t.Hour(),
+		t.Minute(),
+		t.Second(),
+		t.Nanosecond(),
+		loc,
+	), nil
+}
+
+func (timeExporter) supports(v any) bool {
+	return reflect.TypeOf(v) == timeType
+}
+
+// jsonTimeExporter exports time.Time as a string in the format time.RFC3339Nano, see JSONBackend.
+type jsonTimeExporter struct {
+	backend  Backend
+	location TimeLocation
+}
+
+func (e jsonTimeExporter) export(v any) (string, error) {
+	t := v.(time.Time) //nolint:forcetypeassert
+
+	if e.location == TimeLocationUTC {
+		t = t.UTC()
+	}
+
+	return e.backend.renderString(t.Format(time.RFC3339Nano)), nil
+}
+
+func (jsonTimeExporter) supports(v any) bool {
+	return reflect.TypeOf(v) == timeType
+}
@@ -0,0 +1,117 @@
+// Copyright (c) 2023–present Bartłomiej Krukowski
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is furnished
+// to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package exporter_test
+
+import (
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/gontainer/exporter"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestExport_time(t *testing.T) {
+	t.Parallel()
+
+	cet := time.FixedZone("CET", 3600)
+	utc := time.Date(2023, time.March, 1, 12, 30, 15, 500, time.UTC)
+	local := utc.In(time.Local)
+	localDate := fmt.Sprintf(
+		"time.Date(%d, time.%s, %d, %d, %d, %d, 500",
+		local.Year(),
+		local.Month(),
+		local.Day(),
+		local.Hour(),
+		local.Minute(),
+		local.Second(),
+	)
+
+	t.Run("Keep", func(t *testing.T) {
+		t.Parallel()
+
+		//nolint:exhaustruct
+		scenarios := []struct {
+			input  any
+			output string
+		}{
+			{
+				input:  utc,
+				output: `time.Date(2023, time.March, 1, 12, 30, 15, 500, time.UTC)`,
+			},
+			{
+				input:  utc.In(cet),
+				output: `time.Date(2023, time.March, 1, 13, 30, 15, 500, time.FixedZone("CET", 3600))`,
+			},
+			{
+				input:  local,
+				output: localDate + ", time.Local)",
+			},
+			{
+				input:  []any{time.Time{}},
+				output: `[]interface{}{time.Date(1, time.January, 1, 0, 0, 0, 0, time.UTC)}`,
+			},
+			{
+				input:  struct{ CreatedAt time.Time }{CreatedAt: utc},
+				output: `struct { CreatedAt time.Time }{CreatedAt: time.Date(2023, time.March, 1, 12, 30, 15, 500, time.UTC)}`,
+			},
+		}
+
+		for i, s := range scenarios {
+			assert.Equal(t, s.output, exporter.MustExport(s.input), fmt.Sprintf("scenario #%d", i))
+		}
+	})
+
+	t.Run("UTC", func(t *testing.T) {
+		t.Parallel()
+
+		e := exporter.New(exporter.WithTimeLocation(exporter.TimeLocationUTC))
+		assert.Equal(
+			t,
+			`time.Date(2023, time.March, 1, 12, 30, 15, 500, time.UTC)`,
+			e.MustExport(utc.In(cet)),
+		)
+	})
+
+	t.Run("Fixed", func(t *testing.T) {
+		t.Parallel()
+
+		name, offset := local.Zone()
+		e := exporter.New(exporter.WithTimeLocation(exporter.TimeLocationFixed))
+		assert.Equal(
+			t,
+			fmt.Sprintf("%s, time.FixedZone(%+q, %d))", localDate, name, offset),
+			e.MustExport(local),
+		)
+		assert.Equal(
+			t,
+			`time.Date(2023, time.March, 1, 12, 30, 15, 500, time.UTC)`,
+			e.MustExport(utc),
+		)
+	})
+
+	t.Run("Monotonic clock", func(t *testing.T) {
+		t.Parallel()
+
+		now := time.Now().UTC()
+		assert.Equal(t, exporter.MustExport(now.Round(0)), exporter.MustExport(now))
+	})
+}
@@ -0,0 +1,148 @@
+// Copyright (c) 2023–present Bartłomiej Krukowski
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is furnished
+// to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package exporter
+
+import (
+	"errors"
+	"reflect"
+	"time"
+)
+
+// Timestamps defines how time-like values are normalized, see WithTimestamps.
+type Timestamps struct {
+	// Placeholder is a GO expression of the type time.Time, e.g. the name of a variable,
+	// that replaces time-like values. When it is empty, time-like values are replaced by Fixed.
+	Placeholder string
+	// Fixed replaces time-like values, when Placeholder is empty.
+	Fixed time.Time
+	// UnixFrom and UnixTo define the range of integers that are considered as Unix timestamps in seconds.
+	// Integers are not normalized when UnixFrom is greater than UnixTo, e.g. when both values are zero.
+	UnixFrom, UnixTo int64
+}
+
+// WithTimestamps replaces time-like values, so fixtures recorded at different times do not differ.
+// Time-like values are:
+//   - values of the type time.Time
+//   - strings in the format time.RFC3339
+//   - integers in the range [UnixFrom, UnixTo]
+//
+// Given the placeholder "now", they are exported accordingly as:
+//
+//	now
+//	now.Format(time.RFC3339)
+//	int64(now.Unix())
+//
+// Keys of maps are replaced too, so maps with many time-like keys cannot be exported, since their keys collide.
+// Placeholders require the GO backend.
+func WithTimestamps(ts Timestamps) Option {
+	return func(c *config) {
+		c.timestamps = &ts
+	}
+}
+
+// timestampExporter replaces time-like values, see WithTimestamps.
+type timestampExporter struct {
+	exporter   exporter // exporter must not contain timestampExporter
+	backend    Backend
+	timestamps Timestamps
+}
+
+func (e timestampExporter) export(v any) (string, error) {
+	if e.timestamps.Placeholder != "" {
+		if _, ok := e.backend.(goBackend); !ok {
+			return "", errors.New("timestamp placeholders require the GO backend") //nolint:goerr113
+		}
+	}
+
+	p := e.timestamps.Placeholder
+	val := reflect.ValueOf(v)
+
+	switch x := v.(type) {
+	case time.Time:
+		if p != "" {
+			return p, nil
+		}
+
+		return e.exporter.export(e.timestamps.Fixed) //nolint:wrapcheck
+	case string:
+		layout := rfc3339Layout(x)
+		if p != "" {
+			return p + ".Format(time." + layout + ")", nil
+		}
+
+		if layout == "RFC3339Nano" {
+			return e.exporter.export(e.timestamps.Fixed.Format(time.RFC3339Nano)) //nolint:wrapcheck
+		}
+
+		return e.exporter.export(e.timestamps.Fixed.Format(time.RFC3339)) //nolint:wrapcheck
+	}
+
+	if p != "" {
+		return typeName(val.Type()) + "(" + p + ".Unix())", nil
+	}
+
+	return e.exporter.export(reflect.ValueOf(e.timestamps.Fixed.Unix()).Convert(val.Type()).Interface()) //nolint:wrapcheck
+}
+
+func (e timestampExporter) supports(v any) bool {
+	if _, ok := v.(time.Time); ok {
+		return true
+	}
+
+	if s, ok := v.(string); ok {
+		return rfc3339Layout(s) != ""
+	}
+
+	val := reflect.ValueOf(v)
+	if !val.IsValid() || val.Type().PkgPath() != "" || e.timestamps.UnixFrom > e.timestamps.UnixTo {
+		return false
+	}
+
+	var i int64
+
+	switch val.Kind() { //nolint:exhaustive
+	case reflect.Int, reflect.Int32, reflect.Int64:
+		i = val.Int()
+	case reflect.Uint, reflect.Uint32, reflect.Uint64:
+		if val.Uint() > uint64(e.timestamps.UnixTo) {
+			return false
+		}
+
+		i = int64(val.Uint())
+	default:
+		return false
+	}
+
+	return i >= e.timestamps.UnixFrom && i <= e.timestamps.UnixTo
+}
+
+// rfc3339Layout returns the name of the constant of the matching layout from the package time, or an empty string.
+func rfc3339Layout(s string) string {
+	if _, err := time.Parse(time.RFC3339, s); err != nil {
+		return ""
+	}
+
+	if t, err := time.Parse(time.RFC3339Nano, s); err == nil && t.Nanosecond() != 0 {
+		return "RFC3339Nano"
+	}
+
+	return "RFC3339"
+}
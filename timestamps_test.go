@@ -0,0 +1,129 @@
+// Copyright (c) 2023–present Bartłomiej Krukowski
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is furnished
+// to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package exporter_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/gontainer/exporter"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestWithTimestamps(t *testing.T) {
+	t.Parallel()
+
+	recorded := map[string]any{
+		"createdAt": time.Date(2023, time.March, 1, 12, 30, 0, 0, time.UTC),
+		"updatedAt": "2023-03-01T12:30:00Z",
+		"deletedAt": "2023-03-01T12:30:00.123Z",
+		"expiresAt": int64(1677673800),
+		"id":        int64(5),
+		"name":      "Mary",
+	}
+
+	t.Run("Placeholder", func(t *testing.T) {
+		t.Parallel()
+
+		e := exporter.New(exporter.WithTimestamps(exporter.Timestamps{
+			Placeholder: "now",
+			UnixFrom:    946684800,  // 2000-01-01
+			UnixTo:      4102444800, // 2100-01-01
+		}))
+		assert.Equal(
+			t,
+			`map[string]interface{}{"createdAt": now, "deletedAt": now.Format(time.RFC3339Nano), `+
+				`"expiresAt": int64(now.Unix()), "id": int64(5), "name": "Mary", "updatedAt": now.Format(time.RFC3339)}`,
+			e.MustExport(recorded),
+		)
+	})
+
+	t.Run("Fixed", func(t *testing.T) {
+		t.Parallel()
+
+		e := exporter.New(exporter.WithTimestamps(exporter.Timestamps{
+			Fixed: time.Date(2000, time.January, 1, 0, 0, 0, 0, time.UTC),
+		}))
+		assert.Equal(
+			t,
+			`map[string]interface{}{"createdAt": time.Date(2000, time.January, 1, 0, 0, 0, 0, time.UTC), `+
+				`"deletedAt": "2000-01-01T00:00:00Z", "expiresAt": int64(1677673800), "id": int64(5), "name": "Mary", `+
+				`"updatedAt": "2000-01-01T00:00:00Z"}`,
+			e.MustExport(recorded),
+		)
+	})
+
+	t.Run("Fixed Unix timestamps", func(t *testing.T) {
+		t.Parallel()
+
+		e := exporter.New(exporter.WithTimestamps(exporter.Timestamps{
+			Fixed:    time.Date(2000, time.January, 1, 0, 0, 0, 0, time.UTC),
+			UnixFrom: 946684800,
+			UnixTo:   4102444800,
+		}))
+		assert.Equal(t, `[]uint{uint(946684800), uint(5)}`, e.MustExport([]uint{1677673800, 5}))
+	})
+
+	t.Run("File", func(t *testing.T) {
+		t.Parallel()
+
+		e := exporter.New(exporter.WithTimestamps(exporter.Timestamps{Placeholder: "now"}))
+		code, err := e.ExportFile("fixtures", "Dates", []string{"2023-03-01T12:30:00Z"})
+		require.NoError(t, err)
+		assert.Contains(t, string(code), "import (\n\t\"time\"\n)\n")
+	})
+
+	t.Run("Colliding keys", func(t *testing.T) {
+		t.Parallel()
+
+		e := exporter.New(exporter.WithTimestamps(exporter.Timestamps{}))
+		_, err := e.Export(map[string]int{"2023-03-01T12:30:00Z": 1, "2023-03-02T12:30:00Z": 2})
+		assert.EqualError(
+			t,
+			err,
+			`cannot export (map[string]int)["0001-01-01T00:00:00Z"]: distinct keys are exported to the same code`,
+		)
+
+		e = exporter.New(exporter.WithTimestamps(exporter.Timestamps{Placeholder: "now"}))
+		_, err = e.Export(map[time.Time]int{time.Unix(1, 0): 1, time.Unix(2, 0): 2})
+		assert.EqualError(t, err, `cannot export (map[time.Time]int)[now]: distinct keys are exported to the same code`)
+
+		assert.Equal(t, `map[time.Time]int{now: int(1)}`, e.MustExport(map[time.Time]int{time.Unix(1, 0): 1}))
+	})
+
+	t.Run("JSON", func(t *testing.T) {
+		t.Parallel()
+
+		e := exporter.New(
+			exporter.WithBackend(exporter.JSONBackend()),
+			exporter.WithTimestamps(exporter.Timestamps{Fixed: time.Date(2000, time.January, 1, 0, 0, 0, 0, time.UTC)}),
+		)
+		assert.Equal(t, `["2000-01-01T00:00:00Z","hello"]`, e.MustExport([]string{"2023-03-01T12:30:00Z", "hello"}))
+
+		e = exporter.New(
+			exporter.WithBackend(exporter.JSONBackend()),
+			exporter.WithTimestamps(exporter.Timestamps{Placeholder: "now"}),
+		)
+		_, err := e.Export([]string{"2023-03-01T12:30:00Z"})
+		assert.EqualError(t, err, `cannot export ([]string)[0]: timestamp placeholders require the GO backend`)
+	})
+}
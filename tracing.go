@@ -0,0 +1,120 @@
+// Copyright (c) 2023–present Bartłomiej Krukowski
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is furnished
+// to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package exporter
+
+// Trace describes a finished call of Exporter.Export or Exporter.ExportFile, see WithTracing.
+type Trace struct {
+	// Operation is either "Export" or "ExportFile".
+	Operation string
+	// Nodes is the number of exported nodes, see WithMaxNodes.
+	Nodes int
+	// Size is the size of the output in bytes.
+	Size int
+	// Err is the error returned by the call, if any.
+	Err error
+}
+
+// WithTracing instruments calls of Export and ExportFile. The function start is called at the beginning of each call,
+// and the function it returns is called with the trace of the call when it is finished.
+// Calls of ExportFile contain calls of Export, so their traces are nested.
+//
+// The package does not depend on any tracing library, the following code records OpenTelemetry spans:
+//
+//	exporter.WithTracing(func(operation string) func(exporter.Trace) {
+//		_, span := tracer.Start(ctx, "exporter."+operation)
+//
+//		return func(t exporter.Trace) {
+//			span.SetAttributes(attribute.Int("exporter.nodes", t.Nodes), attribute.Int("exporter.size", t.Size))
+//			if t.Err != nil {
+//				span.RecordError(t.Err)
+//				span.SetStatus(codes.Error, t.Err.Error())
+//			}
+//			span.End()
+//		}
+//	})
+func WithTracing(start func(operation string) (end func(Trace))) Option {
+	return func(c *config) {
+		c.tracing = start
+	}
+}
+
+// traceExporter counts exported nodes, and reports the trace of the root value, see WithTracing.
+type traceExporter struct {
+	start func(string) func(Trace)
+	depth int
+	nodes int
+	next  exporter
+}
+
+func newTraceExporter(start func(string) func(Trace), next exporter) *traceExporter {
+	return &traceExporter{
+		start: start,
+		depth: 0,
+		nodes: 0,
+		next:  next,
+	}
+}
+
+func (t *traceExporter) export(v any) (string, error) {
+	t.nodes++
+
+	if t.depth > 0 {
+		return t.next.export(v) //nolint:wrapcheck
+	}
+
+	t.depth++
+	defer func() {
+		t.depth--
+	}()
+
+	end := t.start("Export")
+	code, err := t.next.export(v)
+	end(Trace{Operation: "Export", Nodes: t.nodes, Size: len(code), Err: err})
+
+	return code, err //nolint:wrapcheck
+}
+
+func (t *traceExporter) supports(v any) bool {
+	return t.next.supports(v)
+}
+
+// traceFile calls the given function that generates a file, and reports its trace, see WithTracing.
+// Nodes of the file are the sum of nodes of all values exported by the function.
+func (e *Exporter) traceFile(fn func(e *Exporter) ([]byte, error)) ([]byte, error) {
+	start := e.config.tracing
+	nodes := 0
+
+	cfg := e.config
+	cfg.tracing = func(operation string) func(Trace) {
+		end := start(operation)
+
+		return func(t Trace) {
+			nodes += t.Nodes
+			end(t)
+		}
+	}
+
+	end := start("ExportFile")
+	code, err := fn(&Exporter{config: cfg, exporter: newRootExporter(cfg), caster: e.caster})
+	end(Trace{Operation: "ExportFile", Nodes: nodes, Size: len(code), Err: err})
+
+	return code, err
+}
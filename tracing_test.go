@@ -0,0 +1,78 @@
+// Copyright (c) 2023–present Bartłomiej Krukowski
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is furnished
+// to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package exporter_test
+
+import (
+	"testing"
+
+	"github.com/gontainer/exporter"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type traceRecorder struct {
+	events []string
+	traces []exporter.Trace
+}
+
+func (r *traceRecorder) start(operation string) func(exporter.Trace) {
+	r.events = append(r.events, "start "+operation)
+
+	return func(t exporter.Trace) {
+		r.events = append(r.events, "end "+t.Operation)
+		r.traces = append(r.traces, t)
+	}
+}
+
+func TestWithTracing(t *testing.T) {
+	t.Parallel()
+
+	t.Run("Export", func(t *testing.T) {
+		t.Parallel()
+
+		r := &traceRecorder{}
+		e := exporter.New(exporter.WithTracing(r.start))
+
+		code, err := e.Export([]any{1, []int{2, 3}})
+		require.NoError(t, err)
+		assert.Equal(t, []string{"start Export", "end Export"}, r.events)
+		assert.Equal(t, []exporter.Trace{{Operation: "Export", Nodes: 5, Size: len(code), Err: nil}}, r.traces)
+
+		_, err = e.Export([]any{make(chan int)})
+		require.Error(t, err)
+		assert.Equal(t, exporter.Trace{Operation: "Export", Nodes: 2, Size: 0, Err: err}, r.traces[1])
+	})
+
+	t.Run("ExportFile", func(t *testing.T) {
+		t.Parallel()
+
+		r := &traceRecorder{}
+		e := exporter.New(exporter.WithTracing(r.start), exporter.WithMapSplitting("fixture"))
+
+		code, err := e.ExportFile("fixtures", "Values", map[string][]int{"a": {1}, "b": {2, 3}})
+		require.NoError(t, err)
+		// values and keys of split maps are exported separately
+		require.Len(t, r.traces, 5)
+		assert.Equal(t, "start ExportFile", r.events[0])
+		assert.Equal(t, "end ExportFile", r.events[9])
+		assert.Equal(t, exporter.Trace{Operation: "ExportFile", Nodes: 7, Size: len(code), Err: nil}, r.traces[4])
+	})
+}
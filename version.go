@@ -0,0 +1,101 @@
+// Copyright (c) 2023–present Bartłomiej Krukowski
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is furnished
+// to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package exporter
+
+import (
+	"bufio"
+	"bytes"
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// FormatVersion identifies the format of the code generated by the exporter.
+// Changes of the output that would modify existing fixtures are introduced in new versions,
+// so codebases can upgrade the module first, and regenerate their fixtures later, see WithFormatVersion.
+type FormatVersion int
+
+const (
+	// FormatVersion1 is the format of files that do not declare their version.
+	FormatVersion1 FormatVersion = 1
+	// FormatVersion2 declares the version in the header of generated files, see FormatVersionPrefix.
+	FormatVersion2 FormatVersion = 2
+	// LatestFormatVersion is the default format version.
+	LatestFormatVersion = FormatVersion2
+)
+
+// FormatVersionPrefix starts the line of the header of generated files that declares their format version, e.g.:
+//
+//	// Code generated by github.com/gontainer/exporter. DO NOT EDIT.
+//	// Checksum: sha256:...
+//	// Format: 2
+const FormatVersionPrefix = "// Format: "
+
+// WithFormatVersion makes the exporter generate the code in the given format, e.g. to keep existing fixtures
+// unchanged after upgrading the module. Versions from FormatVersion1 to LatestFormatVersion are supported,
+// other versions make the exporter fail. See GeneratedFormatVersion.
+func WithFormatVersion(v FormatVersion) Option {
+	return func(c *config) {
+		c.formatVersion = v
+	}
+}
+
+// FormatVersion returns the format version of the generated code, see WithFormatVersion.
+func (e *Exporter) FormatVersion() FormatVersion {
+	return e.config.formatVersion
+}
+
+// GeneratedFormatVersion returns the format version declared in the header of the given generated file,
+// or FormatVersion1 when the file does not declare its version, so it can be regenerated in the same format:
+//
+//	exporter.New(exporter.WithFormatVersion(exporter.GeneratedFormatVersion(src))).ExportFile(...)
+func GeneratedFormatVersion(src []byte) FormatVersion {
+	s := bufio.NewScanner(bytes.NewReader(src))
+
+	for s.Scan() {
+		line := strings.TrimRight(s.Text(), "\r")
+		if !strings.HasPrefix(line, "//") {
+			break
+		}
+
+		if v, err := strconv.Atoi(strings.TrimPrefix(line, FormatVersionPrefix)); err == nil &&
+			strings.HasPrefix(line, FormatVersionPrefix) {
+			return FormatVersion(v)
+		}
+	}
+
+	return FormatVersion1
+}
+
+// formatVersionExporter reports unsupported format versions, see WithFormatVersion.
+type formatVersionExporter struct {
+	exporter
+	version FormatVersion
+}
+
+func (f formatVersionExporter) export(any) (string, error) {
+	return "", fmt.Errorf( //nolint:goerr113
+		"format version %d is not supported, use versions from %d to %d",
+		f.version,
+		FormatVersion1,
+		LatestFormatVersion,
+	)
+}
@@ -0,0 +1,99 @@
+// Copyright (c) 2023–present Bartłomiej Krukowski
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is furnished
+// to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package exporter_test
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/gontainer/exporter"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestWithFormatVersion(t *testing.T) {
+	t.Parallel()
+
+	t.Run("Latest", func(t *testing.T) {
+		t.Parallel()
+
+		e := exporter.New()
+		assert.Equal(t, exporter.LatestFormatVersion, e.FormatVersion())
+
+		code, err := e.ExportFile("fixtures", "Numbers", []int{1})
+		require.NoError(t, err)
+		assert.Contains(t, string(code), "\n// Format: 2\n\npackage fixtures\n")
+		assert.Equal(t, exporter.FormatVersion2, exporter.GeneratedFormatVersion(code))
+	})
+
+	t.Run("Version 1", func(t *testing.T) {
+		t.Parallel()
+
+		e := exporter.New(exporter.WithFormatVersion(exporter.FormatVersion1))
+		assert.Equal(t, exporter.FormatVersion1, e.FormatVersion())
+
+		code, err := e.ExportFile("fixtures", "Numbers", []int{1})
+		require.NoError(t, err)
+		assert.Equal(
+			t,
+			`// Code generated by github.com/gontainer/exporter. DO NOT EDIT.
+
+package fixtures
+
+var Numbers = []int{int(1)}
+`,
+			withoutChecksum(t, code),
+		)
+		assert.Equal(t, exporter.FormatVersion1, exporter.GeneratedFormatVersion(code))
+	})
+
+	t.Run("Unsupported versions", func(t *testing.T) {
+		t.Parallel()
+
+		for _, v := range []exporter.FormatVersion{0, exporter.LatestFormatVersion + 1} {
+			e := exporter.New(exporter.WithFormatVersion(v))
+
+			_, err := e.Export(1)
+			assert.EqualError(t, err, fmt.Sprintf("format version %d is not supported, use versions from 1 to 2", v))
+
+			_, err = e.ExportFile("fixtures", "Numbers", 1)
+			assert.Error(t, err)
+		}
+	})
+}
+
+func TestGeneratedFormatVersion(t *testing.T) {
+	t.Parallel()
+
+	scenarios := map[string]exporter.FormatVersion{
+		"":                                       exporter.FormatVersion1,
+		"package fixtures\n":                     exporter.FormatVersion1,
+		"// Format: 3\r\n\r\npackage fixtures\n": exporter.FormatVersion(3),
+		"// Header\n// Format: 2\npackage p\n":   exporter.FormatVersion2,
+		"package p\n\n// Format: 2\n":            exporter.FormatVersion1,
+		"// Format: two\n":                       exporter.FormatVersion1,
+		"// Code generated\n//\tFormat: 2\n":     exporter.FormatVersion1,
+	}
+
+	for src, expected := range scenarios {
+		assert.Equal(t, expected, exporter.GeneratedFormatVersion([]byte(src)), src)
+	}
+}
@@ -0,0 +1,93 @@
+// Copyright (c) 2023–present Bartłomiej Krukowski
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is furnished
+// to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package exporter_test
+
+import (
+	"io/ioutil"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"sync/atomic"
+	"testing"
+
+	"github.com/gontainer/exporter"
+	"github.com/stretchr/testify/require"
+)
+
+// vetFile runs "go vet" on a module that consists of the given generated file of the package fixtures.
+// The file must not import packages other than the standard library.
+// It skips the test if the GO toolchain is not available.
+func vetFile(t *testing.T, code []byte) {
+	t.Helper()
+
+	goBin, err := exec.LookPath("go")
+	if err != nil {
+		t.Skip("go is not available")
+	}
+
+	dir, err := ioutil.TempDir("", "exporter") //nolint:staticcheck
+	require.NoError(t, err)
+
+	defer os.RemoveAll(dir)
+
+	files := map[string][]byte{
+		"go.mod":      []byte("module fixtures\n\ngo 1.21\n"),
+		"fixtures.go": code,
+	}
+	for name, content := range files {
+		require.NoError(t, ioutil.WriteFile(filepath.Join(dir, name), content, 0o600)) //nolint:staticcheck
+	}
+
+	cmd := exec.Command(goBin, "vet", ".") //nolint:gosec
+	cmd.Dir = dir
+	cmd.Env = append(os.Environ(), "GOFLAGS=-mod=mod", "GOWORK=off")
+
+	output, err := cmd.CombinedOutput()
+	require.NoError(t, err, "%s\n%s", output, code)
+}
+
+func TestExportFile_vet(t *testing.T) {
+	t.Parallel()
+
+	hits := new(atomic.Int64)
+	hits.Store(100)
+
+	scenarios := map[string]any{
+		"Pointer to atomic value":   hits,
+		"Pointers to atomic values": []*atomic.Int64{hits, new(atomic.Int64), nil},
+		"Struct with pointers": struct {
+			Hits    *atomic.Int64
+			Enabled *atomic.Bool
+		}{Hits: hits, Enabled: new(atomic.Bool)},
+	}
+
+	for name, v := range scenarios {
+		name, v := name, v
+
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			code, err := exporter.ExportFile("fixtures", "Value", v)
+			require.NoError(t, err)
+			vetFile(t, code)
+		})
+	}
+}
@@ -0,0 +1,50 @@
+// Copyright (c) 2023–present Bartłomiej Krukowski
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is furnished
+// to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package exporter
+
+// VisitedSet tracks values that are being exported, so the exporter can detect cycles, see WithVisitedSet.
+// The exporter calls Push before it exports a value, and Pop after it has exported that value,
+// so the pushed values form a stack of the ancestors of the currently exported value.
+type VisitedSet interface {
+	// Push returns an error, when the given value cannot be exported, e.g. because it is one of its own ancestors.
+	Push(v any) error
+	// Pop removes the value pushed most recently.
+	Pop()
+}
+
+// NewVisitedStack returns the default VisitedSet. It rejects values that are deeply equal to one of their ancestors.
+func NewVisitedStack() VisitedSet { //nolint:ireturn
+	return newStack()
+}
+
+// WithVisitedSet replaces the mechanism that detects cycles, e.g. to allow a bounded number of revisits
+// or to identify wrapper types by custom functions. The factory is called for each exported value,
+// so the returned set does not need to be safe for concurrent use.
+// The default factory is NewVisitedStack.
+func WithVisitedSet(factory func() VisitedSet) Option {
+	return func(c *config) {
+		if factory == nil {
+			factory = NewVisitedStack
+		}
+
+		c.visitedSet = factory
+	}
+}
@@ -0,0 +1,115 @@
+// Copyright (c) 2023–present Bartłomiej Krukowski
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is furnished
+// to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package exporter_test
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+	"testing"
+
+	"github.com/gontainer/exporter"
+	"github.com/stretchr/testify/assert"
+)
+
+// visitedBounded allows each value to be revisited at most max times.
+type visitedBounded struct {
+	max   int
+	stack []any
+}
+
+func (v *visitedBounded) Push(x any) error {
+	visits := 0
+
+	for _, y := range v.stack {
+		if reflect.DeepEqual(x, y) {
+			visits++
+		}
+	}
+
+	if visits > v.max {
+		return errors.New("too many revisits") //nolint:goerr113
+	}
+
+	v.stack = append(v.stack, x)
+
+	return nil
+}
+
+func (v *visitedBounded) Pop() {
+	v.stack = v.stack[:len(v.stack)-1]
+}
+
+// visitedLog records pushed values and delegates to the default VisitedSet.
+type visitedLog struct {
+	exporter.VisitedSet
+	log *[]string
+}
+
+func (v visitedLog) Push(x any) error {
+	*v.log = append(*v.log, fmt.Sprintf("%T", x))
+
+	return v.VisitedSet.Push(x) //nolint:wrapcheck
+}
+
+func TestWithVisitedSet(t *testing.T) {
+	t.Parallel()
+
+	t.Run("Default", func(t *testing.T) {
+		t.Parallel()
+
+		a := []any{1, nil}
+		a[1] = a
+
+		_, err := exporter.New(exporter.WithVisitedSet(nil)).Export(a)
+		assert.EqualError(t, err, `cannot export ([]interface{})[1]: unexpected infinite loop`)
+	})
+
+	t.Run("Bounded revisits", func(t *testing.T) {
+		t.Parallel()
+
+		a := []any{1, nil}
+		a[1] = a
+
+		e := exporter.New(exporter.WithVisitedSet(func() exporter.VisitedSet {
+			return &visitedBounded{max: 1, stack: nil}
+		}))
+
+		_, err := e.Export(a)
+		assert.EqualError(
+			t,
+			err,
+			`cannot export ([]interface{})[1]: cannot export ([]interface{})[1]: too many revisits`,
+		)
+	})
+
+	t.Run("Custom set", func(t *testing.T) {
+		t.Parallel()
+
+		log := make([]string, 0)
+		e := exporter.New(exporter.WithVisitedSet(func() exporter.VisitedSet {
+			return visitedLog{VisitedSet: exporter.NewVisitedStack(), log: &log}
+		}))
+
+		assert.Equal(t, `[]interface{}{int(1), "a"}`, e.MustExport([]any{1, "a"}))
+		assert.Equal(t, []string{"[]interface {}", "int", "string"}, log)
+	})
+}
@@ -0,0 +1,110 @@
+// Copyright (c) 2023–present Bartłomiej Krukowski
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is furnished
+// to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package exporter
+
+import (
+	"bytes"
+	"fmt"
+	"io/ioutil" //nolint:staticcheck
+	"os"
+	"path/filepath"
+)
+
+// WriteFile writes the file generated by ExportFile to the given path, and reports whether its content has changed.
+//
+// See Exporter.WriteFile.
+func WriteFile(path string, pkg string, varName string, v any) (changed bool, err error) {
+	return defaultExporter.WriteFile(path, pkg, varName, v)
+}
+
+// WriteFile writes the file generated by Exporter.ExportFile to the given path atomically.
+// The content is written to a temporary file in the same directory, that is renamed to the given path,
+// so readers never see a partially written file. The file is not touched when its content has not changed.
+// Existing files keep their modes, new files are created with the mode 0644.
+//
+// The generated code is already formatted like by gofmt, and formatting it again would invalidate its checksum,
+// see VerifyGenerated.
+func (e *Exporter) WriteFile(path string, pkg string, varName string, v any) (changed bool, err error) {
+	code, err := e.ExportFile(pkg, varName, v)
+	if err != nil {
+		return false, err
+	}
+
+	current, err := ioutil.ReadFile(path) //nolint:staticcheck
+	if err == nil && bytes.Equal(current, code) {
+		return false, nil
+	}
+
+	if err != nil && !os.IsNotExist(err) {
+		return false, fmt.Errorf("cannot read %s: %w", path, err)
+	}
+
+	if err := writeFileAtomically(path, code); err != nil {
+		return false, err
+	}
+
+	return true, nil
+}
+
+func writeFileAtomically(path string, content []byte) (err error) {
+	mode := os.FileMode(0o644) //nolint:gomnd
+
+	info, err := os.Stat(path)
+
+	switch {
+	case err == nil:
+		mode = info.Mode().Perm()
+	case !os.IsNotExist(err):
+		return fmt.Errorf("cannot read %s: %w", path, err)
+	}
+
+	tmp, err := ioutil.TempFile(filepath.Dir(path), "."+filepath.Base(path)+".*.tmp") //nolint:staticcheck
+	if err != nil {
+		return fmt.Errorf("cannot create temporary file: %w", err)
+	}
+
+	defer func() {
+		if err != nil {
+			_ = os.Remove(tmp.Name())
+		}
+	}()
+
+	if _, err := tmp.Write(content); err != nil {
+		_ = tmp.Close()
+
+		return fmt.Errorf("cannot write %s: %w", tmp.Name(), err)
+	}
+
+	if err := tmp.Close(); err != nil {
+		return fmt.Errorf("cannot write %s: %w", tmp.Name(), err)
+	}
+
+	// temporary files are readable by their owners only
+	if err := os.Chmod(tmp.Name(), mode); err != nil {
+		return fmt.Errorf("cannot change mode of %s: %w", tmp.Name(), err)
+	}
+
+	if err := os.Rename(tmp.Name(), path); err != nil {
+		return fmt.Errorf("cannot rename %s: %w", tmp.Name(), err)
+	}
+
+	return nil
+}
@@ -0,0 +1,85 @@
+// Copyright (c) 2023–present Bartłomiej Krukowski
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is furnished
+// to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package exporter_test
+
+import (
+	"io/ioutil" //nolint:staticcheck
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/gontainer/exporter"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestWriteFile(t *testing.T) {
+	t.Parallel()
+
+	dir, err := ioutil.TempDir("", "exporter") //nolint:staticcheck
+	require.NoError(t, err)
+	defer os.RemoveAll(dir)
+
+	path := filepath.Join(dir, "primes_gen.go")
+
+	changed, err := exporter.WriteFile(path, "fixtures", "Primes", []int{2, 3})
+	require.NoError(t, err)
+	assert.True(t, changed)
+	require.NoError(t, exporter.VerifyGenerated(path))
+
+	info, err := os.Stat(path)
+	require.NoError(t, err)
+	assert.Equal(t, os.FileMode(0o644), info.Mode().Perm())
+
+	changed, err = exporter.WriteFile(path, "fixtures", "Primes", []int{2, 3})
+	require.NoError(t, err)
+	assert.False(t, changed)
+
+	changed, err = exporter.WriteFile(path, "fixtures", "Primes", []int{2, 3, 5})
+	require.NoError(t, err)
+	assert.True(t, changed)
+
+	code, err := ioutil.ReadFile(path) //nolint:staticcheck
+	require.NoError(t, err)
+	assert.Contains(t, string(code), "var Primes = []int{int(2), int(3), int(5)}")
+
+	// existing files keep their modes
+	require.NoError(t, os.Chmod(path, 0o600))
+
+	changed, err = exporter.WriteFile(path, "fixtures", "Primes", []int{2, 3, 5, 7})
+	require.NoError(t, err)
+	assert.True(t, changed)
+
+	info, err = os.Stat(path)
+	require.NoError(t, err)
+	assert.Equal(t, os.FileMode(0o600), info.Mode().Perm())
+
+	// temporary files are removed
+	files, err := ioutil.ReadDir(dir) //nolint:staticcheck
+	require.NoError(t, err)
+	assert.Len(t, files, 1)
+
+	_, err = exporter.WriteFile(filepath.Join(dir, "invalid_gen.go"), "fixtures", "Chan", make(chan int))
+	assert.EqualError(t, err, "type chan int is not supported")
+
+	_, err = exporter.WriteFile(filepath.Join(dir, "missing", "primes_gen.go"), "fixtures", "Primes", []int{2})
+	assert.ErrorContains(t, err, "cannot create temporary file: ")
+}
@@ -0,0 +1,183 @@
+// Copyright (c) 2023–present Bartłomiej Krukowski
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is furnished
+// to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package exporter
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"reflect"
+
+	"gopkg.in/yaml.v3"
+)
+
+// YAMLCoercion defines how CoerceYAML converts weakly-typed scalars of decoded YAML documents.
+// The zero value does not convert anything.
+type YAMLCoercion struct {
+	// Bools converts booleans of YAML 1.1 decoded as strings, e.g. "yes", "No", "on", "OFF", to bools.
+	// YAML 1.2 decoders, e.g. gopkg.in/yaml.v3, decode such scalars as strings.
+	Bools bool
+}
+
+// yamlBools contains booleans of YAML 1.1, see https://yaml.org/type/bool.html.
+//
+//nolint:gochecknoglobals
+var yamlBools = map[string]bool{
+	"y": true, "Y": true, "yes": true, "Yes": true, "YES": true,
+	"n": false, "N": false, "no": false, "No": false, "NO": false,
+	"true": true, "True": true, "TRUE": true,
+	"false": false, "False": false, "FALSE": false,
+	"on": true, "On": true, "ON": true,
+	"off": false, "Off": false, "OFF": false,
+}
+
+// WithYAMLCoercion makes ExportYAML convert scalars of decoded documents according to the given policy,
+// see CoerceYAML. By default, scalars are not converted.
+func WithYAMLCoercion(p YAMLCoercion) Option {
+	return func(c *config) {
+		c.yamlCoercion = p
+	}
+}
+
+// ExportYAML decodes the given YAML document to map[string]any, []any and primitives,
+// and exports the result, e.g.:
+//
+//	exporter.ExportYAML(strings.NewReader("parameters:\n  port: 8080\n"))
+//	// map[string]interface{}{"parameters": map[string]interface{}{"port": int(8080)}}
+//
+// It allows to compile configuration files to GO code, so programs do not need to parse YAML at runtime.
+// Mappings with keys other than strings are decoded to map[any]any, and timestamps to time.Time.
+// The reader must contain exactly one document.
+// The given options configure the exporter, the default configuration is used if there are no options.
+//
+// See Exporter.ExportYAML and WithYAMLCoercion.
+func ExportYAML(r io.Reader, opts ...Option) (string, error) {
+	e := defaultExporter
+	if len(opts) > 0 {
+		e = New(opts...)
+	}
+
+	return e.ExportYAML(r)
+}
+
+// ExportYAML decodes the given YAML document and exports the result, see the function ExportYAML.
+func (e *Exporter) ExportYAML(r io.Reader) (string, error) {
+	v, err := decodeYAML(r)
+	if err != nil {
+		return "", err
+	}
+
+	return e.Export(CoerceYAML(v, e.config.yamlCoercion))
+}
+
+// decodeYAML decodes exactly one YAML document.
+func decodeYAML(r io.Reader) (any, error) {
+	d := yaml.NewDecoder(r)
+
+	var v any
+	if err := d.Decode(&v); err != nil {
+		if errors.Is(err, io.EOF) {
+			return nil, errors.New("cannot decode YAML: the document is empty") //nolint:goerr113
+		}
+
+		return nil, fmt.Errorf("cannot decode YAML: %w", err)
+	}
+
+	var extra any
+	if err := d.Decode(&extra); !errors.Is(err, io.EOF) {
+		return nil, errors.New("cannot decode YAML: unexpected document after the first one") //nolint:goerr113
+	}
+
+	return v, nil
+}
+
+// CoerceYAML returns a copy of the given value decoded from YAML, in which scalars are converted
+// according to the given policy, so the exported literal reflects the intended schema rather than
+// the loose typing of YAML, e.g.:
+//
+//	var v any
+//	_ = yaml.Unmarshal([]byte("debug: yes\nport: \"8080\""), &v)
+//	exporter.Export(exporter.CoerceYAML(v, exporter.YAMLCoercion{Bools: true}))
+//	// map[string]interface{}{"debug": true, "port": "8080"}
+//
+// Only strings stored in interfaces are converted, i.e. values of maps and slices of type interface{},
+// since they are the only places where decoders cannot tell the intended type.
+// Numbers are never parsed from strings, so quoted numbers are kept as strings.
+// Keys of maps are not changed. Values of other types are shared with the original.
+func CoerceYAML(v any, p YAMLCoercion) any {
+	if v == nil {
+		return nil
+	}
+
+	return p.coerce(reflect.ValueOf(v)).Interface()
+}
+
+func (p YAMLCoercion) coerce(v reflect.Value) reflect.Value {
+	//nolint:exhaustive
+	switch v.Kind() {
+	case reflect.String:
+		if b, ok := yamlBools[v.String()]; ok && p.Bools && v.Type() == reflect.TypeOf("") {
+			return reflect.ValueOf(b)
+		}
+	case reflect.Slice:
+		if v.IsNil() || v.Type().Elem().Kind() != reflect.Interface {
+			return v
+		}
+
+		r := reflect.MakeSlice(v.Type(), v.Len(), v.Len())
+		for i := 0; i < v.Len(); i++ {
+			r.Index(i).Set(p.coerceElem(v.Index(i)))
+		}
+
+		return r
+	case reflect.Map:
+		if v.IsNil() || v.Type().Elem().Kind() != reflect.Interface {
+			return v
+		}
+
+		r := reflect.MakeMapWithSize(v.Type(), v.Len())
+		iter := v.MapRange()
+
+		for iter.Next() {
+			r.SetMapIndex(iter.Key(), p.coerceElem(iter.Value()))
+		}
+
+		return r
+	}
+
+	return v
+}
+
+// coerceElem coerces the given value of an interface type.
+func (p YAMLCoercion) coerceElem(v reflect.Value) reflect.Value {
+	r := reflect.New(v.Type()).Elem()
+
+	if !v.IsNil() {
+		c := p.coerce(v.Elem())
+		if !c.Type().AssignableTo(v.Type()) {
+			c = v.Elem()
+		}
+
+		r.Set(c)
+	}
+
+	return r
+}
@@ -0,0 +1,140 @@
+// Copyright (c) 2023–present Bartłomiej Krukowski
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is furnished
+// to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package exporter_test
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/gontainer/exporter"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCoerceYAML(t *testing.T) {
+	t.Parallel()
+
+	input := map[string]any{
+		"debug":   "yes",
+		"verbose": "Off",
+		"port":    "8080",
+		"name":    "no name",
+		"flags":   []any{"on", "N", []string{"yes"}, nil, 1},
+		"labels":  map[string]string{"enabled": "yes"},
+		"nested":  map[any]any{"yes": "y"},
+	}
+
+	assert.Equal(
+		t,
+		`map[string]interface{}{"debug": "yes", "flags": []interface{}{"on", "N", []string{"yes"}, nil, int(1)}, `+
+			`"labels": map[string]string{"enabled": "yes"}, "name": "no name", `+
+			`"nested": map[interface{}]interface{}{"yes": "y"}, "port": "8080", "verbose": "Off"}`,
+		exporter.MustExport(exporter.CoerceYAML(input, exporter.YAMLCoercion{})),
+	)
+
+	assert.Equal(
+		t,
+		`map[string]interface{}{"debug": true, "flags": []interface{}{true, false, []string{"yes"}, nil, int(1)}, `+
+			`"labels": map[string]string{"enabled": "yes"}, "name": "no name", `+
+			`"nested": map[interface{}]interface{}{"yes": true}, "port": "8080", "verbose": false}`,
+		exporter.MustExport(exporter.CoerceYAML(input, exporter.YAMLCoercion{Bools: true})),
+	)
+
+	// the original value is not changed
+	assert.Equal(t, "yes", input["debug"])
+	assert.Nil(t, exporter.CoerceYAML(nil, exporter.YAMLCoercion{Bools: true}))
+	assert.Equal(t, true, exporter.CoerceYAML("YES", exporter.YAMLCoercion{Bools: true}))
+}
+
+func TestExportYAML(t *testing.T) {
+	t.Parallel()
+
+	const input = "parameters:\n  debug: yes\n  ports: [8080, 8081]\n  ratio: 0.5\n  since: 2023-03-01\n  host: ~\n"
+
+	//nolint:exhaustruct
+	scenarios := []struct {
+		name    string
+		input   string
+		output  string
+		error   string
+		options []exporter.Option
+	}{
+		{
+			name:  "Default",
+			input: input,
+			output: `map[string]interface{}{"parameters": map[string]interface{}{"debug": "yes", "host": nil, ` +
+				`"ports": []interface{}{int(8080), int(8081)}, "ratio": float64(0.5), ` +
+				`"since": time.Date(2023, time.March, 1, 0, 0, 0, 0, time.UTC)}}`,
+		},
+		{
+			name:  "Coercion",
+			input: input,
+			output: `map[string]interface{}{"parameters": map[string]interface{}{"debug": true, "host": nil, ` +
+				`"ports": []interface{}{int(8080), int(8081)}, "ratio": float64(0.5), ` +
+				`"since": time.Date(2023, time.March, 1, 0, 0, 0, 0, time.UTC)}}`,
+			options: []exporter.Option{exporter.WithYAMLCoercion(exporter.YAMLCoercion{Bools: true})},
+		},
+		{
+			name:   "Keys other than strings",
+			input:  "1: one\ntwo: 2\n",
+			output: `map[interface{}]interface{}{"two": int(2), int(1): "one"}`,
+		},
+		{
+			name:  "Anchors",
+			input: "base: &base {port: 80}\nprod: *base\n",
+			output: `map[string]interface{}{"base": map[string]interface{}{"port": int(80)}, ` +
+				`"prod": map[string]interface{}{"port": int(80)}}`,
+		},
+		{
+			name:  "Invalid YAML",
+			input: "a: [",
+			error: "cannot decode YAML: yaml: line 1: did not find expected node content",
+		},
+		{
+			name:  "Empty document",
+			input: "",
+			error: "cannot decode YAML: the document is empty",
+		},
+		{
+			name:  "Many documents",
+			input: "a: 1\n---\nb: 2\n",
+			error: "cannot decode YAML: unexpected document after the first one",
+		},
+	}
+
+	for _, s := range scenarios {
+		s := s
+
+		t.Run(s.name, func(t *testing.T) {
+			t.Parallel()
+
+			output, err := exporter.ExportYAML(strings.NewReader(s.input), s.options...)
+			if s.error != "" {
+				assert.EqualError(t, err, s.error)
+				assert.Empty(t, output)
+
+				return
+			}
+
+			assert.NoError(t, err)
+			assert.Equal(t, s.output, output)
+		})
+	}
+}